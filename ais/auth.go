@@ -28,8 +28,11 @@ import (
 type (
 	// TokenList is a list of tokens pushed by authn
 	TokenList struct {
-		Tokens  []string `json:"tokens"`
-		Version int64    `json:"version,string"`
+		Tokens []string `json:"tokens"`
+		// current permissions of the users that own S3 keys; when not nil,
+		// replaces the previous list (an empty one means "no S3 users")
+		S3Users []*cmn.AuthToken `json:"s3_users"`
+		Version int64            `json:"version,string"`
 	}
 
 	authList map[string]*cmn.AuthToken
@@ -41,7 +44,9 @@ type (
 		// list of invalid tokens(revoked or of deleted users)
 		// Authn sends these tokens to primary for broadcasting
 		revokedTokens map[string]bool
-		version       int64
+		// permissions of S3 users: S3 access keys carry only user ID
+		s3Users map[string]*cmn.AuthToken
+		version int64
 	}
 )
 
@@ -79,6 +84,12 @@ func (a *authManager) updateRevokedList(tokens *TokenList) {
 		a.revokedTokens[token] = true
 		delete(a.tokens, token)
 	}
+	if tokens.S3Users != nil {
+		a.s3Users = make(map[string]*cmn.AuthToken, len(tokens.S3Users))
+		for _, user := range tokens.S3Users {
+			a.s3Users[user.UserID] = user
+		}
+	}
 	// clean up the list from obsolete data
 	for token := range a.revokedTokens {
 		rec, err := a.extractTokenData(token)
@@ -108,6 +119,29 @@ func (a *authManager) validateToken(token string) (ar *cmn.AuthToken, err error)
 	return
 }
 
+// Checks if S3 access key is valid and returns the current permissions
+// of the key owner. The key is rejected if AuthN has not pushed the owner's
+// permissions (e.g., the user was deleted).
+func (a *authManager) validateS3Key(accessKey string) (*cmn.AuthToken, error) {
+	a.Lock()
+	defer a.Unlock()
+	if _, ok := a.revokedTokens[accessKey]; ok {
+		return nil, cmn.ErrInvalidToken
+	}
+	key, err := a.extractTokenData(accessKey)
+	if err != nil {
+		return nil, err
+	}
+	if !key.S3 {
+		return nil, cmn.ErrInvalidToken
+	}
+	user, ok := a.s3Users[key.UserID]
+	if !ok {
+		return nil, fmt.Errorf("no permissions for S3 user %q", key.UserID)
+	}
+	return user, nil
+}
+
 // Decrypts token and returns information about a user for whom the token
 // was issued. Return error is the token expired or does not include all
 // mandatory fields
@@ -143,6 +177,12 @@ func (a *authManager) revokedTokenList() *TokenList {
 		Tokens:  make([]string, len(a.revokedTokens)),
 		Version: a.version,
 	}
+	if a.s3Users != nil {
+		tlist.S3Users = make([]*cmn.AuthToken, 0, len(a.s3Users))
+		for _, user := range a.s3Users {
+			tlist.S3Users = append(tlist.S3Users, user)
+		}
+	}
 
 	idx := 0
 	for token := range a.revokedTokens {
@@ -0,0 +1,163 @@
+// Package azcompat provides Azure Blob Storage compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package azcompat
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+type (
+	// List blobs response
+	BlobList struct {
+		XMLName       xml.Name `xml:"EnumerationResults"`
+		Endpoint      string   `xml:"ServiceEndpoint,attr"`
+		ContainerName string   `xml:"ContainerName,attr"`
+		Prefix        string   `xml:"Prefix,omitempty"`
+		Marker        string   `xml:"Marker,omitempty"`
+		MaxResults    int      `xml:"MaxResults"`
+		Delimiter     string   `xml:"Delimiter,omitempty"`
+		Blobs         Blobs    `xml:"Blobs"`
+		NextMarker    string   `xml:"NextMarker"`
+	}
+	Blobs struct {
+		Blobs    []*Blob       `xml:"Blob"`
+		Prefixes []*BlobPrefix `xml:"BlobPrefix"` // "directories" (when delimiter is defined)
+	}
+	Blob struct {
+		Name  string    `xml:"Name"`
+		Props BlobProps `xml:"Properties"`
+	}
+	BlobProps struct {
+		LastModified  string `xml:"Last-Modified"`
+		ETag          string `xml:"Etag"`
+		ContentLength int64  `xml:"Content-Length"`
+		ContentType   string `xml:"Content-Type"`
+		BlobType      string `xml:"BlobType"`
+		LeaseStatus   string `xml:"LeaseStatus"`
+		LeaseState    string `xml:"LeaseState"`
+	}
+	BlobPrefix struct {
+		Name string `xml:"Name"`
+	}
+)
+
+func FillMsgFromAzQuery(query url.Values, msg *cmn.SelectMsg) {
+	msg.PageSize = defaultMaxResults
+	if n, err := strconv.Atoi(query.Get(URLParamMaxResults)); err == nil && n > 0 && n < defaultMaxResults {
+		msg.PageSize = uint(n)
+	}
+	msg.Prefix = query.Get(URLParamPrefix)
+	msg.Delimiter = query.Get(URLParamDelimiter)
+	// Azure marker is opaque: it is AIS continuation token returned by the previous call
+	msg.ContinuationToken = query.Get(URLParamMarker)
+}
+
+func NewBlobList(endpoint, container string, query url.Values) *BlobList {
+	r := &BlobList{
+		Endpoint:      endpoint,
+		ContainerName: container,
+		Prefix:        query.Get(URLParamPrefix),
+		Marker:        query.Get(URLParamMarker),
+		Delimiter:     query.Get(URLParamDelimiter),
+		MaxResults:    defaultMaxResults,
+		Blobs:         Blobs{Blobs: make([]*Blob, 0), Prefixes: make([]*BlobPrefix, 0)},
+	}
+	if n, err := strconv.Atoi(query.Get(URLParamMaxResults)); err == nil && n > 0 && n < defaultMaxResults {
+		r.MaxResults = n
+	}
+	return r
+}
+
+// NOTE: expects entries' access times formatted as RFC3339 (see cmn.SelectMsg.TimeFormat).
+func (r *BlobList) FillFromAisBckList(bckList *cmn.BucketList) {
+	r.NextMarker = bckList.ContinuationToken
+	for _, entry := range bckList.Entries {
+		if entry.IsDir() {
+			r.Blobs.Prefixes = append(r.Blobs.Prefixes, &BlobPrefix{Name: entry.Name})
+			continue
+		}
+		var atime time.Time // zero if the object was not accessed yet
+		if entry.Atime != "" {
+			atime, _ = time.Parse(time.RFC3339, entry.Atime)
+		}
+		etag := timeETag(atime.UnixNano())
+		if entry.Checksum != "" {
+			etag = makeETag(entry.Checksum)
+		}
+		r.Blobs.Blobs = append(r.Blobs.Blobs, &Blob{
+			Name: entry.Name,
+			Props: BlobProps{
+				LastModified:  FormatTime(atime),
+				ETag:          etag,
+				ContentLength: entry.Size,
+				ContentType:   cmn.ContentBinary,
+				BlobType:      BlobTypeBlock,
+				LeaseStatus:   leaseUnlocked,
+				LeaseState:    leaseAvailable,
+			},
+		})
+	}
+}
+
+func (r *BlobList) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// SetBlobHeaders sets response headers of GetBlob and GetBlobProperties requests.
+func SetBlobHeaders(header http.Header, lom *cluster.LOM) {
+	SetPutBlobHeaders(header, lom)
+	header.Set(cmn.HeaderContentType, cmn.ContentBinary)
+	header.Set(cmn.HeaderAcceptRanges, "bytes")
+	header.Set(HeaderBlobType, BlobTypeBlock)
+	header.Set(headerLeaseStatus, leaseUnlocked)
+	header.Set(headerLeaseState, leaseAvailable)
+	header.Set(headerEncrypted, "false")
+	for k, v := range lom.UserMD() {
+		header.Set(headerMetaPrefix+k, v)
+	}
+}
+
+// SetPutBlobHeaders sets response headers of PutBlob request.
+func SetPutBlobHeaders(header http.Header, lom *cluster.LOM) {
+	etag := timeETag(lom.AtimeUnix())
+	if cksum := lom.Cksum(); !cksum.IsEmpty() {
+		etag = makeETag(cksum.Value())
+		if cksum.Type() == cmn.ChecksumMD5 {
+			if b, err := hex.DecodeString(cksum.Value()); err == nil {
+				header.Set(headerContentMD5, base64.StdEncoding.EncodeToString(b))
+			}
+		}
+	}
+	header.Set(headerETag, etag)
+	header.Set(headerLastModified, FormatTime(lom.Atime()))
+	header.Set(headerReqEncrypted, "false")
+}
+
+// UserMDFromHeader returns user-defined metadata (`x-ms-meta-*` headers) of the blob.
+func UserMDFromHeader(header http.Header) (userMD cmn.SimpleKVs, err error) {
+	for k, v := range header {
+		if !strings.HasPrefix(k, headerMetaPrefix) || len(v) == 0 {
+			continue
+		}
+		if userMD == nil {
+			userMD = make(cmn.SimpleKVs, 4)
+		}
+		userMD[strings.ToLower(k[len(headerMetaPrefix):])] = v[0]
+	}
+	err = cmn.ValidateObjUserMD(userMD, nil)
+	return
+}
@@ -0,0 +1,74 @@
+// Package azcompat provides Azure Blob Storage compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package azcompat
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestFillMsgFromAzQuery(t *testing.T) {
+	query := url.Values{}
+	query.Set(URLParamPrefix, "a/")
+	query.Set(URLParamDelimiter, "/")
+	query.Set(URLParamMarker, "a/b")
+	query.Set(URLParamMaxResults, "10")
+	msg := &cmn.SelectMsg{}
+	FillMsgFromAzQuery(query, msg)
+	tassert.Errorf(t, msg.Prefix == "a/" && msg.Delimiter == "/" && msg.ContinuationToken == "a/b" &&
+		msg.PageSize == 10, "unexpected message: %+v", msg)
+
+	query.Set(URLParamMaxResults, "100000")
+	FillMsgFromAzQuery(query, msg)
+	tassert.Errorf(t, msg.PageSize == defaultMaxResults, "expected page size %d, got %d",
+		defaultMaxResults, msg.PageSize)
+}
+
+func TestBlobList(t *testing.T) {
+	query := url.Values{}
+	query.Set(URLParamDelimiter, "/")
+	bckList := &cmn.BucketList{
+		Entries: []*cmn.BucketEntry{
+			{Name: "a", Size: 10, Checksum: "abc", Atime: "2020-12-08T11:25:00Z"},
+			{Name: "b/", Flags: cmn.EntryIsDir},
+			{Name: "c", Size: 1},
+		},
+		ContinuationToken: "c",
+	}
+	list := NewBlobList("http://localhost:8080/az/", "bck", query)
+	list.FillFromAisBckList(bckList)
+	tassert.Fatalf(t, len(list.Blobs.Blobs) == 2 && len(list.Blobs.Prefixes) == 1,
+		"unexpected blobs: %+v", list.Blobs)
+	tassert.Errorf(t, list.NextMarker == "c", "expected next marker %q, got %q", "c", list.NextMarker)
+
+	blob := list.Blobs.Blobs[0]
+	tassert.Errorf(t, blob.Props.ETag == "\"abc\"", "unexpected ETag %s", blob.Props.ETag)
+	tassert.Errorf(t, blob.Props.LastModified == "Tue, 08 Dec 2020 11:25:00 GMT",
+		"unexpected Last-Modified %s", blob.Props.LastModified)
+
+	out := string(list.MustMarshal())
+	for _, s := range []string{
+		`<EnumerationResults ServiceEndpoint="http://localhost:8080/az/" ContainerName="bck">`,
+		`<BlobPrefix><Name>b/</Name></BlobPrefix>`,
+		`<Content-Length>10</Content-Length>`,
+		`<NextMarker>c</NextMarker>`,
+	} {
+		tassert.Errorf(t, strings.Contains(out, s), "%q not found in %s", s, out)
+	}
+}
+
+func TestUserMDFromHeader(t *testing.T) {
+	hdr := make(http.Header)
+	hdr.Set("x-ms-meta-Color", "red")
+	hdr.Set("x-ms-blob-type", BlobTypeBlock)
+	userMD, err := UserMDFromHeader(hdr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(userMD) == 1 && userMD["color"] == "red", "unexpected user metadata: %v", userMD)
+}
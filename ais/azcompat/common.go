@@ -0,0 +1,77 @@
+// Package azcompat provides Azure Blob Storage compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package azcompat
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// NOTE: AIS buckets are presented as Azure containers of a single storage
+// account; the account endpoint is the proxy URL followed by `/az`, e.g.
+// `http://10.10.0.1:8080/az`.
+
+const (
+	// URL query parameters
+	URLParamRestype    = "restype"
+	URLParamComp       = "comp"
+	URLParamPrefix     = "prefix"
+	URLParamDelimiter  = "delimiter"
+	URLParamMarker     = "marker"
+	URLParamMaxResults = "maxresults"
+	URLParamSignature  = "sig" // SAS
+
+	RestypeContainer = "container"
+	CompList         = "list"
+
+	// Headers
+	HeaderVersion      = "x-ms-version"
+	HeaderRequestID    = "x-ms-request-id"
+	HeaderBlobType     = "x-ms-blob-type"
+	HeaderRange        = "x-ms-range"
+	HeaderErrorCode    = "x-ms-error-code"
+	headerMetaPrefix   = "X-Ms-Meta-" // canonical form
+	headerContentMD5   = "Content-MD5"
+	headerETag         = "ETag"
+	headerLastModified = "Last-Modified"
+	headerLeaseStatus  = "x-ms-lease-status"
+	headerLeaseState   = "x-ms-lease-state"
+	headerEncrypted    = "x-ms-server-encrypted"
+	headerReqEncrypted = "x-ms-request-server-encrypted"
+
+	apiVersion        = "2019-12-12"
+	BlobTypeBlock     = "BlockBlob" // the only supported blob type
+	leaseUnlocked     = "unlocked"
+	leaseAvailable    = "available"
+	defaultMaxResults = 5000
+
+	// Error codes
+	ErrAuthFailed   = "AuthenticationFailed"
+	ErrPermMismatch = "AuthorizationPermissionMismatch"
+)
+
+// SetCommonHeaders sets headers that Azure returns in every response.
+func SetCommonHeaders(header http.Header) {
+	header.Set(HeaderVersion, apiVersion)
+	header.Set(HeaderRequestID, cmn.GenUUID())
+}
+
+// IsSharedKeyOrSAS returns true if the request is authorized with a shared key
+// or with a SAS token: AIS supports neither.
+func IsSharedKeyOrSAS(r *http.Request) bool {
+	auth := r.Header.Get(cmn.HeaderAuthorization)
+	return strings.HasPrefix(auth, "SharedKey") || r.URL.Query().Get(URLParamSignature) != ""
+}
+
+func FormatTime(t time.Time) string { return t.UTC().Format(http.TimeFormat) }
+
+// Azure ETag is an opaque quoted string.
+func makeETag(s string) string { return "\"" + s + "\"" }
+
+func timeETag(unixnano int64) string { return makeETag(fmt.Sprintf("0x%X", unixnano)) }
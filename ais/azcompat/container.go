@@ -0,0 +1,73 @@
+// Package azcompat provides Azure Blob Storage compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package azcompat
+
+import (
+	"encoding/xml"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+type (
+	// List containers response
+	ContainerList struct {
+		XMLName    xml.Name     `xml:"EnumerationResults"`
+		Endpoint   string       `xml:"ServiceEndpoint,attr"`
+		Prefix     string       `xml:"Prefix,omitempty"`
+		MaxResults int          `xml:"MaxResults,omitempty"`
+		Containers []*Container `xml:"Containers>Container"`
+		NextMarker string       `xml:"NextMarker"`
+	}
+	Container struct {
+		Name  string         `xml:"Name"`
+		Props ContainerProps `xml:"Properties"`
+	}
+	ContainerProps struct {
+		LastModified string `xml:"Last-Modified"`
+		ETag         string `xml:"Etag"`
+		LeaseStatus  string `xml:"LeaseStatus"`
+		LeaseState   string `xml:"LeaseState"`
+	}
+)
+
+func NewContainerList(endpoint, prefix string) *ContainerList {
+	return &ContainerList{
+		Endpoint:   endpoint,
+		Prefix:     prefix,
+		Containers: make([]*Container, 0),
+	}
+}
+
+func (r *ContainerList) Add(bck *cluster.Bck) {
+	r.Containers = append(r.Containers, &Container{
+		Name: bck.Name,
+		Props: ContainerProps{
+			LastModified: FormatTime(time.Unix(0, bck.Props.Created)),
+			ETag:         timeETag(bck.Props.Created),
+			LeaseStatus:  leaseUnlocked,
+			LeaseState:   leaseAvailable,
+		},
+	})
+}
+
+func (r *ContainerList) MustMarshal() []byte {
+	// Azure always lists containers in lexicographical order
+	sort.Slice(r.Containers, func(i, j int) bool { return r.Containers[i].Name < r.Containers[j].Name })
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// SetContainerHeaders sets response headers of GetContainerProperties request.
+func SetContainerHeaders(header http.Header, bck *cluster.Bck) {
+	header.Set(headerLastModified, FormatTime(time.Unix(0, bck.Props.Created)))
+	header.Set(headerETag, timeETag(bck.Props.Created))
+	header.Set(headerLeaseStatus, leaseUnlocked)
+	header.Set(headerLeaseState, leaseAvailable)
+}
@@ -18,6 +18,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tracing"
 )
 
 type (
@@ -422,7 +423,7 @@ func (m *AISBackendProvider) HeadObj(_ context.Context, lom *cluster.LOM) (objMe
 	return objMeta, 0, nil
 }
 
-func (m *AISBackendProvider) GetObj(_ context.Context, lom *cluster.LOM) (errCode int, err error) {
+func (m *AISBackendProvider) GetObj(ctx context.Context, lom *cluster.LOM) (errCode int, err error) {
 	remoteBck := lom.Bucket()
 	aisCluster, err := m.remoteCluster(remoteBck.Ns.UUID)
 	if err != nil {
@@ -430,7 +431,7 @@ func (m *AISBackendProvider) GetObj(_ context.Context, lom *cluster.LOM) (errCod
 	}
 
 	bck := prepareBck(remoteBck)
-	r, err := api.GetObjectReader(aisCluster.bp, bck, lom.ObjName)
+	r, err := api.GetObjectReader(aisCluster.bp, bck, lom.ObjName, getObjectInput(ctx)...)
 	if err != nil {
 		return extractErrCode(err)
 	}
@@ -444,7 +445,7 @@ func (m *AISBackendProvider) GetObj(_ context.Context, lom *cluster.LOM) (errCod
 	return extractErrCode(err)
 }
 
-func (m *AISBackendProvider) GetObjReader(_ context.Context, lom *cluster.LOM) (r io.ReadCloser, expectedCksm *cmn.Cksum,
+func (m *AISBackendProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r io.ReadCloser, expectedCksm *cmn.Cksum,
 	errCode int, err error) {
 	remoteBck := lom.Bucket()
 	aisCluster, err := m.remoteCluster(remoteBck.Ns.UUID)
@@ -452,11 +453,20 @@ func (m *AISBackendProvider) GetObjReader(_ context.Context, lom *cluster.LOM) (
 		return nil, nil, errCode, err
 	}
 
-	r, err = api.GetObjectReader(aisCluster.bp, remoteBck, lom.ObjName)
+	r, err = api.GetObjectReader(aisCluster.bp, remoteBck, lom.ObjName, getObjectInput(ctx)...)
 	errCode, err = extractErrCode(err)
 	return r, nil, errCode, err
 }
 
+// propagates the trace context (if any) to the remote cluster
+func getObjectInput(ctx context.Context) []api.GetObjectInput {
+	tp := tracing.TraceParent(ctx)
+	if tp == "" {
+		return nil
+	}
+	return []api.GetObjectInput{{Header: http.Header{cmn.HeaderTraceParent: []string{tp}}}}
+}
+
 func (m *AISBackendProvider) PutObj(_ context.Context, r io.Reader, lom *cluster.LOM) (version string, errCode int, err error) {
 	remoteBck := lom.Bucket()
 	aisCluster, err := m.remoteCluster(remoteBck.Ns.UUID)
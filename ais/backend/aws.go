@@ -447,6 +447,9 @@ func (awsp *awsProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r
 	}
 	lom.SetCksum(cksum)
 	lom.SetCustomMD(customMD)
+	if obj.LastModified != nil {
+		lom.SetLastModifiedUnix(obj.LastModified.UnixNano())
+	}
 	setSize(ctx, *obj.ContentLength)
 	return wrapReader(ctx, obj.Body), expectedCksm, 0, nil
 }
@@ -396,6 +396,9 @@ func (ap *azureProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (re
 	}
 
 	lom.SetCustomMD(customMD)
+	if mtime := respProps.LastModified(); !mtime.IsZero() {
+		lom.SetLastModifiedUnix(mtime.UnixNano())
+	}
 	setSize(ctx, resp.ContentLength())
 
 	return wrapReader(ctx, resp.Body(retryOpts)), cksumToUse, 0, nil
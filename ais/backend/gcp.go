@@ -379,6 +379,9 @@ func (gcpp *gcpProvider) GetObjReader(ctx context.Context,
 
 	lom.SetCksum(cksum)
 	lom.SetCustomMD(customMD)
+	if !attrs.Updated.IsZero() {
+		lom.SetLastModifiedUnix(attrs.Updated.UnixNano())
+	}
 	setSize(ctx, rc.Attrs.Size)
 	r = wrapReader(ctx, rc)
 	return
@@ -261,6 +261,7 @@ func (hp *hdfsProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r i
 	}
 
 	lom.SetCustomMD(customMD)
+	lom.SetLastModifiedUnix(fr.Stat().ModTime().UnixNano())
 	setSize(ctx, fr.Stat().Size())
 	return wrapReader(ctx, fr), nil, 0, nil
 }
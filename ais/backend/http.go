@@ -17,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/cmn/debug"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tracing"
 )
 
 type (
@@ -194,7 +195,12 @@ func (hp *httpProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r i
 		glog.Infof("[HTTP CLOUD][GET] original_url: %q", origURL)
 	}
 
-	resp, err := hp.client(origURL).Get(origURL) // nolint:bodyclose // is closed by the caller
+	req, err := http.NewRequest(http.MethodGet, origURL, nil)
+	if err != nil {
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	tracing.Inject(ctx, req.Header)
+	resp, err := hp.client(origURL).Do(req) // nolint:bodyclose // is closed by the caller
 	if err != nil {
 		return nil, nil, http.StatusInternalServerError, err
 	}
@@ -0,0 +1,424 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// POSIX backend: a directory tree on a filesystem that is shared by all targets
+// (NFS, Lustre, or a plain local directory in the single-node case). Each
+// sub-directory of the configured root is a bucket, and each regular file
+// underneath is an object named by its path relative to the bucket directory.
+//
+// There is no notion of object versions in POSIX; instead, the file's mtime is
+// used as the object version, so that (cached) objects that get modified on the
+// filer are detected as such (see `validate_warm_get` bucket property).
+
+const (
+	posixTmpPrefix = ".ais-tmp." // PUT in progress (see PutObj)
+)
+
+type (
+	posixProvider struct {
+		t    cluster.Target
+		root string
+	}
+	posixWalk struct {
+		msg     *cmn.SelectMsg
+		marker  string
+		bckList *cmn.BucketList
+	}
+)
+
+// interface guard
+var _ cluster.BackendProvider = (*posixProvider)(nil)
+
+func NewPOSIX(t cluster.Target, config *cmn.Config) (cluster.BackendProvider, error) {
+	providerConf, ok := config.Backend.ProviderConf(cmn.ProviderPOSIX)
+	cmn.Assert(ok)
+	posixConf := providerConf.(cmn.BackendConfPOSIX)
+
+	fi, err := os.Stat(posixConf.Root)
+	if err != nil {
+		return nil, fmt.Errorf("failed to stat %q backend root (check that the filesystem is mounted), err: %v",
+			cmn.ProviderPOSIX, err)
+	}
+	if !fi.IsDir() {
+		return nil, fmt.Errorf("%q backend root %q is not a directory", cmn.ProviderPOSIX, posixConf.Root)
+	}
+	return &posixProvider{t: t, root: posixConf.Root}, nil
+}
+
+func (pp *posixProvider) posixErrorToAISError(err error) (int, error) {
+	if os.IsNotExist(err) {
+		return http.StatusNotFound, err
+	}
+	if os.IsExist(err) {
+		return http.StatusConflict, err
+	}
+	if os.IsPermission(err) {
+		return http.StatusForbidden, err
+	}
+	return http.StatusBadRequest, err
+}
+
+func (pp *posixProvider) bckDir(bck *cmn.Bck) string { return filepath.Join(pp.root, bck.Name) }
+
+// returns the file path of the object making sure that it does not escape the bucket
+func (pp *posixProvider) objPath(lom *cluster.LOM) (string, error) {
+	var (
+		bckDir   = pp.bckDir(lom.Bck().RemoteBck())
+		filePath = filepath.Join(bckDir, lom.ObjName)
+	)
+	if !strings.HasPrefix(filePath, bckDir+string(filepath.Separator)) ||
+		strings.HasPrefix(filepath.Base(filePath), posixTmpPrefix) {
+		return "", fmt.Errorf("invalid object name %q", lom.ObjName)
+	}
+	return filePath, nil
+}
+
+func (pp *posixProvider) statObj(lom *cluster.LOM) (filePath string, fi os.FileInfo, errCode int, err error) {
+	if filePath, err = pp.objPath(lom); err != nil {
+		return "", nil, http.StatusBadRequest, err
+	}
+	if fi, err = os.Stat(filePath); err != nil {
+		if os.IsNotExist(err) {
+			errCode, err = pp.checkBucket(lom.Bck().RemoteBck())
+			if err == nil {
+				errCode, err = http.StatusNotFound, cmn.NewNotFoundError("%s/%s", lom.Bck().RemoteBck(), lom.ObjName)
+			}
+			return
+		}
+		errCode, err = pp.posixErrorToAISError(err)
+		return
+	}
+	if !fi.Mode().IsRegular() {
+		return "", nil, http.StatusNotFound, cmn.NewNotFoundError("%s/%s", lom.Bck().RemoteBck(), lom.ObjName)
+	}
+	return
+}
+
+func posixVersion(fi os.FileInfo) string { return strconv.FormatInt(fi.ModTime().UnixNano(), 10) }
+
+func (pp *posixProvider) Provider() string  { return cmn.ProviderPOSIX }
+func (pp *posixProvider) MaxPageSize() uint { return 10000 }
+
+///////////////////
+// CREATE BUCKET //
+///////////////////
+
+func (pp *posixProvider) CreateBucket(ctx context.Context, bck *cluster.Bck) (errCode int, err error) {
+	if err = cmn.CreateDir(pp.bckDir(bck.RemoteBck())); err != nil {
+		errCode, err = pp.posixErrorToAISError(err)
+	}
+	return
+}
+
+/////////////////
+// HEAD BUCKET //
+/////////////////
+
+func (pp *posixProvider) checkBucket(bck *cmn.Bck) (errCode int, err error) {
+	fi, err := os.Stat(pp.bckDir(bck))
+	if err != nil {
+		if os.IsNotExist(err) {
+			return http.StatusNotFound, cmn.NewErrorRemoteBucketDoesNotExist(*bck)
+		}
+		return pp.posixErrorToAISError(err)
+	}
+	if !fi.IsDir() {
+		return http.StatusNotFound, cmn.NewErrorRemoteBucketDoesNotExist(*bck)
+	}
+	return 0, nil
+}
+
+func (pp *posixProvider) HeadBucket(ctx context.Context, bck *cluster.Bck) (bckProps cmn.SimpleKVs, errCode int, err error) {
+	if errCode, err = pp.checkBucket(bck.RemoteBck()); err != nil {
+		return
+	}
+	bckProps = make(cmn.SimpleKVs, 2)
+	bckProps[cmn.HeaderBackendProvider] = cmn.ProviderPOSIX
+	bckProps[cmn.HeaderBucketVerEnabled] = "true"
+	return
+}
+
+//////////////////
+// LIST OBJECTS //
+//////////////////
+
+func (pp *posixProvider) ListObjects(ctx context.Context, bck *cluster.Bck, msg *cmn.SelectMsg) (bckList *cmn.BucketList, errCode int, err error) {
+	msg.PageSize = calcPageSize(msg.PageSize, pp.MaxPageSize())
+
+	cloudBck := bck.RemoteBck()
+	if errCode, err = pp.checkBucket(cloudBck); err != nil {
+		return
+	}
+	w := &posixWalk{
+		msg:     msg,
+		marker:  msg.ContinuationToken,
+		bckList: &cmn.BucketList{Entries: make([]*cmn.BucketEntry, 0, msg.PageSize)},
+	}
+	if msg.StartAfter > w.marker {
+		w.marker = msg.StartAfter
+	}
+	if err = w.walk(pp.bckDir(cloudBck), ""); err != nil {
+		errCode, err = pp.posixErrorToAISError(err)
+		return nil, errCode, err
+	}
+	bckList = w.bckList
+	// Set continuation token only if we reached the page size.
+	if uint(len(bckList.Entries)) >= msg.PageSize {
+		bckList.ContinuationToken = bckList.Entries[len(bckList.Entries)-1].Name
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[list_bucket] %s: %d objects", cloudBck, len(bckList.Entries))
+	}
+	return
+}
+
+func (w *posixWalk) done() bool { return uint(len(w.bckList.Entries)) >= w.msg.PageSize }
+
+// Walks the directory in the lexicographical order of the resulting object
+// names which is what makes the continuation token (the last listed name) work.
+// NOTE: `filepath.Walk` won't do: it visits "a/b" before "a.b" while "a.b" < "a/b".
+func (w *posixWalk) walk(dir, relDir string) error {
+	fis, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return err
+	}
+	sort.Slice(fis, func(i, j int) bool { return posixSortKey(fis[i]) < posixSortKey(fis[j]) })
+	for _, fi := range fis {
+		if strings.HasPrefix(fi.Name(), posixTmpPrefix) {
+			continue
+		}
+		objName := path.Join(relDir, fi.Name())
+		if fi.IsDir() {
+			dirName := objName + "/"
+			if !cmn.DirNameContainsPrefix(dirName, w.msg.Prefix) {
+				continue
+			}
+			// the entire subtree precedes the marker
+			if w.marker != "" && dirName <= w.marker && !strings.HasPrefix(w.marker, dirName) {
+				continue
+			}
+			if err := w.walk(filepath.Join(dir, fi.Name()), objName); err != nil {
+				return err
+			}
+			if w.done() {
+				return nil
+			}
+			continue
+		}
+		if !fi.Mode().IsRegular() || !cmn.ObjNameContainsPrefix(objName, w.msg.Prefix) {
+			continue
+		}
+		if w.marker != "" && objName <= w.marker {
+			continue
+		}
+		entry := &cmn.BucketEntry{Name: objName}
+		if w.msg.WantProp(cmn.GetPropsSize) {
+			entry.Size = fi.Size()
+		}
+		if w.msg.WantProp(cmn.GetPropsVersion) {
+			entry.Version = posixVersion(fi)
+		}
+		w.bckList.Entries = append(w.bckList.Entries, entry)
+		if w.done() {
+			return nil
+		}
+	}
+	return nil
+}
+
+func posixSortKey(fi os.FileInfo) string {
+	if fi.IsDir() {
+		return fi.Name() + "/"
+	}
+	return fi.Name()
+}
+
+//////////////////
+// BUCKET NAMES //
+//////////////////
+
+func (pp *posixProvider) ListBuckets(ctx context.Context, query cmn.QueryBcks) (buckets cmn.BucketNames, errCode int, err error) {
+	fis, err := ioutil.ReadDir(pp.root)
+	if err != nil {
+		errCode, err = pp.posixErrorToAISError(err)
+		return
+	}
+	buckets = make(cmn.BucketNames, 0, len(fis))
+	for _, fi := range fis {
+		if !fi.IsDir() {
+			continue
+		}
+		if err := cmn.ValidateBckName(fi.Name()); err != nil {
+			if glog.FastV(4, glog.SmoduleAIS) {
+				glog.Infof("[bucket_names] skipping %q: %v", fi.Name(), err)
+			}
+			continue
+		}
+		buckets = append(buckets, cmn.Bck{Name: fi.Name(), Provider: cmn.ProviderPOSIX})
+	}
+	return
+}
+
+/////////////////
+// HEAD OBJECT //
+/////////////////
+
+func (pp *posixProvider) HeadObj(ctx context.Context, lom *cluster.LOM) (objMeta cmn.SimpleKVs, errCode int, err error) {
+	_, fi, errCode, err := pp.statObj(lom)
+	if err != nil {
+		return nil, errCode, err
+	}
+	objMeta = make(cmn.SimpleKVs, 3)
+	objMeta[cmn.HeaderBackendProvider] = cmn.ProviderPOSIX
+	objMeta[cmn.HeaderObjSize] = strconv.FormatInt(fi.Size(), 10)
+	objMeta[cmn.HeaderObjVersion] = posixVersion(fi)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[head_object] %s", lom)
+	}
+	return
+}
+
+////////////////
+// GET OBJECT //
+////////////////
+
+func (pp *posixProvider) GetObj(ctx context.Context, lom *cluster.LOM) (errCode int, err error) {
+	reader, _, errCode, err := pp.GetObjReader(ctx, lom)
+	if err != nil {
+		return errCode, err
+	}
+	params := cluster.PutObjectParams{
+		Tag:      fs.WorkfileColdget,
+		Reader:   reader,
+		RecvType: cluster.ColdGet,
+	}
+	if err = pp.t.PutObject(lom, params); err != nil {
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[get_object] %s", lom)
+	}
+	return
+}
+
+////////////////////
+// GET OBJ READER //
+////////////////////
+
+func (pp *posixProvider) GetObjReader(ctx context.Context, lom *cluster.LOM) (r io.ReadCloser, expectedCksm *cmn.Cksum, errCode int, err error) {
+	filePath, fi, errCode, err := pp.statObj(lom)
+	if err != nil {
+		return
+	}
+	fh, err := os.Open(filePath)
+	if err != nil {
+		errCode, err = pp.posixErrorToAISError(err)
+		return
+	}
+	version := posixVersion(fi)
+	customMD := cmn.SimpleKVs{
+		cluster.SourceObjMD:  cluster.SourcePOSIXObjMD,
+		cluster.VersionObjMD: version,
+	}
+	lom.SetVersion(version)
+	lom.SetCustomMD(customMD)
+	lom.SetLastModifiedUnix(fi.ModTime().UnixNano())
+	setSize(ctx, fi.Size())
+	return wrapReader(ctx, fh), nil, 0, nil
+}
+
+////////////////
+// PUT OBJECT //
+////////////////
+
+// The object is first written into a temporary file in the destination directory
+// and then renamed, so that readers of the shared filesystem never observe
+// partially written files.
+func (pp *posixProvider) PutObj(ctx context.Context, r io.Reader, lom *cluster.LOM) (version string, errCode int, err error) {
+	filePath, err := pp.objPath(lom)
+	if err != nil {
+		return "", http.StatusBadRequest, err
+	}
+	cloudBck := lom.Bck().RemoteBck()
+	if errCode, err = pp.checkBucket(cloudBck); err != nil {
+		return
+	}
+	var (
+		fi      os.FileInfo
+		tmpPath = filepath.Join(filepath.Dir(filePath), posixTmpPrefix+filepath.Base(filePath)+"."+cmn.GenTie())
+	)
+	fh, err := cmn.CreateFile(tmpPath)
+	if err != nil {
+		goto finish
+	}
+	{
+		buf, slab := pp.t.MMSA().Alloc()
+		_, err = io.CopyBuffer(fh, r, buf)
+		slab.Free(buf)
+	}
+	if err != nil {
+		fh.Close()
+		goto finish
+	}
+	if err = fh.Close(); err != nil {
+		goto finish
+	}
+	if err = os.Rename(tmpPath, filePath); err != nil {
+		goto finish
+	}
+	fi, err = os.Stat(filePath)
+
+finish:
+	if err != nil {
+		if errRm := cmn.RemoveFile(tmpPath); errRm != nil {
+			glog.Errorf("failed to remove %q: %v", tmpPath, errRm)
+		}
+		errCode, err = pp.posixErrorToAISError(err)
+		return "", errCode, err
+	}
+	version = posixVersion(fi)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[put_object] %s, version %s", lom, version)
+	}
+	return version, 0, nil
+}
+
+///////////////////
+// DELETE OBJECT //
+///////////////////
+
+func (pp *posixProvider) DeleteObj(ctx context.Context, lom *cluster.LOM) (errCode int, err error) {
+	filePath, _, errCode, err := pp.statObj(lom)
+	if err != nil {
+		return errCode, err
+	}
+	if err := os.Remove(filePath); err != nil {
+		return pp.posixErrorToAISError(err)
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("[delete_object] %s", lom)
+	}
+	return 0, nil
+}
@@ -0,0 +1,60 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestPosixWalk(t *testing.T) {
+	root, err := ioutil.TempDir("", "posix")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(root)
+
+	// NOTE: "a.b" < "a/b" while filepath.Walk visits the directory "a" first
+	for _, name := range []string{"a.b", "a/b", "a/c", posixTmpPrefix + "d.xyz", "a/" + posixTmpPrefix + "b.xyz"} {
+		f, err := cmn.CreateFile(filepath.Join(root, name))
+		tassert.CheckFatal(t, err)
+		cmn.Close(f)
+	}
+	expected := []string{"a.b", "a/b", "a/c"}
+
+	for pageSize := uint(1); pageSize <= 4; pageSize++ {
+		var (
+			names  []string
+			marker string
+		)
+		for {
+			w := &posixWalk{
+				msg:     &cmn.SelectMsg{PageSize: pageSize},
+				marker:  marker,
+				bckList: &cmn.BucketList{},
+			}
+			tassert.CheckFatal(t, w.walk(root, ""))
+			for _, e := range w.bckList.Entries {
+				names = append(names, e.Name)
+			}
+			if !w.done() {
+				break
+			}
+			marker = w.bckList.Entries[len(w.bckList.Entries)-1].Name // (continuation token)
+		}
+		tassert.Errorf(t, strings.Join(names, ",") == strings.Join(expected, ","),
+			"page size %d: expected %v, got %v", pageSize, expected, names)
+	}
+
+	// starting in the middle of the directory "a"
+	w := &posixWalk{msg: &cmn.SelectMsg{PageSize: 10}, marker: "a/b", bckList: &cmn.BucketList{}}
+	tassert.CheckFatal(t, w.walk(root, ""))
+	tassert.Errorf(t, len(w.bckList.Entries) == 1 && w.bckList.Entries[0].Name == "a/c",
+		"expected [a/c] after marker %q, got %+v", w.marker, w.bckList.Entries)
+}
@@ -0,0 +1,61 @@
+// Package backend contains implementation of various backend providers.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package backend
+
+import (
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Registry of 3rd party backend providers' constructors. A backend implementation
+// (built-in or in-house) plugs into AIS as follows:
+//
+// 1. cmn.RegisterProvider - makes the provider's name (and URI schemes) known to
+//    AIS and (optionally) validates the provider's `backend.conf` config section;
+// 2. backend.Register - registers the constructor that the target calls at startup
+//    for each provider configured in `backend.conf`.
+//
+// Both must be called from `init()`. The provider's parsed config section is
+// available to the constructor via `config.Backend.ProviderConf(provider)`.
+
+type Factory func(t cluster.Target, config *cmn.Config) (cluster.BackendProvider, error)
+
+var factories = make(map[string]Factory, 8)
+
+func init() {
+	Register(cmn.ProviderAmazon, func(t cluster.Target, _ *cmn.Config) (cluster.BackendProvider, error) {
+		return NewAWS(t)
+	})
+	Register(cmn.ProviderAzure, func(t cluster.Target, _ *cmn.Config) (cluster.BackendProvider, error) {
+		return NewAzure(t)
+	})
+	Register(cmn.ProviderGoogle, func(t cluster.Target, _ *cmn.Config) (cluster.BackendProvider, error) {
+		return NewGCP(t)
+	})
+	Register(cmn.ProviderHDFS, func(t cluster.Target, _ *cmn.Config) (cluster.BackendProvider, error) {
+		return NewHDFS(t)
+	})
+	Register(cmn.ProviderPOSIX, NewPOSIX)
+}
+
+// Register adds backend provider's constructor. Must be called from `init()`.
+func Register(provider string, f Factory) {
+	_, ok := cmn.GetProviderInfo(provider)
+	cmn.AssertMsg(ok, "unknown backend provider "+provider+" (see cmn.RegisterProvider)")
+	_, exists := factories[provider]
+	cmn.AssertMsg(!exists, "duplicate backend provider "+provider)
+	factories[provider] = f
+}
+
+// New constructs the named (and configured) backend provider.
+func New(provider string, t cluster.Target, config *cmn.Config) (cluster.BackendProvider, error) {
+	f, ok := factories[provider]
+	if !ok {
+		return nil, fmt.Errorf("backend provider %q is not supported by this build", provider)
+	}
+	return f(t, config)
+}
@@ -387,7 +387,12 @@ func defaultBckProps(args bckPropsArgs) *cmn.BucketProps {
 			skipValidate = true
 		}
 	} else {
-		cmn.Assert(false)
+		// 3rd party non-Cloud provider (see cmn.RegisterProvider)
+		cmn.Assert(args.bck.HasProvider())
+		props.Versioning.Enabled = false
+		if args.hdr != nil {
+			props = mergeRemoteBckProps(props, args.hdr)
+		}
 	}
 
 	if !skipValidate {
@@ -40,6 +40,9 @@ type (
 		role        string // proxy | target
 		confPath    string // path to config
 		daemonID    string // daemon ID to assign
+		zone        string // failure domain: zone
+		rack        string // failure domain: rack
+		weight      string // HRW weight of the target: number or "capacity" (default: none)
 		confCustom  string // "key1=value1,key2=value2" formatted string to override selected entries in config
 		ntargets    int    // expected number of targets in a starting-up cluster (proxy only)
 		skipStartup bool   // determines if the proxy should skip waiting for targets
@@ -112,6 +115,10 @@ func init() {
 	// role aka `DaemonType`
 	flag.StringVar(&daemon.cli.role, "role", "", "role of this AIS daemon: proxy | target")
 	flag.StringVar(&daemon.cli.daemonID, "daemon_id", "", "unique ID to be assigned to the AIS daemon")
+	flag.StringVar(&daemon.cli.zone, "zone", "", "failure domain: zone of the AIS daemon (overrides $"+zoneEnv+")")
+	flag.StringVar(&daemon.cli.rack, "rack", "", "failure domain: rack of the AIS daemon (overrides $"+rackEnv+")")
+	flag.StringVar(&daemon.cli.weight, "weight", "",
+		"HRW weight of the target: positive number or \""+weightCapacity+"\" (overrides $"+weightEnv+"; default: none)")
 
 	// config itself and its command line overrides
 	flag.StringVar(&daemon.cli.confPath, "config", "",
@@ -310,6 +317,7 @@ func initTarget() cmn.Runner {
 	if err := ts.InitCapacity(); err != nil { // goes after fs.Init
 		cmn.ExitLogf("%s", err)
 	}
+	t.si.Weight = initWeight()
 	return t
 }
 
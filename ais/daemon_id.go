@@ -8,15 +8,22 @@ import (
 	"io/ioutil"
 	"os"
 	"path/filepath"
+	"strconv"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/ios"
 )
 
 const (
 	daemonIDEnv  = "AIS_DAEMON_ID"
+	zoneEnv      = "AIS_ZONE"
+	rackEnv      = "AIS_RACK"
+	weightEnv    = "AIS_WEIGHT"
 	proxyIDFname = ".ais.proxy_id"
+
+	weightCapacity = "capacity" // weight the target by the total capacity of its mountpaths
 )
 
 func initDaemonID(daemonType string, config *cmn.Config) (daemonID string) {
@@ -42,6 +49,71 @@ func initDaemonID(daemonType string, config *cmn.Config) (daemonID string) {
 	return daemonID
 }
 
+// Failure domain (zone and rack) of the node - used to spread replicas and
+// EC slices across domains (see cluster.HrwTargetList).
+func initDomain(daemonType string) (zone, rack string) {
+	if zone = daemon.cli.zone; zone == "" {
+		zone = os.Getenv(zoneEnv)
+	}
+	if rack = daemon.cli.rack; rack == "" {
+		rack = os.Getenv(rackEnv)
+	}
+	if zone != "" || rack != "" {
+		glog.Infof("%s failure domain: zone %q, rack %q", daemonType, zone, rack)
+	}
+	return
+}
+
+// HRW weight of the target - determines its share of objects (see cluster.HrwTarget).
+// Weighting is opt-in: the weight is either set administratively or, if requested,
+// derived from the total capacity of the mountpaths. Zero (default) means that
+// the target is not weighted.
+func initWeight() (weight uint64) {
+	value := daemon.cli.weight
+	if value == "" {
+		value = os.Getenv(weightEnv)
+	}
+	switch value {
+	case "":
+		return 0
+	case weightCapacity:
+		return capacityWeight()
+	}
+	weight, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		cmn.ExitLogf("invalid HRW weight %q: %v", value, err)
+	}
+	return
+}
+
+// Returns the total capacity of the mountpaths in GiB rounded to two significant
+// digits - so that targets with nearly the same capacity remain equal.
+func capacityWeight() (weight uint64) {
+	var capacity uint64
+	availablePaths, _ := fs.Get()
+	for _, mpathInfo := range availablePaths {
+		blocks, _, bsize, err := ios.GetFSStats(mpathInfo.Path)
+		if err != nil {
+			glog.Errorf("%s: %v", mpathInfo, err)
+			continue
+		}
+		capacity += blocks * uint64(bsize)
+	}
+	if weight = roundWeight(capacity / cmn.GiB); weight == 0 {
+		weight = 1
+	}
+	glog.Infof("HRW weight %d (total capacity %s)", weight, cmn.B2S(int64(capacity), 2))
+	return
+}
+
+func roundWeight(weight uint64) uint64 {
+	var scale uint64 = 1
+	for weight >= 100 {
+		weight, scale = (weight+5)/10, scale*10
+	}
+	return weight * scale
+}
+
 /////////////
 /// Proxy ///
 /////////////
@@ -33,6 +33,7 @@ import (
 	"github.com/NVIDIA/aistore/cmn/k8s"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/xaction/xreg"
 	jsoniter "github.com/json-iterator/go"
 	"github.com/tinylib/msgp/msgp"
@@ -167,6 +168,7 @@ type (
 		smm                 *memsys.MMSA // system MMSA for small-size allocations
 		electable           electable
 		inPrimaryTransition atomic.Bool
+		rateLimiters        rateLimiters // see cmn.RateLimitConf
 	}
 
 	glogWriter struct{}
@@ -685,7 +687,10 @@ func (h *httprunner) initSI(daemonType string) {
 		intraControlAddr,
 		intraDataAddr,
 	)
+	h.si.Zone, h.si.Rack = initDomain(daemonType)
 	cmn.InitShortID(h.si.Digest())
+	tracing.Init(h.si.ID())
+	h.rateLimiters.init()
 }
 
 func mustDiffer(ip1 cluster.NetInfo, port1 int, use1 bool, ip2 cluster.NetInfo, port2 int, use2 bool, tag string) {
@@ -1205,6 +1210,23 @@ func (h *httprunner) handleWriteError(r *http.Request, tag string, err error) {
 	h.statsT.AddErrorHTTP(r.Method, 1)
 }
 
+// GET /metrics - Prometheus (see stats/prometheus.go)
+func (h *httprunner) metricsHandler(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		cmn.InvalidHandlerWithMsg(w, r, "invalid method for /"+cmn.Metrics+" path")
+		return
+	}
+	running := true
+	xacts, err := xreg.GetStats(xreg.XactFilter{OnlyRunning: &running})
+	if err != nil {
+		glog.Error(err)
+	}
+	w.Header().Set(cmn.HeaderContentType, stats.PromContentType)
+	if err := h.statsT.WritePrometheus(w, xacts); err != nil {
+		h.handleWriteError(r, "metrics", err)
+	}
+}
+
 func (h *httprunner) parseNCopies(value interface{}) (copies int64, err error) {
 	switch v := value.(type) {
 	case string:
@@ -33,6 +33,7 @@ import (
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/stats"
 	"github.com/NVIDIA/aistore/sys"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/xaction"
 	"github.com/NVIDIA/aistore/xaction/xreg"
 	jsoniter "github.com/json-iterator/go"
@@ -147,11 +148,13 @@ func (p *proxyrunner) Run() error {
 
 		{r: cmn.Metasync, h: p.metasyncHandler, net: accessNetIntraControl},
 		{r: cmn.Health, h: p.healthHandler, net: accessNetPublicControl},
+		{r: "/" + cmn.Metrics, h: p.metricsHandler, net: accessNetPublicControl},
 		{r: cmn.Vote, h: p.voteHandler, net: accessNetIntraControl},
 
 		{r: cmn.Notifs, h: p.notifs.handler, net: accessNetIntraControl},
 
 		{r: "/" + cmn.S3, h: p.s3Handler, net: accessNetPublic},
+		{r: "/" + cmn.AZ, h: p.azHandler, net: accessNetPublic},
 
 		{r: "/", h: p.httpCloudHandler, net: accessNetPublic},
 	}
@@ -350,21 +353,34 @@ func (p *proxyrunner) httpbckget(w http.ResponseWriter, r *http.Request) {
 // GET /v1/objects/bucket-name/object-name
 func (p *proxyrunner) httpobjget(w http.ResponseWriter, r *http.Request, origURLBck ...string) {
 	started := time.Now()
+	span := tracing.StartRequest(r, "proxy.get")
+	defer span.End()
 	bckArgs := bckInitArgs{p: p, w: w, r: r, perms: cmn.AccessGET, tryOnlyRem: true}
 	bck, objName, err := p.parseAPIBckObj(w, r, &bckArgs, origURLBck...)
 	if err != nil {
+		span.SetErr(err)
+		return
+	}
+	if !p.allowRequest(w, r, bck) {
 		return
 	}
 
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
+		span.SetErr(err)
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
 	if glog.FastV(4, glog.SmoduleAIS) {
 		glog.Infof("%s %s/%s => %s", r.Method, bck.Name, objName, si)
 	}
+	if span != nil {
+		span.SetAttr("bucket", bck.String())
+		span.SetAttr("object", objName)
+		span.SetAttr("target", si.ID())
+		r = r.WithContext(tracing.NewContext(r.Context(), span))
+	}
 	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
 	http.Redirect(w, r, redirectURL, http.StatusMovedPermanently)
 	p.statsT.Add(stats.GetCount, 1)
@@ -400,6 +416,9 @@ func (p *proxyrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 	if err != nil {
 		return
 	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
 
 	if nodeID == "" {
 		si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
@@ -432,11 +451,18 @@ func (p *proxyrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 // DELETE /v1/objects/bucket-name/object-name
 func (p *proxyrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 	started := time.Now()
-	bckArgs := bckInitArgs{p: p, w: w, r: r, perms: cmn.AccessObjDELETE, tryOnlyRem: true}
+	perms := cmn.AccessObjDELETE
+	if bypassGovernance(r.Header) {
+		perms |= cmn.AccessAdmin
+	}
+	bckArgs := bckInitArgs{p: p, w: w, r: r, perms: perms, tryOnlyRem: true}
 	bck, objName, err := p.parseAPIBckObj(w, r, &bckArgs)
 	if err != nil {
 		return
 	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
 
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
@@ -473,6 +499,9 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 	if msg.Action == cmn.ActDelete || msg.Action == cmn.ActEvictObjects {
 		perms = cmn.AccessObjDELETE
 	}
+	if bypassGovernance(r.Header) {
+		perms |= cmn.AccessAdmin
+	}
 
 	bckArgs := bckInitArgs{p: p, w: w, r: r, msg: &msg, perms: perms, tryOnlyRem: true, queryBck: bck}
 	if msg.Action == cmn.ActEvictRemoteBck {
@@ -483,6 +512,7 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 		}
 		if err != nil {
 			p.invalmsghdlr(w, r, err.Error(), errCode)
+			return
 		}
 	} else if bck, err = bckArgs.initAndTry(bck.Name); err != nil {
 		return
@@ -496,6 +526,10 @@ func (p *proxyrunner) httpbckdelete(w http.ResponseWriter, r *http.Request) {
 		}
 		fallthrough // fallthrough
 	case cmn.ActDestroyBck:
+		if err := bck.Props.ObjLock.CheckDestroy(bck.String(), bypassGovernance(r.Header)); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			return
+		}
 		if p.forwardCP(w, r, &msg, bck.Name) {
 			return
 		}
@@ -691,6 +725,9 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 
 	// Initialize bucket, try creating if it's a cloud bucket.
 	args := bckInitArgs{p: p, w: w, r: r, queryBck: bck, err: err, msg: msg, tryOnlyRem: true}
+	if msg.Action == cmn.ActGetBatch {
+		args.perms = cmn.AccessGET
+	}
 	if bck, err = args.initAndTry(bck.Name); err != nil {
 		return
 	}
@@ -830,13 +867,23 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 			return
 		}
 		w.Write([]byte(xactID))
+	case cmn.ActGetBatch:
+		if !p.allowRequest(w, r, bck) {
+			return
+		}
+		p.getBatch(w, r, msg)
 	case cmn.ActListObjects:
+		if !p.allowRequest(w, r, bck) {
+			return
+		}
 		begin := mono.NanoTime()
 		p.listObjects(w, r, bck, msg, begin)
 	case cmn.ActInvalListCache:
 		p.qm.c.invalidate(bck.Bck)
 	case cmn.ActSummaryBck:
 		p.bucketSummary(w, r, bck, msg)
+	case cmn.ActDiffBck:
+		p.bucketDiff(w, r, bck, msg)
 	case cmn.ActMakeNCopies:
 		var xactID string
 		if xactID, err = p.makeNCopies(msg, bck); err != nil {
@@ -856,6 +903,31 @@ func (p *proxyrunner) hpostBucket(w http.ResponseWriter, r *http.Request, msg *c
 	}
 }
 
+// redirect the entire batch to a single (random) target that then serves
+// as the designated target for this request (see targetrunner.getBatch)
+func (p *proxyrunner) getBatch(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	started := time.Now()
+	batchMsg := &cmn.GetBatchMsg{}
+	if err := cmn.MorphMarshal(msg.Value, batchMsg); err != nil {
+		p.invalmsghdlrf(w, r, "invalid %s action message: %v", msg.Action, err)
+		return
+	}
+	if err := batchMsg.Validate(); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	si, err := p.owner.smap.get().GetRandTarget()
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s %s (%s) => %s", r.Method, msg.Action, r.URL.Path, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
 func (p *proxyrunner) hpostCreateBucket(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg, bck *cluster.Bck) {
 	bucket := bck.Name
 	err := p.checkACL(r.Header, nil, cmn.AccessCreateBucket)
@@ -1067,7 +1139,7 @@ func (p *proxyrunner) bucketSummary(w http.ResponseWriter, r *http.Request, bck
 func (p *proxyrunner) gatherBucketSummary(bck *cluster.Bck, msg *cmn.BucketSummaryMsg) (
 	summaries cmn.BucketsSummaries, uuid string, err error) {
 	var (
-		isNew, q = p.initAsyncQuery(bck, msg, cmn.GenUUID())
+		isNew, q = p.initAsyncQuery(bck, &msg.UUID, cmn.GenUUID())
 		config   = cmn.GCO.Get()
 		smap     = p.owner.smap.get()
 		aisMsg   = p.newAisMsg(&cmn.ActionMsg{Action: cmn.ActSummaryBck, Value: msg}, smap, nil)
@@ -1126,7 +1198,7 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	request := &apiRequest{after: 1, prefix: cmn.URLPathObjects.L}
-	if msg.Action == cmn.ActRenameObject {
+	if msg.Action == cmn.ActRenameObject || msg.Action == cmn.ActSetRetention {
 		request.after = 2
 	}
 	if err := p.parseAPIRequest(w, r, request); err != nil {
@@ -1166,6 +1238,17 @@ func (p *proxyrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 		}
 		p.promoteFQN(w, r, bck, &msg)
 		return
+	case cmn.ActSetRetention:
+		perms := cmn.AccessPUT
+		if bypassGovernance(r.Header) {
+			perms |= cmn.AccessAdmin
+		}
+		if err := p.checkACL(r.Header, bck, perms); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusUnauthorized)
+			return
+		}
+		p.objSetRetention(w, r, bck, request.items[1])
+		return
 	default:
 		p.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
@@ -1284,6 +1367,9 @@ func (p *proxyrunner) httpobjhead(w http.ResponseWriter, r *http.Request, origUR
 	if err != nil {
 		return
 	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
 
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
@@ -1463,31 +1549,41 @@ func (p *proxyrunner) redirectURL(r *http.Request, si *cluster.Snode, ts time.Ti
 		}
 	}
 	redirect = nodeURL + r.URL.Path + "?"
-	if r.URL.RawQuery != "" {
-		redirect += r.URL.RawQuery + "&"
+	if rawQuery := r.URL.RawQuery; rawQuery != "" {
+		// (only the proxy adds redirect params)
+		if q := r.URL.Query(); cmn.DelRedirectParams(q) {
+			rawQuery = q.Encode()
+		}
+		if rawQuery != "" {
+			redirect += rawQuery + "&"
+		}
 	}
 
 	query.Set(cmn.URLParamProxyID, p.si.ID())
 	query.Set(cmn.URLParamUnixTime, cmn.UnixNano2S(ts.UnixNano()))
+	if tp := tracing.TraceParent(r.Context()); tp != "" {
+		query.Set(cmn.URLParamTraceParent, tp)
+	}
+	p.redirectUser(r, query)
 	redirect += query.Encode()
 	return
 }
 
-func (p *proxyrunner) initAsyncQuery(bck *cluster.Bck, msg *cmn.BucketSummaryMsg, newTaskID string) (bool, url.Values) {
-	isNew := msg.UUID == ""
+func (p *proxyrunner) initAsyncQuery(bck *cluster.Bck, uuid *string, newTaskID string) (bool, url.Values) {
+	isNew := *uuid == ""
 	q := url.Values{}
 	if isNew {
-		msg.UUID = newTaskID
+		*uuid = newTaskID
 		q.Set(cmn.URLParamTaskAction, cmn.TaskStart)
 		if glog.FastV(4, glog.SmoduleAIS) {
-			glog.Infof("proxy: starting new async task %s", msg.UUID)
+			glog.Infof("proxy: starting new async task %s", *uuid)
 		}
 	} else {
 		// First request is always 'Status' to avoid wasting gigabytes of
 		// traffic in case when few targets have finished their tasks.
 		q.Set(cmn.URLParamTaskAction, cmn.TaskStatus)
 		if glog.FastV(4, glog.SmoduleAIS) {
-			glog.Infof("proxy: reading async task %s result", msg.UUID)
+			glog.Infof("proxy: reading async task %s result", *uuid)
 		}
 	}
 
@@ -1617,6 +1713,7 @@ end:
 	if uint(len(entries)) >= pageSize {
 		allEntries.ContinuationToken = entries[len(entries)-1].Name
 	}
+	allEntries.AggregateDirs(smsg.Prefix, smsg.Delimiter, true /*skipDirs*/)
 	return allEntries, nil
 }
 
@@ -1688,6 +1785,8 @@ func (p *proxyrunner) listObjectsRemote(bck *cluster.Bck, smsg cmn.SelectMsg) (a
 			}
 		}
 	}
+	// NOTE: remote continuation token is opaque - a directory may be repeated on the next page
+	allEntries.AggregateDirs(smsg.Prefix, smsg.Delimiter, false /*skipDirs*/)
 
 	return allEntries, nil
 }
@@ -1716,6 +1815,18 @@ func (p *proxyrunner) objRename(w http.ResponseWriter, r *http.Request, bck *clu
 	p.statsT.Add(stats.RenameCount, 1)
 }
 
+func (p *proxyrunner) objSetRetention(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, objName string) {
+	started := time.Now()
+	smap := p.owner.smap.get()
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraControl)
+	http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+}
+
 func (p *proxyrunner) promoteFQN(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *cmn.ActionMsg) {
 	promoteArgs := cmn.ActValPromote{}
 	if err := cmn.MorphMarshal(msg.Value, &promoteArgs); err != nil {
@@ -2342,8 +2453,8 @@ func (p *proxyrunner) clusterHandler(w http.ResponseWriter, r *http.Request) {
 // [METHOD] /v1/tokens
 func (p *proxyrunner) tokenHandler(w http.ResponseWriter, r *http.Request) {
 	switch r.Method {
-	case http.MethodDelete:
-		p.httpTokenDelete(w, r)
+	case http.MethodDelete, http.MethodPut:
+		p.httpTokenUpdate(w, r)
 	default:
 		cmn.InvalidHandlerWithMsg(w, r, "invalid method for /token path")
 	}
@@ -2437,6 +2548,8 @@ func (p *proxyrunner) httpcluget(w http.ResponseWriter, r *http.Request) {
 		p.ic.writeStatus(w, r)
 	case cmn.GetWhatMountpaths:
 		p.queryClusterMountpaths(w, r, what)
+	case cmn.GetWhatWriteBack:
+		p.queryClusterWriteBack(w, r, what)
 	case cmn.GetWhatRemoteAIS:
 		config := cmn.GCO.Get()
 		smap := p.owner.smap.get()
@@ -2573,6 +2686,14 @@ func (p *proxyrunner) queryClusterMountpaths(w http.ResponseWriter, r *http.Requ
 	_ = p.writeJSON(w, r, out, what)
 }
 
+func (p *proxyrunner) queryClusterWriteBack(w http.ResponseWriter, r *http.Request, what string) {
+	targetResults := p._queryTargets(w, r)
+	if targetResults == nil {
+		return
+	}
+	_ = p.writeJSON(w, r, targetResults, what)
+}
+
 // helper methods for querying targets
 
 func (p *proxyrunner) _queryTargets(w http.ResponseWriter, r *http.Request) cmn.JSONRawMsgs {
@@ -2906,7 +3027,7 @@ func (p *proxyrunner) _updFinal(ctx *smapModifier, clone *smapX) {
 		pairs = append(pairs, revsPair{rmd, aisMsg})
 	}
 
-	if len(tokens.Tokens) > 0 {
+	if len(tokens.Tokens) > 0 || len(tokens.S3Users) > 0 {
 		pairs = append(pairs, revsPair{tokens, aisMsg})
 	}
 	_ = p.metasyncer.sync(pairs...)
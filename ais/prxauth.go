@@ -16,12 +16,20 @@ import (
 
 var errInvalidToken = errors.New("invalid token")
 
-func (p *proxyrunner) httpTokenDelete(w http.ResponseWriter, r *http.Request) {
+// DELETE: revoke tokens; PUT: update permissions of S3 users
+func (p *proxyrunner) httpTokenUpdate(w http.ResponseWriter, r *http.Request) {
 	tokenList := &TokenList{}
 	if _, err := p.checkRESTItems(w, r, 0, false, cmn.URLPathTokens.L); err != nil {
 		return
 	}
-	if p.forwardCP(w, r, nil, "revoke token") {
+	if r.Method == http.MethodPut && cmn.GCO.Get().Auth.Enabled {
+		// permissions of S3 users are pushed only by AuthN on behalf of administrator
+		if token, err := p.validateToken(r.Header); err != nil || !token.IsAdmin {
+			p.invalmsghdlr(w, r, "not authorized", http.StatusUnauthorized)
+			return
+		}
+	}
+	if p.forwardCP(w, r, nil, "update token list") {
 		return
 	}
 	if err := cmn.ReadJSON(w, r, tokenList); err != nil {
@@ -50,7 +58,34 @@ func (p *proxyrunner) validateToken(hdr http.Header) (*cmn.AuthToken, error) {
 		glog.Errorf("invalid token: %v", err)
 		return nil, errInvalidToken
 	}
+	if auth.S3 {
+		// S3 access key is not a secret and cannot be used on its own
+		glog.Errorf("S3 access key of %q used as a token", auth.UserID)
+		return nil, errInvalidToken
+	}
+
+	return auth, nil
+}
 
+// Verifies AWS signature V4 (header or presigned URL) of an S3 request.
+// The access key is an S3 token issued by AuthN, and the secret key is
+// derived from the access key (see cmn.S3SecretKey).
+// Returns the current permissions of the key owner (pushed by AuthN).
+func (p *proxyrunner) validateS3Sig(r *http.Request) (*cmn.AuthToken, error) {
+	sig, err := cmn.ParseSigV4(r)
+	if err != nil {
+		return nil, err
+	}
+	auth, err := p.authn.validateS3Key(sig.AccessKey)
+	if err != nil {
+		glog.Errorf("invalid S3 access key: %v", err)
+		return nil, errInvalidToken
+	}
+	secretKey := cmn.S3SecretKey(sig.AccessKey, cmn.GCO.Get().Auth.Secret)
+	if err := sig.Verify(r, secretKey); err != nil {
+		glog.Errorf("S3 request of %q: %v", auth.UserID, err)
+		return nil, err
+	}
 	return auth, nil
 }
 
@@ -66,19 +101,33 @@ func (p *proxyrunner) checkACL(hdr http.Header, bck *cluster.Bck, ace cmn.Access
 	if isIntraCall(hdr) {
 		return nil
 	}
-	var (
-		token  *cmn.AuthToken
-		cfg    = cmn.GCO.Get()
-		bucket *cmn.Bck
-		err    error
-	)
-	if cfg.Auth.Enabled {
-		token, err = p.validateToken(hdr)
-		if err != nil {
+	var token *cmn.AuthToken
+	if cmn.GCO.Get().Auth.Enabled {
+		var err error
+		if token, err = p.validateToken(hdr); err != nil {
 			return err
 		}
-		uid := p.owner.smap.Get().UUID
+	}
+	return p.checkTokenACL(token, bck, ace)
+}
+
+// S3 requests: the token is the verified S3 access key (see validateS3Sig).
+// If AuthN is off, only bucket permissions are checked - with no exceptions.
+func (p *proxyrunner) checkACLS3(token *cmn.AuthToken, bck *cluster.Bck, ace cmn.AccessAttrs) error {
+	if token != nil {
+		return p.checkTokenACL(token, bck, ace)
+	}
+	if bck == nil {
+		return nil
+	}
+	return bck.Allow(ace)
+}
 
+// nil token means that AuthN is disabled
+func (p *proxyrunner) checkTokenACL(token *cmn.AuthToken, bck *cluster.Bck, ace cmn.AccessAttrs) error {
+	if token != nil {
+		var bucket *cmn.Bck
+		uid := p.owner.smap.Get().UUID
 		if bck != nil {
 			bucket = &bck.Bck
 		}
@@ -90,7 +139,7 @@ func (p *proxyrunner) checkACL(hdr http.Header, bck *cluster.Bck, ace cmn.Access
 		// cluster ACL like create/list buckets, node management etc
 		return nil
 	}
-	if !cfg.Auth.Enabled || token.IsAdmin {
+	if token == nil || token.IsAdmin {
 		// PATCH and ACL are always allowed in two cases:
 		// - a user is a superuser
 		// - AuthN is disabled
@@ -99,7 +148,7 @@ func (p *proxyrunner) checkACL(hdr http.Header, bck *cluster.Bck, ace cmn.Access
 	if ace == 0 {
 		return nil
 	}
-	if !cfg.Auth.Enabled {
+	if token == nil {
 		// Without AuthN, read-only access is always OK
 		ace &^= cmn.AccessRO
 	}
@@ -0,0 +1,278 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/azcompat"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// [METHOD] /az
+func (p *proxyrunner) azHandler(w http.ResponseWriter, r *http.Request) {
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AzureRequest: %s - %s", r.Method, r.URL)
+	}
+	apiItems, err := p.checkRESTItems(w, r, 0, true, cmn.URLPathAZ.L)
+	if err != nil {
+		return
+	}
+	if len(apiItems) > 1 {
+		p.blobAz(w, r, apiItems)
+		return
+	}
+
+	azcompat.SetCommonHeaders(w.Header())
+	q := r.URL.Query()
+	if len(apiItems) == 0 {
+		if r.Method != http.MethodGet || q.Get(azcompat.URLParamComp) != azcompat.CompList {
+			p.invalmsghdlrf(w, r, "invalid request %s %s: container name required", r.Method, r.URL.Path)
+			return
+		}
+		p.listContainersAz(w, r)
+		return
+	}
+	if q.Get(azcompat.URLParamRestype) != azcompat.RestypeContainer {
+		p.invalmsghdlrf(w, r, "invalid request %s %s: blob name required", r.Method, r.URL.Path)
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		if q.Get(azcompat.URLParamComp) == azcompat.CompList {
+			p.listBlobsAz(w, r, apiItems[0])
+			return
+		}
+		p.headContainerAz(w, r, apiItems[0])
+	case http.MethodHead:
+		p.headContainerAz(w, r, apiItems[0])
+	case http.MethodPut:
+		p.createContainerAz(w, r, apiItems[0])
+	case http.MethodDelete:
+		p.deleteContainerAz(w, r, apiItems[0])
+	default:
+		p.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
+	}
+}
+
+// Azure service endpoint as seen by the client
+func azEndpoint(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + "/" + cmn.AZ + "/"
+}
+
+// Azure clients can only pass AIS token as OAuth bearer token;
+// errors are reported with Azure error codes.
+func (p *proxyrunner) checkACLAz(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, ace cmn.AccessAttrs) bool {
+	if cmn.GCO.Get().Auth.Enabled && azcompat.IsSharedKeyOrSAS(r) {
+		w.Header().Set(azcompat.HeaderErrorCode, azcompat.ErrAuthFailed)
+		p.invalmsghdlr(w, r, "shared key and SAS authorization are not supported, use AIS token as bearer token",
+			http.StatusForbidden)
+		return false
+	}
+	err := p.checkACL(r.Header, bck, ace)
+	if err == nil {
+		return true
+	}
+	if err == errInvalidToken {
+		w.Header().Set(azcompat.HeaderErrorCode, azcompat.ErrAuthFailed)
+	} else {
+		w.Header().Set(azcompat.HeaderErrorCode, azcompat.ErrPermMismatch)
+	}
+	p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+	return false
+}
+
+// GET az/?comp=list
+func (p *proxyrunner) listContainersAz(w http.ResponseWriter, r *http.Request) {
+	if !p.checkACLAz(w, r, nil, cmn.AccessListBuckets) {
+		return
+	}
+	var (
+		bmd    = p.owner.bmd.get()
+		query  = cmn.QueryBcks{Provider: cmn.ProviderAIS}
+		prefix = r.URL.Query().Get(azcompat.URLParamPrefix)
+		resp   = azcompat.NewContainerList(azEndpoint(r), prefix)
+	)
+	bmd.Range(&query.Provider, nil, func(bck *cluster.Bck) bool {
+		if (query.Equal(bck.Bck) || query.Contains(bck.Bck)) && strings.HasPrefix(bck.Name, prefix) {
+			resp.Add(bck)
+		}
+		return false
+	})
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(resp.MustMarshal())
+}
+
+// [GET|HEAD] az/container?restype=container
+func (p *proxyrunner) headContainerAz(w http.ResponseWriter, r *http.Request, container string) {
+	bck := cluster.NewBck(container, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		w.Header().Set(azcompat.HeaderErrorCode, "ContainerNotFound")
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !p.checkACLAz(w, r, bck, cmn.AccessBckHEAD) {
+		return
+	}
+	azcompat.SetContainerHeaders(w.Header(), bck)
+}
+
+// PUT az/container?restype=container
+func (p *proxyrunner) createContainerAz(w http.ResponseWriter, r *http.Request, container string) {
+	bck := cluster.NewBck(container, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := cmn.ValidateBckName(container); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if !p.checkACLAz(w, r, nil, cmn.AccessCreateBucket) {
+		return
+	}
+	msg := cmn.ActionMsg{Action: cmn.ActCreateBck}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+container) {
+		return
+	}
+	if err := p.createBucket(&msg, bck); err != nil {
+		errCode := http.StatusInternalServerError
+		if _, ok := err.(*cmn.ErrorBucketAlreadyExists); ok {
+			errCode = http.StatusConflict
+			w.Header().Set(azcompat.HeaderErrorCode, "ContainerAlreadyExists")
+		}
+		p.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+// DELETE az/container?restype=container
+func (p *proxyrunner) deleteContainerAz(w http.ResponseWriter, r *http.Request, container string) {
+	bck := cluster.NewBck(container, cmn.ProviderAIS, cmn.NsGlobal)
+	msg := cmn.ActionMsg{Action: cmn.ActDestroyBck}
+	if err := bck.Init(p.owner.bmd); err != nil {
+		w.Header().Set(azcompat.HeaderErrorCode, "ContainerNotFound")
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !p.checkACLAz(w, r, bck, cmn.AccessDestroyBucket) {
+		return
+	}
+	if err := bck.Props.ObjLock.CheckDestroy(bck.String(), false /*bypass*/); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+container) {
+		return
+	}
+	if err := p.destroyBucket(&msg, bck); err != nil {
+		if _, ok := err.(*cmn.ErrorBucketAlreadyExists); !ok {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		glog.Infof("%s: %s already %q-ed, nothing to do", p.si, bck, msg.Action)
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// GET az/container?restype=container&comp=list
+func (p *proxyrunner) listBlobsAz(w http.ResponseWriter, r *http.Request, container string) {
+	bck := cluster.NewBck(container, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		w.Header().Set(azcompat.HeaderErrorCode, "ContainerNotFound")
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	if !p.checkACLAz(w, r, bck, cmn.AccessObjLIST) {
+		return
+	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
+	smsg := cmn.SelectMsg{UUID: cmn.GenUUID(), TimeFormat: time.RFC3339}
+	smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsAtime)
+	azcompat.FillMsgFromAzQuery(r.URL.Query(), &smsg)
+
+	var (
+		objList *cmn.BucketList
+		err     error
+	)
+	if bck.IsAIS() || smsg.IsFlagSet(cmn.SelectCached) {
+		objList, err = p.listObjectsAIS(bck, smsg)
+	} else {
+		objList, err = p.listObjectsRemote(bck, smsg)
+	}
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	resp := azcompat.NewBlobList(azEndpoint(r), bck.Name, r.URL.Query())
+	resp.FillFromAisBckList(objList)
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(resp.MustMarshal())
+}
+
+// [GET|HEAD|PUT|DELETE] az/container/blob
+func (p *proxyrunner) blobAz(w http.ResponseWriter, r *http.Request, items []string) {
+	started := time.Now()
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		w.Header().Set(azcompat.HeaderErrorCode, "ContainerNotFound")
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	var ace cmn.AccessAttrs
+	switch r.Method {
+	case http.MethodGet:
+		ace = cmn.AccessGET
+	case http.MethodHead:
+		ace = cmn.AccessObjHEAD
+	case http.MethodPut:
+		ace = cmn.AccessPUT
+	case http.MethodDelete:
+		ace = cmn.AccessObjDELETE
+	default:
+		p.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
+		return
+	}
+	if !p.checkACLAz(w, r, bck, ace) {
+		return
+	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
+	var (
+		smap    = p.owner.smap.get()
+		objName = path.Join(items[1:]...)
+	)
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AISAZ: %s %s/%s => %s", r.Method, bck, objName, si)
+	}
+	switch r.Method {
+	case http.MethodGet, http.MethodHead:
+		netName := cmn.NetworkIntraData
+		if r.Method == http.MethodHead {
+			netName = cmn.NetworkIntraControl
+		}
+		azcompat.SetCommonHeaders(w.Header())
+		redirectURL := p.redirectURL(r, si, started, netName)
+		http.Redirect(w, r, redirectURL, http.StatusTemporaryRedirect)
+	default:
+		// NOTE: Azure SDKs follow redirects only for GET and HEAD requests
+		p.markProxied(r, started)
+		p.reverseNodeRequest(w, r, si)
+	}
+}
@@ -0,0 +1,123 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// POST {action: ActDiffBck} /v1/buckets/bucket-name
+//
+// Asynchronous (task-based) request - see also bucketSummary:
+// the first call starts the task and returns its ID, subsequent calls
+// (with the same ID) return http.StatusAccepted until all targets are done.
+func (p *proxyrunner) bucketDiff(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, amsg *cmn.ActionMsg) {
+	var (
+		diff *cmn.BucketDiff
+		uuid string
+		dmsg = cmn.BucketDiffMsg{}
+		dst  = bck
+	)
+	if err := cmn.MorphMarshal(amsg.Value, &dmsg); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if id := r.URL.Query().Get(cmn.URLParamUUID); id != "" {
+		dmsg.UUID = id
+	}
+	if dmsg.Backend {
+		if !bck.IsRemote() {
+			p.invalmsghdlrf(w, r, "cannot compare %s with its backend: not a remote bucket", bck)
+			return
+		}
+	} else {
+		var (
+			err  error
+			args = bckInitArgs{p: p, w: w, r: r, queryBck: cluster.NewBckEmbed(dmsg.Dst), msg: amsg, tryOnlyRem: true}
+		)
+		if dst, err = args.initAndTry(dmsg.Dst.Name); err != nil {
+			return
+		}
+		if bck.Equal(dst, false, true) {
+			p.invalmsghdlrf(w, r, "cannot compare bucket %s with itself", bck)
+			return
+		}
+		dmsg.Dst = dst.Bck
+	}
+
+	diff, uuid, err := p.gatherBucketDiff(bck, dst, &dmsg)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if uuid != "" {
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(uuid))
+		return
+	}
+	p.writeJSON(w, r, diff, "bucket_diff")
+}
+
+func (p *proxyrunner) gatherBucketDiff(src, dst *cluster.Bck, msg *cmn.BucketDiffMsg) (
+	diff *cmn.BucketDiff, uuid string, err error) {
+	var (
+		isNew, q = p.initAsyncQuery(src, &msg.UUID, cmn.GenUUID())
+		config   = cmn.GCO.Get()
+		smap     = p.owner.smap.get()
+		aisMsg   = p.newAisMsg(&cmn.ActionMsg{Action: cmn.ActDiffBck, Value: msg}, smap, nil)
+		body     = cmn.MustMarshal(aisMsg)
+	)
+	args := allocBcastArgs()
+	args.req = cmn.ReqArgs{
+		Method: http.MethodPost,
+		Path:   cmn.URLPathBuckets.Join(src.Name),
+		Query:  q,
+		Body:   body,
+	}
+	args.smap = smap
+	args.timeout = config.Timeout.MaxHostBusy + config.Timeout.MaxKeepalive
+	results := p.bcastGroup(args)
+	allOK, _, err := p.checkBckTaskResp(msg.UUID, results)
+	if err != nil {
+		freeBcastArgs(args)
+		return nil, "", err
+	}
+	if !allOK || isNew {
+		freeBcastArgs(args)
+		return nil, msg.UUID, nil
+	}
+
+	// all targets are done - merge the listings and compare
+	q = url.Values{}
+	q = cmn.AddBckToQuery(q, src.Bck)
+	q.Set(cmn.URLParamTaskAction, cmn.TaskResult)
+	q.Set(cmn.URLParamSilent, "true")
+	args.req.Query = q
+	args.fv = func() interface{} { return &cmn.BucketDiffLists{} }
+	results = p.bcastGroup(args)
+	freeBcastArgs(args)
+	lists := &cmn.BucketDiffLists{}
+	for _, res := range results {
+		err = res.err
+		if err == nil {
+			err = lists.Merge(res.v.(*cmn.BucketDiffLists))
+		}
+		if err != nil {
+			freeCallResults(results)
+			return nil, "", err
+		}
+	}
+	freeCallResults(results)
+
+	// checksums are comparable only when both buckets are listed in-cluster
+	// and have the same checksum type
+	cksum := !msg.Backend && src.IsAIS() && dst.IsAIS() &&
+		src.CksumConf().Type != cmn.ChecksumNone && src.CksumConf().Type == dst.CksumConf().Type
+	return cmn.DiffBuckets(lists.Src, lists.Dst, cksum), "", nil
+}
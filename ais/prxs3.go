@@ -26,6 +26,16 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 		glog.Infof("S3Request: %s - %s", r.Method, r.URL)
 	}
 
+	// NOTE: must be verified before the URL gets modified (see ReparseQuery below)
+	var token *cmn.AuthToken
+	if cmn.GCO.Get().Auth.Enabled {
+		var err error
+		if token, err = p.validateS3Sig(r); err != nil {
+			p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+
 	// TODO: Fix the hack, https://github.com/tensorflow/tensorflow/issues/41798
 	cmn.ReparseQuery(r)
 	apiItems, err := p.checkRESTItems(w, r, 0, true, cmn.URLPathS3.L)
@@ -40,29 +50,45 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		if len(apiItems) == 1 {
-			p.headBckS3(w, r, apiItems[0])
+			p.headBckS3(w, r, token, apiItems[0])
 			return
 		}
-		p.headObjS3(w, r, apiItems)
+		p.headObjS3(w, r, token, apiItems)
 	case http.MethodGet:
 		if len(apiItems) == 0 {
 			// nothing  - list all the buckets
-			p.bckNamesToS3(w)
+			p.bckNamesToS3(w, r, token)
 			return
 		}
 		if len(apiItems) == 1 {
 			q := r.URL.Query()
 			_, versioning := q[s3compat.URLParamVersioning]
 			if versioning {
-				p.getBckVersioningS3(w, r, apiItems[0])
+				p.getBckVersioningS3(w, r, token, apiItems[0])
+				return
+			}
+			if _, lifecycle := q[s3compat.URLParamLifecycle]; lifecycle {
+				p.getBckLifecycleS3(w, r, token, apiItems[0])
+				return
+			}
+			if _, versions := q[s3compat.URLParamVersions]; versions {
+				p.bckListVersionsS3(w, r, token, apiItems[0])
 				return
 			}
 			// only bucket name - list objects in the bucket
-			p.bckListS3(w, r, apiItems[0])
+			p.bckListS3(w, r, token, apiItems[0])
+			return
+		}
+		if isMptS3(r.URL.Query()) {
+			p.mptObjS3(w, r, token, apiItems)
+			return
+		}
+		if _, tagging := r.URL.Query()[s3compat.URLParamTagging]; tagging {
+			p.taggingObjS3(w, r, token, apiItems)
 			return
 		}
 		// object data otherwise
-		p.getObjS3(w, r, apiItems)
+		p.getObjS3(w, r, token, apiItems)
 	case http.MethodPut:
 		if len(apiItems) == 0 {
 			p.invalmsghdlr(w, r, "object or bucket name required")
@@ -72,24 +98,36 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 			q := r.URL.Query()
 			_, versioning := q[s3compat.URLParamVersioning]
 			if versioning {
-				p.putBckVersioningS3(w, r, apiItems[0])
+				p.putBckVersioningS3(w, r, token, apiItems[0])
 				return
 			}
-			p.putBckS3(w, r, apiItems[0])
+			if _, lifecycle := q[s3compat.URLParamLifecycle]; lifecycle {
+				p.putBckLifecycleS3(w, r, token, apiItems[0])
+				return
+			}
+			p.putBckS3(w, r, token, apiItems[0])
+			return
+		}
+		if _, tagging := r.URL.Query()[s3compat.URLParamTagging]; tagging {
+			p.taggingObjS3(w, r, token, apiItems)
 			return
 		}
-		p.putObjS3(w, r, apiItems)
+		p.putObjS3(w, r, token, apiItems)
 	case http.MethodPost:
+		q := r.URL.Query()
+		if isMptS3(q) {
+			p.mptObjS3(w, r, token, apiItems)
+			return
+		}
 		if len(apiItems) != 1 {
 			p.invalmsghdlr(w, r, "bucket name expected")
 			return
 		}
-		q := r.URL.Query()
 		if _, multiple := q[s3compat.URLParamMultiDelete]; !multiple {
 			p.invalmsghdlr(w, r, "invalid request")
 			return
 		}
-		p.delMultipleObjs(w, r, apiItems[0])
+		p.delMultipleObjs(w, r, token, apiItems[0])
 	case http.MethodDelete:
 		if len(apiItems) == 0 {
 			p.invalmsghdlr(w, r, "object or bucket name required")
@@ -99,20 +137,36 @@ func (p *proxyrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 			q := r.URL.Query()
 			_, multiple := q[s3compat.URLParamMultiDelete]
 			if multiple {
-				p.delMultipleObjs(w, r, apiItems[0])
+				p.delMultipleObjs(w, r, token, apiItems[0])
+				return
+			}
+			if _, lifecycle := q[s3compat.URLParamLifecycle]; lifecycle {
+				p.putBckLifecycleS3(w, r, token, apiItems[0])
 				return
 			}
-			p.delBckS3(w, r, apiItems[0])
+			p.delBckS3(w, r, token, apiItems[0])
+			return
+		}
+		if isMptS3(r.URL.Query()) {
+			p.mptObjS3(w, r, token, apiItems)
 			return
 		}
-		p.delObjS3(w, r, apiItems)
+		if _, tagging := r.URL.Query()[s3compat.URLParamTagging]; tagging {
+			p.taggingObjS3(w, r, token, apiItems)
+			return
+		}
+		p.delObjS3(w, r, token, apiItems)
 	default:
 		p.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
 	}
 }
 
 // GET s3/
-func (p *proxyrunner) bckNamesToS3(w http.ResponseWriter) {
+func (p *proxyrunner) bckNamesToS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken) {
+	if err := p.checkACLS3(token, nil, cmn.AccessListBuckets); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
 	bmd := p.owner.bmd.get()
 	query := cmn.QueryBcks{Provider: cmn.ProviderAIS}
 	cp := &query.Provider
@@ -131,12 +185,16 @@ func (p *proxyrunner) bckNamesToS3(w http.ResponseWriter) {
 }
 
 // PUT s3/bck-name
-func (p *proxyrunner) putBckS3(w http.ResponseWriter, r *http.Request, bucket string) {
+func (p *proxyrunner) putBckS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := cmn.ValidateBckName(bucket); err != nil {
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if err := p.checkACLS3(token, nil, cmn.AccessCreateBucket); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
 	msg := cmn.ActionMsg{Action: cmn.ActCreateBck}
 	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
 		return
@@ -152,14 +210,18 @@ func (p *proxyrunner) putBckS3(w http.ResponseWriter, r *http.Request, bucket st
 
 // DEL s3/bck-name
 // TODO: AWS allows to delete bucket only if it is empty
-func (p *proxyrunner) delBckS3(w http.ResponseWriter, r *http.Request, bucket string) {
+func (p *proxyrunner) delBckS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	msg := cmn.ActionMsg{Action: cmn.ActDestroyBck}
 	if err := bck.Init(p.owner.bmd); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
 		return
 	}
-	if err := bck.Allow(cmn.AccessDestroyBucket); err != nil {
+	if err := p.checkACLS3(token, bck, cmn.AccessDestroyBucket); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	if err := bck.Props.ObjLock.CheckDestroy(bck.String(), false /*bypass*/); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
@@ -178,17 +240,20 @@ func (p *proxyrunner) delBckS3(w http.ResponseWriter, r *http.Request, bucket st
 
 // DEL s3/bck-name?delete
 // Delete list of objects
-func (p *proxyrunner) delMultipleObjs(w http.ResponseWriter, r *http.Request, bucket string) {
+func (p *proxyrunner) delMultipleObjs(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, bucket string) {
 	defer cmn.Close(r.Body)
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
 		return
 	}
-	if err := bck.Allow(cmn.AccessObjDELETE); err != nil {
+	if err := p.checkACLS3(token, bck, cmn.AccessObjDELETE); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
 	decoder := xml.NewDecoder(r.Body)
 	objList := &s3compat.Delete{}
 	if err := decoder.Decode(objList); err != nil {
@@ -218,13 +283,13 @@ func (p *proxyrunner) delMultipleObjs(w http.ResponseWriter, r *http.Request, bu
 }
 
 // HEAD s3/bck-name
-func (p *proxyrunner) headBckS3(w http.ResponseWriter, r *http.Request, bucket string) {
+func (p *proxyrunner) headBckS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
 		return
 	}
-	if err := bck.Allow(cmn.AccessBckHEAD); err != nil {
+	if err := p.checkACLS3(token, bck, cmn.AccessBckHEAD); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
@@ -241,12 +306,19 @@ func (p *proxyrunner) headBckS3(w http.ResponseWriter, r *http.Request, bucket s
 }
 
 // GET s3/bckName
-func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket string) {
+func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd); err != nil {
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if err := p.checkACLS3(token, bck, cmn.AccessObjLIST); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
 	smsg := cmn.SelectMsg{UUID: cmn.GenUUID(), TimeFormat: time.RFC3339}
 	smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsAtime, cmn.GetPropsVersion)
 	s3compat.FillMsgFromS3Query(r.URL.Query(), &smsg)
@@ -266,8 +338,42 @@ func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket s
 		return
 	}
 
-	resp := s3compat.NewListObjectResult()
-	resp.ContinuationToken = smsg.ContinuationToken
+	resp := s3compat.NewListObjectResult(bck.Name, r.URL.Query())
+	resp.FillFromAisBckList(objList, &smsg)
+	b := resp.MustMarshal()
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(b)
+}
+
+// GET s3/bckName?versions
+func (p *proxyrunner) bckListVersionsS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, bucket string) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := p.checkACLS3(token, bck, cmn.AccessObjLIST); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
+	smsg := cmn.SelectMsg{UUID: cmn.GenUUID(), TimeFormat: time.RFC3339}
+	smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsAtime, cmn.GetPropsVersion)
+	s3compat.FillMsgFromS3VersionsQuery(r.URL.Query(), &smsg)
+
+	// NOTE: prior versions are retained only for ais buckets, and only in-cluster
+	if !bck.IsAIS() {
+		smsg.SetFlag(cmn.SelectCached)
+	}
+	objList, err := p.listObjectsAIS(bck, smsg)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+
+	resp := s3compat.NewListVersionsResult(bck.Name, r.URL.Query())
 	resp.FillFromAisBckList(objList, &smsg)
 	b := resp.MustMarshal()
 	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
@@ -275,7 +381,7 @@ func (p *proxyrunner) bckListS3(w http.ResponseWriter, r *http.Request, bucket s
 }
 
 // PUT s3/bckName/objName - with HeaderObjSrc in request header - a source
-func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []string) {
+func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, items []string) {
 	started := time.Now()
 	src := r.Header.Get(s3compat.HeaderObjSrc)
 	src = strings.Trim(src, "/") // in examples the path starts with "/"
@@ -289,7 +395,7 @@ func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
-	if err := bckSrc.Allow(cmn.AccessGET); err != nil {
+	if err := p.checkACLS3(token, bckSrc, cmn.AccessGET); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
@@ -307,10 +413,13 @@ func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []
 		smap = p.owner.smap.get()
 		err  error
 	)
-	if err = bckDst.Allow(cmn.AccessPUT); err != nil {
+	if err = p.checkACLS3(token, bckDst, cmn.AccessPUT); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
+	if !p.allowRequest(w, r, bckDst) {
+		return
+	}
 	objName := strings.Trim(parts[1], "/")
 	si, err = cluster.HrwTarget(bckSrc.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -321,20 +430,24 @@ func (p *proxyrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []
 		glog.Infof("AISS3 COPY: %s %s/%s => %s/%v %s", r.Method, bckSrc, objName, bckDst, items, si)
 	}
 	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
-	s3Redirect(w, redirectURL, bckDst.Name)
+	s3Redirect(w, r, redirectURL, bckDst.Name)
 }
 
-func s3Redirect(w http.ResponseWriter, url, bck string) {
+func s3Redirect(w http.ResponseWriter, r *http.Request, redirectURL, bck string) {
+	if cmn.IsPresigned(r.URL.Query()) {
+		// the target validates presigned URL as well: it needs the original (signed) host
+		redirectURL += "&" + cmn.URLParamSigHost + "=" + url.QueryEscape(r.Host)
+	}
 	h := w.Header()
 
-	h.Set(cmn.HeaderLocation, url)
+	h.Set(cmn.HeaderLocation, redirectURL)
 	h.Set(cmn.HeaderContentType, "text/xml; charset=utf-8")
 	w.WriteHeader(http.StatusTemporaryRedirect)
-	fmt.Fprint(w, s3compat.MakeRedirectBody(url, bck))
+	fmt.Fprint(w, s3compat.MakeRedirectBody(redirectURL, bck))
 }
 
 // PUT s3/bckName/objName - without extra info in request header
-func (p *proxyrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, items []string) {
+func (p *proxyrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, items []string) {
 	started := time.Now()
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd); err != nil {
@@ -350,10 +463,13 @@ func (p *proxyrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, ite
 		smap = p.owner.smap.get()
 		err  error
 	)
-	if err = bck.Allow(cmn.AccessPUT); err != nil {
+	if err = p.checkACLS3(token, bck, cmn.AccessPUT); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
 	objName := path.Join(items[1:]...)
 	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -364,20 +480,20 @@ func (p *proxyrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, ite
 		glog.Infof("AISS3: %s %s/%s => %s", r.Method, bck, objName, si)
 	}
 	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
-	s3Redirect(w, redirectURL, bck.Name)
+	s3Redirect(w, r, redirectURL, bck.Name)
 }
 
 // PUT s3/bckName/objName
-func (p *proxyrunner) putObjS3(w http.ResponseWriter, r *http.Request, items []string) {
+func (p *proxyrunner) putObjS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, items []string) {
 	if r.Header.Get(s3compat.HeaderObjSrc) == "" {
-		p.directPutObjS3(w, r, items)
+		p.directPutObjS3(w, r, token, items)
 		return
 	}
-	p.copyObjS3(w, r, items)
+	p.copyObjS3(w, r, token, items)
 }
 
 // GET s3/<bucket-name/<object-name>[?uuid=<etl-uuid>]
-func (p *proxyrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []string) {
+func (p *proxyrunner) getObjS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, items []string) {
 	started := time.Now()
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd); err != nil {
@@ -393,10 +509,13 @@ func (p *proxyrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []s
 		smap = p.owner.smap.get()
 		err  error
 	)
-	if err = bck.Allow(cmn.AccessGET); err != nil {
+	if err = p.checkACLS3(token, bck, cmn.AccessGET); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
 	objName := path.Join(items[1:]...)
 
 	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
@@ -408,10 +527,10 @@ func (p *proxyrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []s
 		glog.Infof("AISS3: %s %s/%s => %s", r.Method, bck, objName, si)
 	}
 	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
-	s3Redirect(w, redirectURL, bck.Name)
+	s3Redirect(w, r, redirectURL, bck.Name)
 }
 
-func (p *proxyrunner) headObjS3(w http.ResponseWriter, r *http.Request, items []string) {
+func (p *proxyrunner) headObjS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, items []string) {
 	started := time.Now()
 	if len(items) < 2 {
 		p.invalmsghdlr(w, r, "object name is missing")
@@ -423,10 +542,13 @@ func (p *proxyrunner) headObjS3(w http.ResponseWriter, r *http.Request, items []
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
-	if err := bck.Allow(cmn.AccessObjHEAD); err != nil {
+	if err := p.checkACLS3(token, bck, cmn.AccessObjHEAD); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
 	smap := p.owner.smap.get()
 	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -437,11 +559,11 @@ func (p *proxyrunner) headObjS3(w http.ResponseWriter, r *http.Request, items []
 		glog.Infof("AISS3 %s %s/%s => %s", r.Method, bucket, objName, si)
 	}
 	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraControl)
-	s3Redirect(w, redirectURL, bck.Name)
+	s3Redirect(w, r, redirectURL, bck.Name)
 }
 
 // DEL s3/bckName/objName
-func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []string) {
+func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, items []string) {
 	started := time.Now()
 	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd); err != nil {
@@ -457,10 +579,13 @@ func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []s
 		smap = p.owner.smap.get()
 		err  error
 	)
-	if err = bck.Allow(cmn.AccessObjDELETE); err != nil {
+	if err = p.checkACLS3(token, bck, cmn.AccessObjDELETE); err != nil {
 		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
 		return
 	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
 	objName := path.Join(items[1:]...)
 	si, err = cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
 	if err != nil {
@@ -471,16 +596,107 @@ func (p *proxyrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []s
 		glog.Infof("AISS3: %s %s/%s => %s", r.Method, bck, objName, si)
 	}
 	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
-	s3Redirect(w, redirectURL, bck.Name)
+	s3Redirect(w, r, redirectURL, bck.Name)
+}
+
+// multipart upload (all requests are served by the target that owns the object):
+// POST s3/bckName/objName?uploads - start
+// PUT s3/bckName/objName?partNumber=N&uploadId=ID - upload part (handled as a regular PUT)
+// GET s3/bckName/objName?uploadId=ID - list parts
+// POST s3/bckName/objName?uploadId=ID - complete
+// DELETE s3/bckName/objName?uploadId=ID - abort
+func (p *proxyrunner) mptObjS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, items []string) {
+	started := time.Now()
+	if len(items) < 2 {
+		p.invalmsghdlr(w, r, "object name is undefined")
+		return
+	}
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := p.checkACLS3(token, bck, cmn.AccessPUT); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
+	var (
+		smap    = p.owner.smap.get()
+		objName = path.Join(items[1:]...)
+	)
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AISS3 MPT: %s %s/%s => %s", r.Method, bck, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
+	s3Redirect(w, r, redirectURL, bck.Name)
+}
+
+// [GET|PUT|DELETE] s3/bk-name/obj-name?tagging
+func (p *proxyrunner) taggingObjS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, items []string) {
+	started := time.Now()
+	if len(items) < 2 {
+		p.invalmsghdlr(w, r, "object name is undefined")
+		return
+	}
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	ace := cmn.AccessPUT
+	if r.Method == http.MethodGet {
+		ace = cmn.AccessObjHEAD
+	}
+	if err := p.checkACLS3(token, bck, ace); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	if !p.allowRequest(w, r, bck) {
+		return
+	}
+	var (
+		smap    = p.owner.smap.get()
+		objName = path.Join(items[1:]...)
+	)
+	si, err := cluster.HrwTarget(bck.MakeUname(objName), &smap.Smap)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("AISS3 tagging: %s %s/%s => %s", r.Method, bck, objName, si)
+	}
+	redirectURL := p.redirectURL(r, si, started, cmn.NetworkIntraData)
+	s3Redirect(w, r, redirectURL, bck.Name)
+}
+
+func isMptS3(q url.Values) bool {
+	if _, ok := q[s3compat.URLParamMptUploads]; ok {
+		return true
+	}
+	_, ok := q[s3compat.URLParamMptUploadID]
+	return ok
 }
 
 // GET s3/bk-name?versioning
-func (p *proxyrunner) getBckVersioningS3(w http.ResponseWriter, r *http.Request, bucket string) {
+func (p *proxyrunner) getBckVersioningS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, bucket string) {
 	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
 	if err := bck.Init(p.owner.bmd); err != nil {
 		p.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if err := p.checkACLS3(token, bck, cmn.AccessBckHEAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
 	resp := s3compat.NewVersioningConfiguration(bck.Props.Versioning.Enabled)
 	b := resp.MustMarshal()
 	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
@@ -488,7 +704,7 @@ func (p *proxyrunner) getBckVersioningS3(w http.ResponseWriter, r *http.Request,
 }
 
 // PUT s3/bk-name?versioning
-func (p *proxyrunner) putBckVersioningS3(w http.ResponseWriter, r *http.Request, bucket string) {
+func (p *proxyrunner) putBckVersioningS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, bucket string) {
 	msg := &cmn.ActionMsg{Action: cmn.ActSetBprops}
 	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
 		return
@@ -503,6 +719,10 @@ func (p *proxyrunner) putBckVersioningS3(w http.ResponseWriter, r *http.Request,
 		p.invalmsghdlr(w, r, "bucket does not exist", http.StatusNotFound)
 		return
 	}
+	if err := p.checkACLS3(token, bck, cmn.AccessPATCH); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
 	decoder := xml.NewDecoder(r.Body)
 	defer cmn.Close(r.Body)
 	vconf := &s3compat.VersioningConfiguration{}
@@ -518,3 +738,73 @@ func (p *proxyrunner) putBckVersioningS3(w http.ResponseWriter, r *http.Request,
 		p.invalmsghdlr(w, r, err.Error())
 	}
 }
+
+// GET s3/bk-name?lifecycle
+func (p *proxyrunner) getBckLifecycleS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, bucket string) {
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := p.checkACLS3(token, bck, cmn.AccessBckHEAD); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	resp, err := s3compat.NewLifecycleConfiguration(&bck.Props.Lifecycle)
+	if err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	b := resp.MustMarshal()
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(b)
+}
+
+// PUT s3/bk-name?lifecycle
+// DELETE s3/bk-name?lifecycle (removes all lifecycle rules)
+func (p *proxyrunner) putBckLifecycleS3(w http.ResponseWriter, r *http.Request, token *cmn.AuthToken, bucket string) {
+	msg := &cmn.ActionMsg{Action: cmn.ActSetBprops}
+	if p.forwardCP(w, r, nil, msg.Action+"-"+bucket) {
+		return
+	}
+	bck := cluster.NewBck(bucket, cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(p.owner.bmd); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	_, exists := p.owner.bmd.get().Get(bck)
+	if !exists {
+		p.invalmsghdlr(w, r, "bucket does not exist", http.StatusNotFound)
+		return
+	}
+	if err := p.checkACLS3(token, bck, cmn.AccessPATCH); err != nil {
+		p.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+		return
+	}
+	rules := []cmn.LifecycleRule{}
+	if r.Method == http.MethodPut {
+		decoder := xml.NewDecoder(r.Body)
+		defer cmn.Close(r.Body)
+		lc := &s3compat.LifecycleConfiguration{}
+		if err := decoder.Decode(lc); err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		conf, err := lc.Conf()
+		if err != nil {
+			p.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		rules = conf.Rules
+	}
+	propsToUpdate := cmn.BucketPropsToUpdate{
+		Lifecycle: &cmn.LifecycleConfToUpdate{Rules: &rules},
+	}
+	if _, err := p.setBucketProps(w, r, msg, bck, &propsToUpdate); err != nil {
+		p.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
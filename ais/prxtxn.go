@@ -298,6 +298,10 @@ func (p *proxyrunner) setBucketProps(w http.ResponseWriter, r *http.Request, msg
 	default:
 		cmn.Assert(false)
 	}
+	// (compliance-mode object lock cannot be relaxed - not even by resetting the props)
+	if err = bprops.ObjLock.ValidateUpdate(&nprops.ObjLock); err != nil {
+		return
+	}
 	// msg{propsToUpdate} => nmsg{nprops} and prep context(nmsg)
 	*nmsg = *msg
 	nmsg.Value = nprops
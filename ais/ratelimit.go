@@ -0,0 +1,285 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Rate limiting of user requests (see cmn.RateLimitConf):
+//  -> proxies limit requests per second (prior to redirecting)
+//  -> targets limit bandwidth: the transferred bytes are throttled as they are being
+//     read (PUT) or written (GET); a new request is rejected while the bucket (or the user)
+//     has more than `rateLimitMaxBacklog` worth of transfers in flight
+//  -> cluster-wide limits are evenly divided between the nodes that enforce them,
+//     which assumes that clients spread requests across proxies, and HRW - across targets
+//  -> users are identified by their AuthN tokens (proxy) and, respectively,
+//     by cmn.URLParamUserID added by the redirecting proxy (target)
+// Limiters are created on demand and removed by the housekeeper once idle.
+
+const (
+	rateLimitIdle       = 10 * time.Minute
+	rateLimitMaxBacklog = time.Second
+)
+
+type (
+	rateLimiters struct {
+		mu      sync.Mutex
+		buckets map[string]*cmn.RateLimiter // by bucket uname
+		users   map[string]*cmn.RateLimiter // by AuthN user ID
+	}
+	// limiters to throttle the bytes transferred
+	bwLimiters []*cmn.RateLimiter
+
+	throttledReader struct {
+		io.ReadCloser
+		bwl bwLimiters
+	}
+	throttledWriter struct {
+		http.ResponseWriter
+		bwl bwLimiters
+	}
+)
+
+func (rls *rateLimiters) init() {
+	rls.buckets = make(map[string]*cmn.RateLimiter, 16)
+	rls.users = make(map[string]*cmn.RateLimiter, 16)
+	hk.Reg("rate-limiters", rls.housekeep, rateLimitIdle)
+}
+
+func (rls *rateLimiters) bucket(bck *cluster.Bck, rate float64) *cmn.RateLimiter {
+	return rls.get(rls.buckets, bck.MakeUname(""), rate)
+}
+
+func (rls *rateLimiters) user(uid string, rate float64) *cmn.RateLimiter {
+	return rls.get(rls.users, uid, rate)
+}
+
+func (rls *rateLimiters) get(m map[string]*cmn.RateLimiter, key string, rate float64) *cmn.RateLimiter {
+	rls.mu.Lock()
+	rl, ok := m[key]
+	if !ok {
+		rl = cmn.NewRateLimiter(rate)
+		m[key] = rl
+	}
+	rls.mu.Unlock()
+	if ok {
+		rl.SetRate(rate)
+	}
+	return rl
+}
+
+func (rls *rateLimiters) housekeep() time.Duration {
+	rls.mu.Lock()
+	for _, m := range []map[string]*cmn.RateLimiter{rls.buckets, rls.users} {
+		for key, rl := range m {
+			if rl.Full() {
+				delete(m, key)
+			}
+		}
+	}
+	rls.mu.Unlock()
+	return rateLimitIdle
+}
+
+// cluster-wide limit => this node's share
+func perNodeRate(limit int64, nodeCnt int) float64 {
+	if nodeCnt < 1 {
+		nodeCnt = 1
+	}
+	return float64(limit) / float64(nodeCnt)
+}
+
+// Responds with 429 and the time (in seconds, at least one) to retry after.
+func (h *httprunner) tooManyRequests(w http.ResponseWriter, r *http.Request, retryAfter time.Duration,
+	stat, format string, a ...interface{}) {
+	secs := int64((retryAfter + time.Second - 1) / time.Second)
+	if secs < 1 {
+		secs = 1
+	}
+	h.statsT.Add(stat, 1)
+	w.Header().Set(cmn.HeaderRetryAfter, strconv.FormatInt(secs, 10))
+	h.invalmsghdlrsilent(w, r, fmt.Sprintf(format, a...), http.StatusTooManyRequests)
+}
+
+////////////////
+// bwLimiters //
+////////////////
+
+// Reserves `n` bytes with all the limiters and waits for the longest of them.
+func (bwl bwLimiters) wait(n int) {
+	if n <= 0 {
+		return
+	}
+	var delay time.Duration
+	for _, rl := range bwl {
+		if d := rl.Reserve(int64(n)); d > delay {
+			delay = d
+		}
+	}
+	if delay > 0 {
+		time.Sleep(delay)
+	}
+}
+
+func (bwl bwLimiters) reader(r io.ReadCloser) io.ReadCloser {
+	if len(bwl) == 0 || r == nil {
+		return r
+	}
+	return &throttledReader{ReadCloser: r, bwl: bwl}
+}
+
+// NOTE: hides `ReadFrom` (and `sendfile`) of the original writer
+func (bwl bwLimiters) writer(w http.ResponseWriter) http.ResponseWriter {
+	if len(bwl) == 0 {
+		return w
+	}
+	return &throttledWriter{ResponseWriter: w, bwl: bwl}
+}
+
+func (tr *throttledReader) Read(b []byte) (n int, err error) {
+	n, err = tr.ReadCloser.Read(b)
+	tr.bwl.wait(n)
+	return
+}
+
+func (tw *throttledWriter) Write(b []byte) (int, error) {
+	tw.bwl.wait(len(b))
+	return tw.ResponseWriter.Write(b)
+}
+
+///////////
+// proxy //
+///////////
+
+// Returns the ID of the authenticated user, if any. The user is resolved (and
+// the token validated) only once per request - see allowRequest and redirectUser.
+func (p *proxyrunner) requestUser(r *http.Request) string {
+	if uid, ok := r.Context().Value(cmn.CtxUserID).(string); ok {
+		return uid
+	}
+	uid := p.resolveUser(r)
+	*r = *r.WithContext(context.WithValue(r.Context(), cmn.CtxUserID, uid))
+	return uid
+}
+
+// S3 requests are signed with the user's S3 access key (see validateS3Sig).
+func (p *proxyrunner) resolveUser(r *http.Request) string {
+	if !cmn.GCO.Get().Auth.Enabled || isIntraCall(r.Header) {
+		return ""
+	}
+	var (
+		token *cmn.AuthToken
+		err   error
+	)
+	if sig, errSig := cmn.ParseSigV4(r); errSig == nil {
+		token, err = p.authn.validateToken(sig.AccessKey)
+	} else {
+		token, err = p.validateToken(r.Header)
+	}
+	if err != nil {
+		return ""
+	}
+	return token.UserID
+}
+
+// Returns false - having responded with 429 - if the request exceeds the rate
+// of requests of the bucket or the user.
+func (p *proxyrunner) allowRequest(w http.ResponseWriter, r *http.Request, bck *cluster.Bck) bool {
+	conf := &cmn.GCO.Get().RateLimit
+	if !conf.Enabled || isIntraCall(r.Header) {
+		return true
+	}
+	nproxies := p.owner.smap.get().CountActiveProxies()
+	if limit := bck.Props.RateLimit.RPS; limit > 0 {
+		if rl := p.rateLimiters.bucket(bck, perNodeRate(limit, nproxies)); !rl.TryAcquire(1) {
+			p.tooManyRequests(w, r, rl.Delay(1), stats.ErrRateLimitBckRPSCount,
+				"bucket %s: too many requests (limit %d/s)", bck, limit)
+			return false
+		}
+	}
+	if limit := conf.User.RPS; limit > 0 {
+		if uid := p.requestUser(r); uid != "" {
+			if rl := p.rateLimiters.user(uid, perNodeRate(limit, nproxies)); !rl.TryAcquire(1) {
+				p.tooManyRequests(w, r, rl.Delay(1), stats.ErrRateLimitUserRPSCount,
+					"user %q: too many requests (limit %d/s)", uid, limit)
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Adds the user to the redirect URL for the target to enforce the user's bandwidth.
+func (p *proxyrunner) redirectUser(r *http.Request, query url.Values) {
+	conf := &cmn.GCO.Get().RateLimit
+	if !conf.Enabled || conf.User.Bandwidth == 0 {
+		return
+	}
+	if uid := p.requestUser(r); uid != "" {
+		query.Set(cmn.URLParamUserID, uid)
+	}
+}
+
+// Marks the request that is reverse-proxied (rather than redirected) to a target
+// for the latter to enforce bandwidth limits - see redirectURL and allowTransfer.
+func (p *proxyrunner) markProxied(r *http.Request, ts time.Time) {
+	query := r.URL.Query()
+	cmn.DelRedirectParams(query) // (only the proxy adds redirect params)
+	query.Set(cmn.URLParamProxyID, p.si.ID())
+	query.Set(cmn.URLParamUnixTime, cmn.UnixNano2S(ts.UnixNano()))
+	p.redirectUser(r, query)
+	r.URL.RawQuery = query.Encode()
+}
+
+////////////
+// target //
+////////////
+
+// Returns false - having responded with 429 - if the bucket or the user has
+// exceeded its bandwidth by more than `rateLimitMaxBacklog`; otherwise, returns
+// the limiters to throttle the bytes transferred (none if not limited).
+// Only the requests redirected by proxies are subject to rate limiting.
+func (t *targetrunner) allowTransfer(w http.ResponseWriter, r *http.Request, bck *cluster.Bck,
+	query url.Values) (bwl bwLimiters, ok bool) {
+	conf := &cmn.GCO.Get().RateLimit
+	if !conf.Enabled || isRedirect(query) == "" || isIntraCall(r.Header) {
+		return nil, true
+	}
+	ntargets := t.owner.smap.get().CountActiveTargets()
+	if limit := bck.Props.RateLimit.Bandwidth; limit > 0 {
+		rl := t.rateLimiters.bucket(bck, perNodeRate(limit, ntargets))
+		if d := rl.Delay(0); d > rateLimitMaxBacklog {
+			t.tooManyRequests(w, r, d, stats.ErrRateLimitBckBWCount,
+				"bucket %s: bandwidth limit exceeded (%s/s)", bck, cmn.B2S(limit, 2))
+			return nil, false
+		}
+		bwl = append(bwl, rl)
+	}
+	if limit := conf.User.Bandwidth; limit > 0 {
+		if uid := query.Get(cmn.URLParamUserID); uid != "" {
+			rl := t.rateLimiters.user(uid, perNodeRate(limit, ntargets))
+			if d := rl.Delay(0); d > rateLimitMaxBacklog {
+				t.tooManyRequests(w, r, d, stats.ErrRateLimitUserBWCount,
+					"user %q: bandwidth limit exceeded (%s/s)", uid, cmn.B2S(limit, 2))
+				return nil, false
+			}
+			bwl = append(bwl, rl)
+		}
+	}
+	return bwl, true
+}
@@ -48,13 +48,13 @@ type (
 	}
 )
 
+// TODO: AIS buckets and objects do not have owners
+var defaultOwner = BckOwner{ID: "1", Name: "ais"}
+
 func NewListBucketResult() *ListBucketResult {
 	return &ListBucketResult{
-		Ns: s3Namespace,
-		Owner: BckOwner{ // TODO:
-			ID:   "1",
-			Name: "ais",
-		},
+		Ns:      s3Namespace,
+		Owner:   defaultOwner,
 		Buckets: make([]*Bucket, 0),
 	}
 }
@@ -20,14 +20,48 @@ const (
 	versioningEnabled   = "Enabled"
 	versioningDisabled  = "Suspended"
 
+	// list objects
+	URLParamListType          = "list-type"
+	URLParamPrefix            = "prefix"
+	URLParamDelimiter         = "delimiter"
+	URLParamMaxKeys           = "max-keys"
+	URLParamMarker            = "marker"
+	URLParamContinuationToken = "continuation-token"
+	URLParamStartAfter        = "start-after"
+	URLParamFetchOwner        = "fetch-owner"
+	listTypeV2                = "2"
+
+	// multipart upload
+	URLParamMptUploads    = "uploads"
+	URLParamMptUploadID   = "uploadId"
+	URLParamMptPartNumber = "partNumber"
+	maxPartNum            = 10000
+
+	// object tagging
+	URLParamTagging = "tagging"
+
+	// bucket lifecycle
+	URLParamLifecycle = "lifecycle"
+
+	// object versions (see cmn.VersionConf.Retain)
+	URLParamVersions  = "versions"
+	URLParamVersionID = "versionId"
+	URLParamKeyMarker = "key-marker"
+	versionIDNull     = "null" // unversioned object
+
 	s3Namespace = "http://s3.amazonaws.com/doc/2006-03-01"
 	// TODO: can it be omitted? // storageClass = "STANDARD"
 
 	// Headers
-	headerETag    = "ETag"
+	HeaderETag    = "ETag"
 	headerVersion = "x-amz-version-id"
 	HeaderObjSrc  = "x-amz-copy-source"
 
+	// user-defined metadata and tags
+	headerMetaPrefix   = "X-Amz-Meta-" // canonical form
+	headerTagging      = "x-amz-tagging"
+	headerTaggingCount = "x-amz-tagging-count"
+
 	headerAtime = "Last-Modified"
 )
 
@@ -0,0 +1,158 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// S3 bucket lifecycle configuration maps onto AIS lifecycle rules (cmn.LifecycleConf):
+// * Expiration => cmn.LifecycleDelete
+// * Transition => cmn.LifecycleEvict (the cached copy gets "transitioned" to
+//   the backend; the storage class is ignored)
+// An S3 rule that has both results in two AIS rules with the same ID.
+// Filtering by tags, date-based expiration and transition, and noncurrent version
+// actions are not supported.
+
+const (
+	lifecycleEnabled  = "Enabled"
+	lifecycleDisabled = "Disabled"
+
+	lifecycleStorageClass = "GLACIER" // reported in GetBucketLifecycle (ignored in Put)
+)
+
+type (
+	// GetBucketLifecycleConfiguration response and PutBucketLifecycleConfiguration request
+	LifecycleConfiguration struct {
+		XMLName xml.Name        `xml:"LifecycleConfiguration"`
+		Ns      string          `xml:"xmlns,attr,omitempty"`
+		Rules   []LifecycleRule `xml:"Rule"`
+	}
+	LifecycleRule struct {
+		ID         string               `xml:"ID,omitempty"`
+		Filter     *LifecycleFilter     `xml:"Filter,omitempty"`
+		Prefix     *string              `xml:"Prefix,omitempty"` // legacy (instead of Filter)
+		Status     string               `xml:"Status"`
+		Expiration *LifecycleExpiration `xml:"Expiration,omitempty"`
+		Transition *LifecycleTransition `xml:"Transition,omitempty"`
+
+		// not supported
+		NoncurrentExpiration *struct{} `xml:"NoncurrentVersionExpiration,omitempty"`
+		NoncurrentTransition *struct{} `xml:"NoncurrentVersionTransition,omitempty"`
+	}
+	LifecycleFilter struct {
+		Prefix string    `xml:"Prefix"`
+		Tag    *Tag      `xml:"Tag,omitempty"` // not supported
+		And    *struct{} `xml:"And,omitempty"` // ditto
+	}
+	LifecycleExpiration struct {
+		Days int64  `xml:"Days,omitempty"`
+		Date string `xml:"Date,omitempty"` // not supported
+	}
+	LifecycleTransition struct {
+		Days         int64  `xml:"Days,omitempty"`
+		Date         string `xml:"Date,omitempty"` // not supported
+		StorageClass string `xml:"StorageClass,omitempty"`
+	}
+)
+
+var ErrNoLifecycle = errors.New("the lifecycle configuration does not exist")
+
+// NewLifecycleConfiguration converts AIS lifecycle rules; consecutive AIS
+// rules with the same ID, prefix, and status are merged into a single S3 rule.
+func NewLifecycleConfiguration(conf *cmn.LifecycleConf) (*LifecycleConfiguration, error) {
+	if len(conf.Rules) == 0 {
+		return nil, ErrNoLifecycle
+	}
+	lc := &LifecycleConfiguration{Ns: s3Namespace, Rules: make([]LifecycleRule, 0, len(conf.Rules))}
+	for i := range conf.Rules {
+		var (
+			rule   = &conf.Rules[i]
+			status = lifecycleDisabled
+			s3rule *LifecycleRule
+		)
+		if rule.Enabled {
+			status = lifecycleEnabled
+		}
+		if l := len(lc.Rules); l > 0 {
+			prev := &lc.Rules[l-1]
+			if prev.ID == rule.ID && prev.Filter.Prefix == rule.Prefix && prev.Status == status {
+				s3rule = prev
+			}
+		}
+		if s3rule == nil {
+			lc.Rules = append(lc.Rules, LifecycleRule{
+				ID:     rule.ID,
+				Filter: &LifecycleFilter{Prefix: rule.Prefix},
+				Status: status,
+			})
+			s3rule = &lc.Rules[len(lc.Rules)-1]
+		}
+		switch rule.Action {
+		case cmn.LifecycleDelete:
+			s3rule.Expiration = &LifecycleExpiration{Days: rule.Days}
+		case cmn.LifecycleEvict:
+			s3rule.Transition = &LifecycleTransition{Days: rule.Days, StorageClass: lifecycleStorageClass}
+		}
+	}
+	return lc, nil
+}
+
+func (lc *LifecycleConfiguration) MustMarshal() []byte {
+	b, err := xml.Marshal(lc)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// Conf converts S3 lifecycle configuration into AIS lifecycle rules.
+func (lc *LifecycleConfiguration) Conf() (*cmn.LifecycleConf, error) {
+	conf := &cmn.LifecycleConf{Rules: make([]cmn.LifecycleRule, 0, len(lc.Rules))}
+	for i := range lc.Rules {
+		s3rule := &lc.Rules[i]
+		id := s3rule.ID
+		if id == "" {
+			id = fmt.Sprintf("rule-%d", i+1)
+		}
+		if s3rule.Status != lifecycleEnabled && s3rule.Status != lifecycleDisabled {
+			return nil, fmt.Errorf("lifecycle rule %q: invalid status %q", id, s3rule.Status)
+		}
+		if s3rule.NoncurrentExpiration != nil || s3rule.NoncurrentTransition != nil {
+			return nil, fmt.Errorf("lifecycle rule %q: noncurrent version actions are not supported", id)
+		}
+		var prefix string
+		switch {
+		case s3rule.Filter != nil:
+			if s3rule.Filter.Tag != nil || s3rule.Filter.And != nil {
+				return nil, fmt.Errorf("lifecycle rule %q: filtering by tags is not supported", id)
+			}
+			prefix = s3rule.Filter.Prefix
+		case s3rule.Prefix != nil:
+			prefix = *s3rule.Prefix
+		}
+		if s3rule.Expiration == nil && s3rule.Transition == nil {
+			return nil, fmt.Errorf("lifecycle rule %q: no action (expected expiration and/or transition)", id)
+		}
+		rule := cmn.LifecycleRule{ID: id, Prefix: prefix, Enabled: s3rule.Status == lifecycleEnabled}
+		if exp := s3rule.Expiration; exp != nil {
+			if exp.Date != "" || exp.Days <= 0 {
+				return nil, fmt.Errorf("lifecycle rule %q: expiration must specify days", id)
+			}
+			rule.Action, rule.Days = cmn.LifecycleDelete, exp.Days
+			conf.Rules = append(conf.Rules, rule)
+		}
+		if tr := s3rule.Transition; tr != nil {
+			if tr.Date != "" || tr.Days <= 0 {
+				return nil, fmt.Errorf("lifecycle rule %q: transition must specify days", id)
+			}
+			rule.Action, rule.Days = cmn.LifecycleEvict, tr.Days
+			conf.Rules = append(conf.Rules, rule)
+		}
+	}
+	return conf, conf.Validate()
+}
@@ -0,0 +1,71 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestLifecycleConfiguration(t *testing.T) {
+	body := `<LifecycleConfiguration>` +
+		`<Rule><ID>logs</ID><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status>` +
+		`<Transition><Days>7</Days><StorageClass>GLACIER</StorageClass></Transition>` +
+		`<Expiration><Days>30</Days></Expiration></Rule>` +
+		`<Rule><Prefix>tmp/</Prefix><Status>Disabled</Status><Expiration><Days>1</Days></Expiration></Rule>` +
+		`</LifecycleConfiguration>`
+	lc := &LifecycleConfiguration{}
+	tassert.CheckFatal(t, xml.Unmarshal([]byte(body), lc))
+	conf, err := lc.Conf()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(conf.Rules) == 3, "expected 3 rules, got %+v", conf.Rules)
+	expected := []cmn.LifecycleRule{
+		{ID: "logs", Prefix: "logs/", Action: cmn.LifecycleDelete, Days: 30, Enabled: true},
+		{ID: "logs", Prefix: "logs/", Action: cmn.LifecycleEvict, Days: 7, Enabled: true},
+		{ID: "rule-2", Prefix: "tmp/", Action: cmn.LifecycleDelete, Days: 1, Enabled: false},
+	}
+	for i := range expected {
+		tassert.Errorf(t, conf.Rules[i] == expected[i], "rule #%d: expected %+v, got %+v",
+			i, expected[i], conf.Rules[i])
+	}
+
+	// and back
+	out, err := NewLifecycleConfiguration(conf)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(out.Rules) == 2, "expected 2 S3 rules, got %d", len(out.Rules))
+	rule := out.Rules[0]
+	tassert.Errorf(t, rule.ID == "logs" && rule.Filter.Prefix == "logs/" && rule.Status == lifecycleEnabled &&
+		rule.Expiration.Days == 30 && rule.Transition.Days == 7, "unexpected rule: %+v", rule)
+	s := string(out.MustMarshal())
+	tassert.Errorf(t, strings.Contains(s, "<Filter><Prefix>tmp/</Prefix></Filter><Status>Disabled</Status>"),
+		"unexpected output: %s", s)
+
+	_, err = NewLifecycleConfiguration(&cmn.LifecycleConf{})
+	tassert.Errorf(t, err == ErrNoLifecycle, "expected %v, got %v", ErrNoLifecycle, err)
+}
+
+func TestLifecycleConfigurationUnsupported(t *testing.T) {
+	tests := []string{
+		`<Rule><Status>Enabled</Status></Rule>`,
+		`<Rule><Status>On</Status><Expiration><Days>1</Days></Expiration></Rule>`,
+		`<Rule><Status>Enabled</Status><Expiration><Date>2021-01-01T00:00:00Z</Date></Expiration></Rule>`,
+		`<Rule><Filter><Tag><Key>a</Key><Value>b</Value></Tag></Filter><Status>Enabled</Status>` +
+			`<Expiration><Days>1</Days></Expiration></Rule>`,
+		`<Rule><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>1</NoncurrentDays>` +
+			`</NoncurrentVersionExpiration></Rule>`,
+		`<Rule><ID>a</ID><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule>` +
+			`<Rule><ID>a</ID><Status>Enabled</Status><Expiration><Days>2</Days></Expiration></Rule>`,
+	}
+	for _, rules := range tests {
+		lc := &LifecycleConfiguration{}
+		tassert.CheckFatal(t, xml.Unmarshal([]byte("<LifecycleConfiguration>"+rules+"</LifecycleConfiguration>"), lc))
+		_, err := lc.Conf()
+		tassert.Errorf(t, err != nil, "expected error for %s", rules)
+	}
+}
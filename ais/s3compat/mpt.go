@@ -0,0 +1,324 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"crypto/md5"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/jsp"
+)
+
+// NOTE: the state of a multipart upload (see mpt) is stored in the upload's
+// directory (fs.MptUploadType) next to the uploaded parts, on a mountpath of the
+// target that owns the (HRW) object - and thus survives restarts. The directory
+// gets removed when the upload is completed or aborted, or when it becomes stale
+// (see RemoveStaleUpload).
+
+const mptManifest = "upload" // upload state; the rest of the files are parts
+
+type (
+	MptPart struct {
+		MD5  string `json:"md5"`  // MD5 of the part (*)
+		FQN  string `json:"fqn"`  // FQN of the part file
+		Size int64  `json:"size"` // part size in bytes (*)
+		Num  int64  `json:"num"`  // part number (*)
+	}
+	mpt struct {
+		BckName    string     `json:"bck"`
+		ObjName    string     `json:"obj"`
+		Started    int64      `json:"started"`              // unix time (ns)
+		Completing int        `json:"completing,omitempty"` // PID of the process completing the upload
+		Parts      []*MptPart `json:"parts"`                // in the order of arrival
+	}
+
+	// Multipart upload XML requests and responses
+	InitiateMptUploadResult struct {
+		Ns       string `xml:"xmlns,attr"`
+		Bucket   string `xml:"Bucket"`
+		Key      string `xml:"Key"`
+		UploadID string `xml:"UploadId"`
+	}
+	CompleteMptUpload struct {
+		Parts []*PartInfo `xml:"Part"`
+	}
+	CompleteMptUploadResult struct {
+		Ns     string `xml:"xmlns,attr"`
+		Bucket string `xml:"Bucket"`
+		Key    string `xml:"Key"`
+		ETag   string `xml:"ETag"`
+	}
+	ListPartsResult struct {
+		Ns       string      `xml:"xmlns,attr"`
+		Bucket   string      `xml:"Bucket"`
+		Key      string      `xml:"Key"`
+		UploadID string      `xml:"UploadId"`
+		Parts    []*PartInfo `xml:"Part"`
+	}
+	PartInfo struct {
+		ETag       string `xml:"ETag"`
+		PartNumber int64  `xml:"PartNumber"`
+		Size       int64  `xml:"Size,omitempty"`
+	}
+)
+
+var (
+	ErrMptCompleting = errors.New("multipart upload is being completed")
+
+	mu sync.Mutex // serializes updates of the upload state
+)
+
+// ValidUploadID returns true if the upload ID may have been generated by InitUpload.
+func ValidUploadID(id string) bool {
+	return cmn.IsValidUUID(id) && !strings.ContainsAny(id, "/.")
+}
+
+// Start multipart upload in the given (upload's) directory
+func InitUpload(dir, bckName, objName string) error {
+	if err := cmn.CreateDir(dir); err != nil {
+		return err
+	}
+	upload := &mpt{
+		BckName: bckName,
+		ObjName: objName,
+		Started: time.Now().UnixNano(),
+		Parts:   make([]*MptPart, 0, 8),
+	}
+	return upload.save(dir)
+}
+
+// PartFQN returns a new, unique, FQN for the given part.
+func PartFQN(dir string, num int64) string {
+	return filepath.Join(dir, strconv.FormatInt(num, 10)+"."+cmn.GenUUID())
+}
+
+// Add part to an active upload. Returns the previously uploaded part
+// with the same number, if any - the caller is expected to remove its file.
+func AddPart(dir, bckName, objName string, npart *MptPart) (prev *MptPart, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+	upload, err := load(dir, bckName, objName)
+	if err != nil {
+		return nil, err
+	}
+	if upload.completing() {
+		return nil, ErrMptCompleting
+	}
+	for i, part := range upload.Parts {
+		if part.Num == npart.Num {
+			upload.Parts[i] = npart
+			return part, upload.save(dir)
+		}
+	}
+	upload.Parts = append(upload.Parts, npart)
+	return nil, upload.save(dir)
+}
+
+// Validate the list of parts sent by a client in CompleteMultipartUpload
+// request against the parts that were actually uploaded, and mark the upload
+// as being completed - until either CompleteUpload or CancelCompletion.
+// Returns uploaded parts sorted by part number.
+func StartCompletion(dir, bckName, objName string, parts []*PartInfo) ([]*MptPart, error) {
+	mu.Lock()
+	defer mu.Unlock()
+	upload, err := load(dir, bckName, objName)
+	if err != nil {
+		return nil, err
+	}
+	if upload.completing() {
+		return nil, ErrMptCompleting
+	}
+	id := filepath.Base(dir)
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("upload %q: no parts to complete", id)
+	}
+	res := make([]*MptPart, 0, len(parts))
+	for i, part := range parts {
+		if i > 0 && part.PartNumber <= parts[i-1].PartNumber {
+			return nil, fmt.Errorf("upload %q: parts must be in ascending order", id)
+		}
+		var found *MptPart
+		for _, uploaded := range upload.Parts {
+			if uploaded.Num == part.PartNumber {
+				found = uploaded
+				break
+			}
+		}
+		if found == nil {
+			return nil, fmt.Errorf("upload %q: part %d not found", id, part.PartNumber)
+		}
+		if etag := strings.Trim(part.ETag, "\""); etag != "" && etag != found.MD5 {
+			return nil, fmt.Errorf("upload %q: part %d ETag mismatch (%q vs %q)",
+				id, part.PartNumber, etag, found.MD5)
+		}
+		res = append(res, found)
+	}
+	upload.Completing = os.Getpid()
+	if err := upload.save(dir); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// CancelCompletion makes the upload active again (e.g., when failed to assemble the object).
+func CancelCompletion(dir, bckName, objName string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	upload, err := load(dir, bckName, objName)
+	if err != nil {
+		return err
+	}
+	upload.Completing = 0
+	return upload.save(dir)
+}
+
+// CompleteUpload removes the upload (that is being completed) with all its parts.
+func CompleteUpload(dir string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	return os.RemoveAll(dir)
+}
+
+// AbortUpload removes the upload with all its parts - unless it is being completed.
+func AbortUpload(dir, bckName, objName string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	upload, err := load(dir, bckName, objName)
+	if err != nil {
+		return err
+	}
+	if upload.completing() {
+		return ErrMptCompleting
+	}
+	return os.RemoveAll(dir)
+}
+
+// RemoveStaleUpload removes the upload if it has been started before the given time.
+func RemoveStaleUpload(dir string, before time.Time) (removed bool, err error) {
+	mu.Lock()
+	defer mu.Unlock()
+	started := time.Time{}
+	upload := &mpt{}
+	if _, err = jsp.Load(filepath.Join(dir, mptManifest), upload, jsp.Plain()); err == nil {
+		started = time.Unix(0, upload.Started)
+	} else if os.IsNotExist(err) {
+		// (e.g., failed to start)
+		finfo, errStat := os.Stat(dir)
+		if errStat != nil {
+			return false, errStat
+		}
+		started = finfo.ModTime()
+	} else {
+		return false, err
+	}
+	if started.After(before) {
+		return false, nil
+	}
+	return true, os.RemoveAll(dir)
+}
+
+func NewInitiateMptUploadResult(bckName, objName, id string) *InitiateMptUploadResult {
+	return &InitiateMptUploadResult{Ns: s3Namespace, Bucket: bckName, Key: objName, UploadID: id}
+}
+
+func (r *InitiateMptUploadResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+func NewCompleteMptUploadResult(bckName, objName, etag string) *CompleteMptUploadResult {
+	return &CompleteMptUploadResult{Ns: s3Namespace, Bucket: bckName, Key: objName, ETag: etag}
+}
+
+func (r *CompleteMptUploadResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+func NewListPartsResult(bckName, objName, id string, parts []*PartInfo) *ListPartsResult {
+	return &ListPartsResult{Ns: s3Namespace, Bucket: bckName, Key: objName, UploadID: id, Parts: parts}
+}
+
+func (r *ListPartsResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+// Return the list of uploaded parts sorted by part number
+func ListParts(dir, bckName, objName string) ([]*PartInfo, error) {
+	mu.Lock()
+	upload, err := load(dir, bckName, objName)
+	mu.Unlock()
+	if err != nil {
+		return nil, err
+	}
+	parts := make([]*PartInfo, 0, len(upload.Parts))
+	for _, part := range upload.Parts {
+		parts = append(parts, &PartInfo{ETag: part.MD5, PartNumber: part.Num, Size: part.Size})
+	}
+	sort.Slice(parts, func(i, j int) bool { return parts[i].PartNumber < parts[j].PartNumber })
+	return parts, nil
+}
+
+func ParsePartNum(s string) (int64, error) {
+	partNum, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || partNum < 1 || partNum > maxPartNum {
+		return 0, fmt.Errorf("invalid part number %q (must be in 1-%d range)", s, maxPartNum)
+	}
+	return partNum, nil
+}
+
+// Amazon-compatible ETag of an object assembled from parts:
+// MD5 of the concatenated binary MD5s of the parts followed by "-<number of parts>"
+func MptETag(parts []*MptPart) string {
+	h := md5.New()
+	for _, part := range parts {
+		b, err := hex.DecodeString(part.MD5)
+		if err != nil {
+			return ""
+		}
+		h.Write(b)
+	}
+	return hex.EncodeToString(h.Sum(nil)) + "-" + strconv.Itoa(len(parts))
+}
+
+/////////
+// mpt //
+/////////
+
+func load(dir, bckName, objName string) (*mpt, error) {
+	upload := &mpt{}
+	if _, err := jsp.Load(filepath.Join(dir, mptManifest), upload, jsp.Plain()); err != nil {
+		if os.IsNotExist(err) {
+			return nil, fmt.Errorf("upload %q %s", filepath.Base(dir), cmn.DoesNotExist)
+		}
+		return nil, err
+	}
+	if upload.BckName != bckName || upload.ObjName != objName {
+		return nil, fmt.Errorf("upload %q does not belong to %s/%s", filepath.Base(dir), bckName, objName)
+	}
+	return upload, nil
+}
+
+func (upload *mpt) save(dir string) error {
+	return jsp.Save(filepath.Join(dir, mptManifest), upload, jsp.Plain())
+}
+
+// (the upload may have been left "completing" by the previous run of the target)
+func (upload *mpt) completing() bool { return upload.Completing == os.Getpid() }
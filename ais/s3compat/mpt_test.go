@@ -0,0 +1,110 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+const (
+	md5One = "0cc175b9c0f1b6a831c399e269772661"
+	md5Two = "92eb5ffee6ae2fec3ad71c777531578f"
+)
+
+func TestMptParts(t *testing.T) {
+	const (
+		bckName = "bck"
+		objName = "obj"
+	)
+	dir, err := ioutil.TempDir("", "mpt")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+	dir = filepath.Join(dir, "upload-id")
+	tassert.CheckFatal(t, InitUpload(dir, bckName, objName))
+
+	prev, err := AddPart(dir, bckName, objName, &MptPart{MD5: md5Two, FQN: "fqn-2", Size: 1, Num: 2})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, prev == nil, "unexpected previous part")
+	_, err = AddPart(dir, bckName, objName, &MptPart{MD5: md5Two, FQN: "fqn-1", Size: 1, Num: 1})
+	tassert.CheckFatal(t, err)
+	// re-upload part #1
+	prev, err = AddPart(dir, bckName, objName, &MptPart{MD5: md5One, FQN: "fqn-1.1", Size: 1, Num: 1})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, prev != nil && prev.FQN == "fqn-1", "expected previous part %q, got %+v", "fqn-1", prev)
+
+	_, err = AddPart(dir, bckName, "other-obj", &MptPart{MD5: md5One, Num: 3})
+	tassert.Fatalf(t, err != nil, "expected error when adding part to another object")
+
+	list, err := ListParts(dir, bckName, objName)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(list) == 2 && list[0].PartNumber == 1 && list[1].PartNumber == 2,
+		"unexpected list of parts: %+v", list)
+
+	_, err = StartCompletion(dir, bckName, objName, []*PartInfo{{PartNumber: 2}, {PartNumber: 1}})
+	tassert.Fatalf(t, err != nil, "expected error: parts out of order")
+	_, err = StartCompletion(dir, bckName, objName, []*PartInfo{{PartNumber: 3}})
+	tassert.Fatalf(t, err != nil, "expected error: part does not exist")
+	_, err = StartCompletion(dir, bckName, objName, []*PartInfo{{PartNumber: 1, ETag: md5Two}})
+	tassert.Fatalf(t, err != nil, "expected error: ETag mismatch")
+
+	parts, err := StartCompletion(dir, bckName, objName, []*PartInfo{
+		{PartNumber: 1, ETag: "\"" + md5One + "\""},
+		{PartNumber: 2, ETag: md5Two},
+	})
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(parts) == 2 && parts[0].FQN == "fqn-1.1", "unexpected parts: %+v", parts)
+
+	etag := MptETag(parts)
+	tassert.Fatalf(t, strings.HasSuffix(etag, "-2") && len(etag) == 32+2, "invalid ETag %q", etag)
+
+	// the upload is being completed
+	_, err = AddPart(dir, bckName, objName, &MptPart{MD5: md5One, Num: 3})
+	tassert.Fatalf(t, errors.Is(err, ErrMptCompleting), "expected %v, got %v", ErrMptCompleting, err)
+	err = AbortUpload(dir, bckName, objName)
+	tassert.Fatalf(t, errors.Is(err, ErrMptCompleting), "expected %v, got %v", ErrMptCompleting, err)
+	tassert.CheckFatal(t, CancelCompletion(dir, bckName, objName))
+	_, err = StartCompletion(dir, bckName, objName, []*PartInfo{{PartNumber: 1}})
+	tassert.CheckFatal(t, err)
+
+	tassert.CheckFatal(t, CompleteUpload(dir))
+	_, err = ListParts(dir, bckName, objName)
+	tassert.Fatalf(t, err != nil, "expected error: upload does not exist")
+}
+
+func TestMptRemoveStale(t *testing.T) {
+	dir, err := ioutil.TempDir("", "mpt")
+	tassert.CheckFatal(t, err)
+	defer os.RemoveAll(dir)
+	dir = filepath.Join(dir, "upload-id")
+	tassert.CheckFatal(t, InitUpload(dir, "bck", "obj"))
+
+	removed, err := RemoveStaleUpload(dir, time.Now().Add(-time.Hour))
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, !removed, "expected new upload to be kept")
+	removed, err = RemoveStaleUpload(dir, time.Now())
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, removed, "expected stale upload to be removed")
+	_, err = os.Stat(dir)
+	tassert.Errorf(t, os.IsNotExist(err), "expected %q to be removed, got %v", dir, err)
+}
+
+func TestParsePartNum(t *testing.T) {
+	for _, s := range []string{"1", "42", "10000"} {
+		_, err := ParsePartNum(s)
+		tassert.CheckError(t, err)
+	}
+	for _, s := range []string{"", "0", "-1", "10001", "abc"} {
+		_, err := ParsePartNum(s)
+		tassert.Errorf(t, err != nil, "expected error for part number %q", s)
+	}
+}
@@ -19,23 +19,39 @@ import (
 const defaultLastModified = 0 // When an object was not accessed yet
 
 type (
-	// List objects response
+	// List objects response (both ListObjects and ListObjectsV2)
 	ListObjectResult struct {
-		Ns                    string     `xml:"xmlns,attr"`
-		Prefix                string     `xml:"Prefix"`
-		KeyCount              int        `xml:"KeyCount"` // number of objects in the response
-		MaxKeys               int        `xml:"MaxKeys"`
-		IsTruncated           bool       `xml:"IsTruncated"`           // true if there are more pages to read
-		ContinuationToken     string     `xml:"ContinuationToken"`     // original ContinuationToken
-		NextContinuationToken string     `xml:"NextContinuationToken"` // NextContinuationToken to read the next page
-		Contents              []*ObjInfo `xml:"Contents"`              // list of objects
+		Ns          string `xml:"xmlns,attr"`
+		Name        string `xml:"Name"`
+		Prefix      string `xml:"Prefix"`
+		Delimiter   string `xml:"Delimiter,omitempty"`
+		KeyCount    int    `xml:"KeyCount"` // number of objects and common prefixes in the response
+		MaxKeys     int    `xml:"MaxKeys"`
+		IsTruncated bool   `xml:"IsTruncated"` // true if there are more pages to read
+		// ListObjects (V1)
+		Marker     string `xml:"Marker,omitempty"`     // original Marker
+		NextMarker string `xml:"NextMarker,omitempty"` // Marker to read the next page
+		// ListObjectsV2
+		ContinuationToken     string `xml:"ContinuationToken,omitempty"`     // original ContinuationToken
+		NextContinuationToken string `xml:"NextContinuationToken,omitempty"` // NextContinuationToken to read the next page
+		StartAfter            string `xml:"StartAfter,omitempty"`
+
+		Contents       []*ObjInfo      `xml:"Contents"`       // list of objects
+		CommonPrefixes []*CommonPrefix `xml:"CommonPrefixes"` // list of "directories" (when delimiter is defined)
+
+		listV2     bool
+		fetchOwner bool
 	}
 	ObjInfo struct {
-		Key          string `xml:"Key"`
-		LastModified string `xml:"LastModified"`
-		ETag         string `xml:"ETag"`
-		Size         int64  `xml:"Size"`
-		Class        string `xml:"StorageClass"`
+		Key          string    `xml:"Key"`
+		LastModified string    `xml:"LastModified"`
+		ETag         string    `xml:"ETag"`
+		Size         int64     `xml:"Size"`
+		Class        string    `xml:"StorageClass"`
+		Owner        *BckOwner `xml:"Owner,omitempty"`
+	}
+	CommonPrefix struct {
+		Prefix string `xml:"Prefix"`
 	}
 
 	// Response for object copy request
@@ -46,30 +62,55 @@ type (
 )
 
 func FillMsgFromS3Query(query url.Values, msg *cmn.SelectMsg) {
-	mxStr := query.Get("max-keys")
+	mxStr := query.Get(URLParamMaxKeys)
 	if pageSize, err := strconv.Atoi(mxStr); err == nil && pageSize > 0 {
 		msg.PageSize = uint(pageSize)
 	}
-	if prefix := query.Get("prefix"); prefix != "" {
+	if prefix := query.Get(URLParamPrefix); prefix != "" {
 		msg.Prefix = prefix
 	}
+	msg.Delimiter = query.Get(URLParamDelimiter)
 	var token string
-	if token = query.Get("continuation-token"); token != "" {
-		msg.ContinuationToken = token
+	if query.Get(URLParamListType) == listTypeV2 {
+		token = query.Get(URLParamContinuationToken)
+		// start-after makes sense only on first call. For the next call,
+		// when continuation-token is set, start-after is ignored
+		if after := query.Get(URLParamStartAfter); after != "" && token == "" {
+			msg.StartAfter = after
+		}
+	} else {
+		// ListObjects (V1): the marker is either an object name or the next
+		// marker returned by the previous call - the same as AIS token
+		token = query.Get(URLParamMarker)
 	}
-	// start-after makes sense only on first call. For the next call,
-	// when continuation-token is set, start-after is ignored
-	if after := query.Get("start-after"); after != "" && token == "" {
-		msg.StartAfter = after
+	if token != "" {
+		msg.ContinuationToken = token
 	}
 }
 
-func NewListObjectResult() *ListObjectResult {
-	return &ListObjectResult{
-		Ns:       s3Namespace,
-		MaxKeys:  1000,
-		Contents: make([]*ObjInfo, 0),
+func NewListObjectResult(bckName string, query url.Values) *ListObjectResult {
+	r := &ListObjectResult{
+		Ns:             s3Namespace,
+		Name:           bckName,
+		Prefix:         query.Get(URLParamPrefix),
+		Delimiter:      query.Get(URLParamDelimiter),
+		MaxKeys:        1000,
+		Contents:       make([]*ObjInfo, 0),
+		CommonPrefixes: make([]*CommonPrefix, 0),
+		listV2:         query.Get(URLParamListType) == listTypeV2,
 	}
+	if maxKeys, err := strconv.Atoi(query.Get(URLParamMaxKeys)); err == nil && maxKeys > 0 {
+		r.MaxKeys = maxKeys
+	}
+	if r.listV2 {
+		r.ContinuationToken = query.Get(URLParamContinuationToken)
+		r.StartAfter = query.Get(URLParamStartAfter)
+		r.fetchOwner, _ = cmn.ParseBool(query.Get(URLParamFetchOwner))
+	} else {
+		r.Marker = query.Get(URLParamMarker)
+		r.fetchOwner = true // V1 always returns the owner
+	}
+	return r
 }
 
 func (r *ListObjectResult) MustMarshal() []byte {
@@ -79,7 +120,15 @@ func (r *ListObjectResult) MustMarshal() []byte {
 }
 
 func (r *ListObjectResult) Add(entry *cmn.BucketEntry, smsg *cmn.SelectMsg) {
-	r.Contents = append(r.Contents, entryToS3(entry, smsg))
+	if entry.IsDir() {
+		r.CommonPrefixes = append(r.CommonPrefixes, &CommonPrefix{Prefix: entry.Name})
+		return
+	}
+	objInfo := entryToS3(entry, smsg)
+	if r.fetchOwner {
+		objInfo.Owner = &defaultOwner
+	}
+	r.Contents = append(r.Contents, objInfo)
 }
 
 func entryToS3(entry *cmn.BucketEntry, smsg *cmn.SelectMsg) *ObjInfo {
@@ -100,7 +149,11 @@ func entryToS3(entry *cmn.BucketEntry, smsg *cmn.SelectMsg) *ObjInfo {
 func (r *ListObjectResult) FillFromAisBckList(bckList *cmn.BucketList, smsg *cmn.SelectMsg) {
 	r.KeyCount = len(bckList.Entries)
 	r.IsTruncated = bckList.ContinuationToken != ""
-	r.ContinuationToken = bckList.ContinuationToken
+	if r.listV2 {
+		r.NextContinuationToken = bckList.ContinuationToken
+	} else {
+		r.NextMarker = bckList.ContinuationToken
+	}
 	for _, e := range bckList.Entries {
 		r.Add(e, smsg)
 	}
@@ -125,12 +178,13 @@ func lomCksum(lom *cluster.LOM) string {
 
 func SetHeaderFromLOM(header http.Header, lom *cluster.LOM, size int64) {
 	if cksumValue := lomCksum(lom); cksumValue != "" {
-		header.Set(headerETag, cksumValue)
+		header.Set(HeaderETag, cksumValue)
 	}
 	header.Set(headerAtime, FormatTime(lom.Atime()))
 	header.Set(cmn.HeaderContentLength, strconv.FormatInt(size, 10))
 	header.Set(cmn.HeaderContentType, cmn.ContentBinary)
 	header.Set(headerVersion, lom.Version())
+	setUserMDHeader(header, lom)
 }
 
 func SetETLHeader(header http.Header, lom *cluster.LOM) {
@@ -0,0 +1,99 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// NOTE: user-defined metadata (`x-amz-meta-*` headers) and tags are stored
+// in the object's custom metadata (see cluster.LOM.UserMD and LOM.Tags).
+
+type (
+	// GetObjectTagging response and PutObjectTagging request
+	Tagging struct {
+		XMLName xml.Name `xml:"Tagging"`
+		Ns      string   `xml:"xmlns,attr,omitempty"`
+		TagSet  []Tag    `xml:"TagSet>Tag"`
+	}
+	Tag struct {
+		Key   string `xml:"Key"`
+		Value string `xml:"Value"`
+	}
+)
+
+func NewTagging(tags cmn.SimpleKVs) *Tagging {
+	tagging := &Tagging{Ns: s3Namespace, TagSet: make([]Tag, 0, len(tags))}
+	for k, v := range tags {
+		tagging.TagSet = append(tagging.TagSet, Tag{Key: k, Value: v})
+	}
+	sort.Slice(tagging.TagSet, func(i, j int) bool { return tagging.TagSet[i].Key < tagging.TagSet[j].Key })
+	return tagging
+}
+
+func (t *Tagging) MustMarshal() []byte {
+	b, err := xml.Marshal(t)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
+
+func (t *Tagging) Tags() (cmn.SimpleKVs, error) {
+	tags := make(cmn.SimpleKVs, len(t.TagSet))
+	for _, tag := range t.TagSet {
+		if _, ok := tags[tag.Key]; ok {
+			return nil, fmt.Errorf("duplicate tag %q", tag.Key)
+		}
+		tags[tag.Key] = tag.Value
+	}
+	return tags, nil
+}
+
+// UserMDFromHeader returns user-defined metadata and tags of the object
+// from the PUT request headers.
+func UserMDFromHeader(header http.Header) (userMD, tags cmn.SimpleKVs, err error) {
+	for k, v := range header {
+		if !strings.HasPrefix(k, headerMetaPrefix) || len(v) == 0 {
+			continue
+		}
+		if userMD == nil {
+			userMD = make(cmn.SimpleKVs, 4)
+		}
+		// S3 metadata keys are case-insensitive and are returned in lower case
+		userMD[strings.ToLower(k[len(headerMetaPrefix):])] = v[0]
+	}
+	if tagging := header.Get(headerTagging); tagging != "" {
+		query, err := url.ParseQuery(tagging)
+		if err != nil {
+			return nil, nil, fmt.Errorf("invalid %s header: %v", headerTagging, err)
+		}
+		tags = make(cmn.SimpleKVs, len(query))
+		for k, v := range query {
+			if len(v) != 1 {
+				return nil, nil, fmt.Errorf("invalid %s header: duplicate tag %q", headerTagging, k)
+			}
+			tags[k] = v[0]
+		}
+	}
+	err = cmn.ValidateObjUserMD(userMD, tags)
+	return
+}
+
+func setUserMDHeader(header http.Header, lom *cluster.LOM) {
+	for k, v := range lom.UserMD() {
+		header.Set(headerMetaPrefix+k, v)
+	}
+	if tags := lom.Tags(); len(tags) > 0 {
+		header.Set(headerTaggingCount, strconv.Itoa(len(tags)))
+	}
+}
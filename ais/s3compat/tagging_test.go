@@ -0,0 +1,56 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestUserMDFromHeader(t *testing.T) {
+	hdr := make(http.Header)
+	hdr.Set("x-amz-meta-Color", "red")
+	hdr.Set("X-Amz-Meta-size", "xl")
+	hdr.Set("Content-Type", "text/plain")
+	hdr.Set(headerTagging, "project=ais&team=a%20b")
+	userMD, tags, err := UserMDFromHeader(hdr)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(userMD) == 2 && userMD["color"] == "red" && userMD["size"] == "xl",
+		"unexpected user metadata: %v", userMD)
+	tassert.Errorf(t, len(tags) == 2 && tags["project"] == "ais" && tags["team"] == "a b",
+		"unexpected tags: %v", tags)
+
+	hdr = make(http.Header)
+	hdr.Set(headerTagging, "a=1&a=2")
+	_, _, err = UserMDFromHeader(hdr)
+	tassert.Errorf(t, err != nil, "expected error for duplicate tag")
+
+	hdr = make(http.Header)
+	hdr.Set("x-amz-meta-big", strings.Repeat("x", 4096))
+	_, _, err = UserMDFromHeader(hdr)
+	tassert.Errorf(t, err != nil, "expected error for too large metadata")
+}
+
+func TestTagging(t *testing.T) {
+	body := `<Tagging><TagSet><Tag><Key>b</Key><Value>2</Value></Tag>` +
+		`<Tag><Key>a</Key><Value>1</Value></Tag></TagSet></Tagging>`
+	tagging := &Tagging{}
+	tassert.CheckFatal(t, xml.Unmarshal([]byte(body), tagging))
+	tags, err := tagging.Tags()
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(tags) == 2 && tags["a"] == "1" && tags["b"] == "2", "unexpected tags: %v", tags)
+
+	out := string(NewTagging(tags).MustMarshal())
+	tassert.Errorf(t, strings.Contains(out, "<TagSet><Tag><Key>a</Key><Value>1</Value></Tag><Tag><Key>b</Key>"),
+		"tags must be sorted by key: %s", out)
+
+	tagging.TagSet = append(tagging.TagSet, Tag{Key: "a", Value: "3"})
+	_, err = tagging.Tags()
+	tassert.Errorf(t, err != nil, "expected error for duplicate tag")
+}
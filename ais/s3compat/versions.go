@@ -0,0 +1,104 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"encoding/xml"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// S3 `versionId` is the AIS object version. ListObjectVersions returns the
+// current version of each object followed by its retained prior versions (see
+// cmn.VersionConf.Retain); size, ETag, and modification time are provided only
+// for the current versions.
+
+type (
+	ListVersionsResult struct {
+		Ns            string        `xml:"xmlns,attr"`
+		Name          string        `xml:"Name"`
+		Prefix        string        `xml:"Prefix"`
+		KeyMarker     string        `xml:"KeyMarker"`
+		NextKeyMarker string        `xml:"NextKeyMarker,omitempty"`
+		MaxKeys       int           `xml:"MaxKeys"`
+		IsTruncated   bool          `xml:"IsTruncated"`
+		Versions      []*ObjVersion `xml:"Version"`
+	}
+	ObjVersion struct {
+		Key          string `xml:"Key"`
+		VersionID    string `xml:"VersionId"`
+		IsLatest     bool   `xml:"IsLatest"`
+		LastModified string `xml:"LastModified,omitempty"`
+		ETag         string `xml:"ETag,omitempty"`
+		Size         int64  `xml:"Size,omitempty"`
+	}
+)
+
+// VersionIDFromQuery returns the requested version of the object
+// (empty if the current version is requested).
+func VersionIDFromQuery(query url.Values) string {
+	if version := query.Get(URLParamVersionID); version != versionIDNull {
+		return version
+	}
+	return ""
+}
+
+func FillMsgFromS3VersionsQuery(query url.Values, msg *cmn.SelectMsg) {
+	if pageSize, err := strconv.Atoi(query.Get(URLParamMaxKeys)); err == nil && pageSize > 0 {
+		msg.PageSize = uint(pageSize)
+	}
+	msg.Prefix = query.Get(URLParamPrefix)
+	msg.ContinuationToken = query.Get(URLParamKeyMarker)
+	msg.SetFlag(cmn.SelectVersions)
+}
+
+func NewListVersionsResult(bckName string, query url.Values) *ListVersionsResult {
+	r := &ListVersionsResult{
+		Ns:        s3Namespace,
+		Name:      bckName,
+		Prefix:    query.Get(URLParamPrefix),
+		KeyMarker: query.Get(URLParamKeyMarker),
+		MaxKeys:   1000,
+		Versions:  make([]*ObjVersion, 0),
+	}
+	if maxKeys, err := strconv.Atoi(query.Get(URLParamMaxKeys)); err == nil && maxKeys > 0 {
+		r.MaxKeys = maxKeys
+	}
+	return r
+}
+
+func (r *ListVersionsResult) FillFromAisBckList(bckList *cmn.BucketList, smsg *cmn.SelectMsg) {
+	r.IsTruncated = bckList.ContinuationToken != ""
+	r.NextKeyMarker = bckList.ContinuationToken
+	for _, entry := range bckList.Entries {
+		if entry.IsDir() {
+			continue
+		}
+		objInfo := entryToS3(entry, smsg)
+		r.Versions = append(r.Versions, &ObjVersion{
+			Key:          entry.Name,
+			VersionID:    cmn.Either(entry.Version, versionIDNull),
+			IsLatest:     true,
+			LastModified: objInfo.LastModified,
+			ETag:         objInfo.ETag,
+			Size:         objInfo.Size,
+		})
+		if entry.Versions == "" {
+			continue
+		}
+		for _, version := range strings.Split(entry.Versions, ",") {
+			r.Versions = append(r.Versions, &ObjVersion{Key: entry.Name, VersionID: version})
+		}
+	}
+}
+
+func (r *ListVersionsResult) MustMarshal() []byte {
+	b, err := xml.Marshal(r)
+	cmn.AssertNoErr(err)
+	return []byte(xml.Header + string(b))
+}
@@ -0,0 +1,60 @@
+// Package s3compat provides Amazon S3 compatibility layer
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package s3compat
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestVersionIDFromQuery(t *testing.T) {
+	tassert.Errorf(t, VersionIDFromQuery(url.Values{}) == "", "expected current version")
+	tassert.Errorf(t, VersionIDFromQuery(url.Values{URLParamVersionID: {"null"}}) == "", "expected current version")
+	tassert.Errorf(t, VersionIDFromQuery(url.Values{URLParamVersionID: {"3"}}) == "3", "expected version 3")
+}
+
+func TestListVersions(t *testing.T) {
+	query := url.Values{
+		URLParamVersions:  {""},
+		URLParamPrefix:    {"dir/"},
+		URLParamKeyMarker: {"dir/a"},
+		URLParamMaxKeys:   {"2"},
+	}
+	msg := &cmn.SelectMsg{}
+	FillMsgFromS3VersionsQuery(query, msg)
+	tassert.Errorf(t, msg.PageSize == 2 && msg.Prefix == "dir/" && msg.ContinuationToken == "dir/a",
+		"unexpected select message: %+v", msg)
+	tassert.Errorf(t, msg.IsFlagSet(cmn.SelectVersions), "expected %q to be requested", cmn.GetPropsVersions)
+
+	bckList := &cmn.BucketList{
+		Entries: []*cmn.BucketEntry{
+			{Name: "dir/b", Version: "4", Versions: "3,1", Checksum: "cksum", Size: 10},
+			{Name: "dir/c"},
+		},
+		ContinuationToken: "dir/c",
+	}
+	r := NewListVersionsResult("bck", query)
+	r.FillFromAisBckList(bckList, msg)
+	tassert.Errorf(t, r.MaxKeys == 2 && r.IsTruncated && r.NextKeyMarker == "dir/c", "unexpected result: %+v", r)
+	tassert.Fatalf(t, len(r.Versions) == 4, "expected 4 versions, got %d", len(r.Versions))
+	expected := []struct {
+		key, version string
+		latest       bool
+	}{
+		{"dir/b", "4", true}, {"dir/b", "3", false}, {"dir/b", "1", false}, {"dir/c", versionIDNull, true},
+	}
+	for i, e := range expected {
+		v := r.Versions[i]
+		tassert.Errorf(t, v.Key == e.key && v.VersionID == e.version && v.IsLatest == e.latest,
+			"version #%d: expected %+v, got %+v", i, e, v)
+	}
+	s := string(r.MustMarshal())
+	tassert.Errorf(t, strings.Contains(s, "<Version><Key>dir/b</Key><VersionId>3</VersionId><IsLatest>false</IsLatest></Version>"),
+		"unexpected output: %s", s)
+}
@@ -11,6 +11,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"path"
 	"path/filepath"
 	"sort"
 	"strconv"
@@ -33,6 +34,7 @@ import (
 	"github.com/NVIDIA/aistore/nl"
 	"github.com/NVIDIA/aistore/reb"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/xaction"
 	"github.com/NVIDIA/aistore/xaction/xreg"
@@ -81,6 +83,7 @@ type (
 		rebManager   *reb.Manager
 		dbDriver     dbdriver.Driver
 		transactions transactions
+		wb           writeBack
 		gfn          struct {
 			local  localGFN
 			global globalGFN
@@ -199,13 +202,19 @@ func (t *targetrunner) Run() error {
 
 	t.checkRestarted()
 
-	// register object type and workfile type
+	// register object type, workfile type, prior versions of objects, and multipart uploads
 	if err := fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{}); err != nil {
 		cmn.ExitLogf("%v", err)
 	}
 	if err := fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{}); err != nil {
 		cmn.ExitLogf("%v", err)
 	}
+	if err := fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
+	if err := fs.CSM.RegisterContentType(fs.MptUploadType, &fs.MptUploadContentResolver{}); err != nil {
+		cmn.ExitLogf("%v", err)
+	}
 
 	dryRunInit()
 
@@ -255,6 +264,13 @@ func (t *targetrunner) Run() error {
 	// transactions
 	t.transactions.init(t)
 
+	// write-back queue (remote buckets)
+	t.wb.init(t)
+
+	// periodic bucket lifecycle (policy-based deletion and eviction)
+	t.initLifecycle()
+	t.initMpt()
+
 	t.rebManager = reb.NewManager(t, config, t.statsT)
 
 	// register storage target's handler(s) and start listening
@@ -297,23 +313,12 @@ func (c clouds) init(t *targetrunner) {
 	}
 }
 
-// 3rd part cloud: empty stubs unless populated via build tags
+// 3rd party backends (see backend.Register): the built-in ones are empty stubs
+// unless populated via build tags
 func (c clouds) initExt(t *targetrunner) (err error) {
 	config := cmn.GCO.Get()
 	for provider := range config.Backend.Providers {
-		switch provider {
-		case cmn.ProviderAmazon:
-			c[provider], err = backend.NewAWS(t)
-		case cmn.ProviderAzure:
-			c[provider], err = backend.NewAzure(t)
-		case cmn.ProviderGoogle:
-			c[provider], err = backend.NewGCP(t)
-		case cmn.ProviderHDFS:
-			c[provider], err = backend.NewHDFS(t)
-		default:
-			err = fmt.Errorf("unknown backend provider: %q", provider)
-		}
-		if err != nil {
+		if c[provider], err = backend.New(provider, t, config); err != nil {
 			return
 		}
 	}
@@ -327,6 +332,7 @@ func (t *targetrunner) initRecvHandlers() {
 		{r: cmn.Daemon, h: t.daemonHandler, net: accessNetPublicControl},
 		{r: cmn.Metasync, h: t.metasyncHandler, net: accessNetIntraControl},
 		{r: cmn.Health, h: t.healthHandler, net: accessNetPublicControl},
+		{r: "/" + cmn.Metrics, h: t.metricsHandler, net: accessNetPublicControl},
 		{r: cmn.Xactions, h: t.xactHandler, net: accessNetIntraControl},
 		{r: cmn.Rebalance, h: t.rebManager.RespHandler, net: accessNetIntraData},
 		{r: cmn.EC, h: t.ecHandler, net: accessNetIntraData},
@@ -341,6 +347,7 @@ func (t *targetrunner) initRecvHandlers() {
 		{r: cmn.Query, h: t.queryHandler, net: accessNetPublicControl},
 
 		{r: "/" + cmn.S3, h: t.s3Handler, net: accessNetPublicData},
+		{r: "/" + cmn.AZ, h: t.azHandler, net: accessNetPublicData},
 		{r: "/", h: cmn.InvalidHandler, net: accessNetAll},
 	}
 	t.registerNetworkHandlers(networkHandlers)
@@ -561,6 +568,10 @@ func (t *targetrunner) httpbckpost(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	switch msg.Action {
+	case cmn.ActGetBatch:
+		t.getBatch(w, r, request.bck, msg)
+	case cmn.ActDiffBck:
+		t.bucketDiff(w, r, request.bck, msg)
 	case cmn.ActPrefetch:
 		if !request.bck.IsRemote() {
 			t.invalmsghdlrf(w, r, "%s: expecting remote bucket, got %s, action=%s", t.si, request.bck, msg.Action)
@@ -710,13 +721,22 @@ func (t *targetrunner) getObject(w http.ResponseWriter, r *http.Request, query u
 		config       = cmn.GCO.Get()
 		isGFNRequest = cmn.IsParseBool(query.Get(cmn.URLParamIsGFNRequest))
 		started      = time.Now()
+		span         = tracing.StartRequest(r, "target.get")
 	)
+	defer span.End()
 
 	if ptime != "" {
 		if redelta := requestLatency(started, ptime); redelta != 0 {
 			t.statsT.Add(stats.GetRedirLatency, redelta)
 		}
 	}
+	if span != nil {
+		span.SetAttr("bucket", bck.String())
+		span.SetAttr("object", objName)
+		if isGFNRequest {
+			span.SetAttr("gfn", "true")
+		}
+	}
 	lom := cluster.AllocLOM(objName)
 	defer cluster.FreeLOM(lom)
 	if err := lom.Init(bck.Bck); err != nil {
@@ -725,10 +745,15 @@ func (t *targetrunner) getObject(w http.ResponseWriter, r *http.Request, query u
 			err = lom.Init(bck.Bck)
 		}
 		if err != nil {
+			span.SetErr(err)
 			t.invalmsghdlr(w, r, err.Error())
 			return
 		}
 	}
+	bwl, ok := t.allowTransfer(w, r, lom.Bck(), query)
+	if !ok {
+		return
+	}
 
 	if isETLRequest(query) {
 		t.doETL(w, r, query.Get(cmn.URLParamUUID), bck, objName)
@@ -739,17 +764,30 @@ func (t *targetrunner) getObject(w http.ResponseWriter, r *http.Request, query u
 		goi.started = started
 		goi.t = t
 		goi.lom = lom
-		goi.w = w
-		goi.ctx = context.Background()
+		goi.w = bwl.writer(w)
+		goi.ctx = tracing.NewContext(context.Background(), span)
 		goi.ranges = cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: 0}
 		goi.isGFN = isGFNRequest
 		goi.chunked = config.Net.HTTP.Chunked
+		goi.preconds = cmn.ParsePreconds(r.Header)
 	}
 	if bck.IsHTTP() {
 		originalURL := query.Get(cmn.URLParamOrigURL)
 		goi.ctx = context.WithValue(goi.ctx, cmn.CtxOriginalURL, originalURL)
 	}
-	if sent, errCode, err := goi.getObject(); err != nil {
+	var (
+		handled, sent bool
+		errCode       int
+		err           error
+	)
+	if version := query.Get(cmn.URLParamVersion); version != "" {
+		handled, sent, errCode, err = goi.getVersion(version, nil)
+	}
+	if !handled {
+		sent, errCode, err = goi.getObject()
+	}
+	if err != nil {
+		span.SetErr(err)
 		if sent {
 			// Cannot send error message at this point so we just glog.
 			glog.Errorf("GET %s: %v", lom, err)
@@ -804,6 +842,10 @@ func (t *targetrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 	}
+	bwl, ok := t.allowTransfer(w, r, lom.Bck(), query)
+	if !ok {
+		return
+	}
 	if lom.Load() == nil { // if exists, check custom md
 		srcProvider, hasSrc := lom.GetCustomMD(cluster.SourceObjMD)
 		if hasSrc && srcProvider != cluster.SourceWebObjMD {
@@ -821,8 +863,15 @@ func (t *targetrunner) httpobjput(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 	lom.SetAtimeUnix(started.UnixNano())
+	r.Body = bwl.reader(r.Body)
 	appendTy := query.Get(cmn.URLParamAppendType)
 	if appendTy == "" {
+		if !isIntraPut(r.Header) {
+			if err := setUserMDFromHdr(lom, r.Header); err != nil {
+				t.invalmsghdlr(w, r, err.Error())
+				return
+			}
+		}
 		if errCode, err := t.doPut(r, lom, started); err != nil {
 			t.fsErr(err, lom.FQN)
 			t.invalmsghdlr(w, r, err.Error(), errCode)
@@ -863,8 +912,19 @@ func (t *targetrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	bypass := bypassGovernance(r.Header)
+	if version := query.Get(cmn.URLParamVersion); version != "" {
+		if errCode, err := t.delObjVersion(lom, version, bypass); err != nil {
+			t.invalmsghdlr(w, r, err.Error(), errCode)
+		}
+		return
+	}
 
-	errCode, err := t.DeleteObject(context.Background(), lom, evict)
+	ctx := context.Background()
+	if bypass {
+		ctx = context.WithValue(ctx, cmn.CtxBypassGovernance, true)
+	}
+	errCode, err := t.DeleteObject(ctx, lom, evict)
 	if err != nil {
 		if errCode == http.StatusNotFound {
 			t.invalmsghdlrsilent(w, r,
@@ -876,8 +936,6 @@ func (t *targetrunner) httpobjdelete(w http.ResponseWriter, r *http.Request) {
 		}
 		return
 	}
-	// EC cleanup if EC is enabled
-	ec.ECM.CleanupObject(lom)
 }
 
 // POST /v1/objects/bucket-name/object-name
@@ -903,6 +961,12 @@ func (t *targetrunner) httpobjpost(w http.ResponseWriter, r *http.Request) {
 			return
 		}
 		t.promoteFQN(w, r, &msg)
+	case cmn.ActSetRetention:
+		if isRedirect(query) == "" {
+			t.invalmsghdlrf(w, r, "%s: %s-%s(obj) is expected to be redirected", t.si, r.Method, msg.Action)
+			return
+		}
+		t.setObjRetention(w, r, &msg)
 	default:
 		t.invalmsghdlrf(w, r, fmtUnknownAct, msg)
 	}
@@ -939,6 +1003,7 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 		checkExists    = cmn.IsParseBool(query.Get(cmn.URLParamCheckExists))
 		checkExistsAny = cmn.IsParseBool(query.Get(cmn.URLParamCheckExistsAny))
 		silent         = cmn.IsParseBool(query.Get(cmn.URLParamSilent))
+		prevVersion    bool
 	)
 	if silent {
 		invalidHandler = t.invalmsghdlrsilent
@@ -957,6 +1022,18 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 		invalidHandler(w, r, err.Error())
 		return
 	}
+	if version := query.Get(cmn.URLParamVersion); version != "" {
+		vlom, errCode, errV := loadPrevVersion(lom, version)
+		if errV != nil {
+			lom.Unlock(false)
+			invalidHandler(w, r, errV.Error(), errCode)
+			return
+		}
+		if vlom != nil {
+			defer cluster.FreeLOM(vlom)
+			lom, err, prevVersion = vlom, nil, true
+		}
+	}
 	lom.Unlock(false)
 
 	if glog.FastV(4, glog.SmoduleAIS) {
@@ -983,6 +1060,17 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 		}
 		return
 	}
+	if exists {
+		switch status := readPreconds(r, lom); status {
+		case http.StatusNotModified:
+			setETag(hdr, lom)
+			w.WriteHeader(status)
+			return
+		case http.StatusPreconditionFailed:
+			invalidHandler(w, r, fmt.Sprintf("%s: %s", lom, http.StatusText(status)), status)
+			return
+		}
+	}
 	if lom.Bck().IsAIS() || exists { // && !lom.VerConf().Enabled) {
 		if !exists {
 			invalidHandler(w, r, fmt.Sprintf("%s/%s %s", bck.Name, objName, cmn.DoesNotExist),
@@ -1009,7 +1097,10 @@ func (t *targetrunner) headObject(w http.ResponseWriter, r *http.Request, query
 	if exists {
 		objProps.Size = lom.Size()
 		objProps.NumCopies = lom.NumCopies()
-		if lom.Bck().Props.EC.Enabled {
+		if until := lom.RetainUntil(); !until.IsZero() {
+			objProps.RetainUntil = until.UnixNano()
+		}
+		if lom.Bck().Props.EC.Enabled && !prevVersion {
 			if md, err := ec.ObjectMetadata(lom.Bck(), objName); err == nil {
 				hdr.Set(cmn.HeaderObjECMeta, ec.MetaToString(md))
 			}
@@ -1237,6 +1328,9 @@ func (t *targetrunner) doPut(r *http.Request, lom *cluster.LOM, started time.Tim
 		}
 		poi.recvType = cluster.RecvType(n)
 	}
+	if poi.recvType == cluster.RegularPut {
+		poi.preconds = cmn.ParsePreconds(header)
+	}
 	sizeStr := header.Get("Content-Length")
 	if sizeStr != "" {
 		if size, ers := strconv.ParseInt(sizeStr, 10, 64); ers == nil {
@@ -1248,6 +1342,53 @@ func (t *targetrunner) doPut(r *http.Request, lom *cluster.LOM, started time.Tim
 	return
 }
 
+// Sets user-defined metadata and tags of the object that is being PUT by a client
+// replacing the existing ones, if any.
+func setUserMDFromHdr(lom *cluster.LOM, hdr http.Header) error {
+	userMD, err := cmn.KVsFromHdr(hdr, cmn.HeaderObjUserMD)
+	if err != nil {
+		return err
+	}
+	tags, err := cmn.KVsFromHdr(hdr, cmn.HeaderObjTags)
+	if err != nil {
+		return err
+	}
+	if err := cmn.ValidateObjUserMD(userMD, tags); err != nil {
+		return err
+	}
+	lom.SetUserMD(userMD)
+	lom.SetTags(tags)
+	return nil
+}
+
+// Initializes LOM of the object in ais bucket given by URL items: bucket name
+// followed by object name (used by S3 and Azure compatibility layers).
+func (t *targetrunner) initAISLOM(w http.ResponseWriter, r *http.Request, items []string) (lom *cluster.LOM) {
+	if len(items) < 2 {
+		t.invalmsghdlr(w, r, "object name is undefined")
+		return
+	}
+	bck := cluster.NewBck(items[0], cmn.ProviderAIS, cmn.NsGlobal)
+	if err := bck.Init(t.owner.bmd); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom = cluster.AllocLOM(path.Join(items[1:]...))
+	err := lom.Init(bck.Bck)
+	if err != nil {
+		if _, ok := err.(*cmn.ErrorRemoteBucketDoesNotExist); ok {
+			t.BMDVersionFixup(r, cmn.Bck{}, true /*sleep*/)
+			err = lom.Init(bck.Bck)
+		}
+	}
+	if err != nil {
+		cluster.FreeLOM(lom)
+		t.invalmsghdlr(w, r, err.Error())
+		return nil
+	}
+	return
+}
+
 func (t *targetrunner) putMirror(lom *cluster.LOM) {
 	const retries = 2
 	if !lom.MirrorConf().Enabled {
@@ -1279,6 +1420,7 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 		aisErr, backendErr         error
 		aisErrCode, backendErrCode int
 		delFromAIS, delFromBackend bool
+		wbPending                  bool
 	)
 	lom.Lock(true)
 	defer lom.Unlock(true)
@@ -1286,6 +1428,18 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 	delFromBackend = lom.Bck().IsRemote() && !evict
 	if err := lom.Load(false); err == nil {
 		delFromAIS = true
+		if err := lom.CheckRetention(ctxBypassGovernance(ctx)); err != nil {
+			return http.StatusForbidden, err
+		}
+		if err := lom.CheckVersionsRetention(ctxBypassGovernance(ctx)); err != nil {
+			if cmn.IsErrObjLocked(err) {
+				return http.StatusForbidden, err
+			}
+			return http.StatusInternalServerError, err
+		}
+		if wbPending = lom.IsWriteBackPending(); wbPending && evict {
+			return http.StatusConflict, fmt.Errorf("cannot evict %s: pending write-back", lom)
+		}
 	} else if !cmn.IsObjNotExist(err) {
 		return 0, err
 	} else {
@@ -1297,6 +1451,9 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 
 	if delFromBackend {
 		backendErrCode, backendErr = t.Backend(lom.Bck()).DeleteObj(ctx, lom)
+		if wbPending && backendErrCode == http.StatusNotFound {
+			backendErrCode, backendErr = 0, nil // not uploaded yet
+		}
 		if backendErr == nil {
 			t.statsT.Add(stats.DeleteCount, 1)
 		}
@@ -1304,6 +1461,14 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 	if delFromAIS {
 		size := lom.Size()
 		aisErr = lom.Remove()
+		if aisErr == nil && lom.Bck().IsAIS() {
+			if err := lom.DelAllVersions(); err != nil {
+				glog.Errorf("%s: failed to delete prior versions: %v", lom, err)
+			}
+		}
+		if wbPending && aisErr == nil {
+			t.wb.cancel(lom)
+		}
 		if aisErr != nil {
 			if !os.IsNotExist(aisErr) {
 				if backendErr != nil {
@@ -1322,6 +1487,10 @@ func (t *targetrunner) DeleteObject(ctx context.Context, lom *cluster.LOM, evict
 	if backendErr != nil {
 		return backendErrCode, backendErr
 	}
+	if aisErr == nil {
+		// EC cleanup if EC is enabled
+		ec.ECM.CleanupObject(lom)
+	}
 	return aisErrCode, aisErr
 }
 
@@ -1354,6 +1523,21 @@ func (t *targetrunner) renameObject(w http.ResponseWriter, r *http.Request, msg
 		t.invalmsghdlrf(w, r, "%s: cannot rename/move object %s onto itself", t.si, lom)
 		return
 	}
+	if lom.Bprops().ObjLock.Enabled() {
+		lom.Lock(false)
+		err := lom.Load(false)
+		if err == nil {
+			err = lom.CheckRetention(false)
+		}
+		if err == nil {
+			err = lom.CheckVersionsRetention(false)
+		}
+		lom.Unlock(false)
+		if cmn.IsErrObjLocked(err) {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
 	buf, slab := t.gmm.Alloc()
 	coi := allocCopyObjInfo()
 	{
@@ -1373,6 +1557,8 @@ func (t *targetrunner) renameObject(w http.ResponseWriter, r *http.Request, msg
 	lom.Lock(true)
 	if err = lom.Remove(); err != nil {
 		glog.Warningf("%s: failed to delete renamed object %s (new name %s): %v", t.si, lom, msg.Name, err)
+	} else if err = lom.DelAllVersions(); err != nil { // (prior versions are not renamed)
+		glog.Warningf("%s: failed to delete prior versions of the renamed object %s: %v", t.si, lom, err)
 	}
 	lom.Unlock(true)
 }
@@ -1121,7 +1121,59 @@ func TestRangeRead(t *testing.T) {
 		verifyInvalidRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=-1-0")
 		verifyInvalidRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=-1-2")
 		verifyInvalidRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=10--1")
-		verifyInvalidRangesQuery(t, proxyURL, bck.Bck, objName, "bytes=1-2,4-6")
+
+		tutils.Logln("Testing multi-range.")
+		verifyMultiRanges(t, proxyURL, bck.Bck, cksumProps.Type, objName, []cmn.HTTPRange{
+			{Start: 1, Length: 2}, {Start: 4, Length: 3},
+		})
+		verifyMultiRanges(t, proxyURL, bck.Bck, cksumProps.Type, objName, []cmn.HTTPRange{
+			{Start: 0, Length: 500}, {Start: 2000, Length: 1000}, {Start: int64(m.fileSize) - 1, Length: 1},
+		})
+	})
+}
+
+func TestGetBatch(t *testing.T) {
+	runProviderTests(t, func(t *testing.T, bck *cluster.Bck) {
+		var (
+			m = ioContext{
+				t:         t,
+				bck:       bck.Bck,
+				num:       100,
+				fileSize:  cmn.KiB,
+				fixedSize: true,
+			}
+			baseParams = tutils.BaseAPIParams()
+			missing    = "batch-missing-" + cmn.RandString(8)
+		)
+
+		m.init()
+		m.puts()
+		defer m.del()
+
+		names := append([]string{missing}, m.objNames...)
+		for _, format := range cmn.SupportedBatchFormats {
+			tutils.Logf("Batch GET %d objects (format %q)\n", len(names), format)
+			var (
+				received = cmn.NewStringSet()
+				msg      = &cmn.GetBatchMsg{ListMsg: cmn.ListMsg{ObjNames: names}, Format: format}
+			)
+			err := api.GetBatch(baseParams, bck.Bck, msg, func(hdr *cmn.BatchEntryHdr, r io.Reader) error {
+				tassert.Errorf(t, !received.Contains(hdr.Name), "duplicate entry %q", hdr.Name)
+				received.Add(hdr.Name)
+				if hdr.Name == missing {
+					tassert.Errorf(t, hdr.Err != "", "expected error entry for %q", hdr.Name)
+					return nil
+				}
+				tassert.Errorf(t, hdr.Err == "", "unexpected error entry %q: %s", hdr.Name, hdr.Err)
+				n, err := io.Copy(ioutil.Discard, r)
+				tassert.CheckFatal(t, err)
+				tassert.Errorf(t, n == int64(m.fileSize) && hdr.Size == n,
+					"%q: expected %d bytes, got %d (size %d)", hdr.Name, m.fileSize, n, hdr.Size)
+				return nil
+			})
+			tassert.CheckFatal(t, err)
+			tassert.Errorf(t, len(received) == len(names), "expected %d entries, got %d", len(names), len(received))
+		}
 	})
 }
 
@@ -1230,6 +1282,31 @@ func verifyValidRangesQuery(t *testing.T, proxyURL string, bck cmn.Bck, objName,
 	tassert.Errorf(t, contentRange != "", "%q header should be set", cmn.HeaderContentRange)
 }
 
+// NOTE: expects range checksums to be enabled (`EnableReadRange`)
+func verifyMultiRanges(t *testing.T, proxyURL string, bck cmn.Bck, cksumType, objName string, ranges []cmn.HTTPRange) {
+	var (
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		fqn        = findObjOnDisk(bck, objName)
+	)
+	parts, err := api.GetObjectRanges(baseParams, bck, objName, ranges)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(parts) == len(ranges), "expected %d parts, got %d", len(ranges), len(parts))
+
+	file, err := os.Open(fqn)
+	tassert.CheckFatal(t, err)
+	defer file.Close()
+	for i, part := range parts {
+		tassert.Errorf(t, part.HTTPRange == ranges[i], "part #%d: expected range %+v, got %+v", i, ranges[i], part.HTTPRange)
+		expected := make([]byte, ranges[i].Length)
+		_, err := file.ReadAt(expected, ranges[i].Start)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, bytes.Equal(part.Data, expected), "part #%d (%+v): data mismatch", i, part.HTTPRange)
+		if cksumType != cmn.ChecksumNone {
+			tassert.Errorf(t, part.Cksum != nil, "part #%d (%+v): expected range checksum", i, part.HTTPRange)
+		}
+	}
+}
+
 func verifyInvalidRangesQuery(t *testing.T, proxyURL string, bck cmn.Bck, objName, rangeQuery string) {
 	var (
 		baseParams = tutils.BaseAPIParams(proxyURL)
@@ -1240,6 +1317,172 @@ func verifyInvalidRangesQuery(t *testing.T, proxyURL string, bck cmn.Bck, objNam
 	tassert.Errorf(t, err != nil, "must fail for %q combination", rangeQuery)
 }
 
+func TestConditionalRequests(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL(t)
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: cmn.RandString(10), Provider: cmn.ProviderAIS}
+		objName    = "conditional/" + cmn.RandString(8)
+	)
+	tutils.CreateFreshBucket(t, proxyURL, bck, nil)
+
+	put := func(data, ifMatch, ifNoneMatch string) error {
+		return api.PutObject(api.PutObjectArgs{
+			BaseParams:  baseParams,
+			Bck:         bck,
+			Object:      objName,
+			Reader:      readers.NewBytesReader([]byte(data)),
+			IfMatch:     ifMatch,
+			IfNoneMatch: ifNoneMatch,
+		})
+	}
+
+	tutils.Logln("Create-only PUT (If-None-Match: *)")
+	tassert.CheckFatal(t, put("v1", "", "*"))
+	err := put("v1-again", "", "*")
+	tassert.Fatalf(t, api.HTTPStatus(err) == http.StatusPreconditionFailed, "expected 412, got %v", err)
+
+	tutils.Logln("Compare-and-swap PUT (If-Match)")
+	props, err := api.HeadObject(baseParams, bck, objName)
+	tassert.CheckFatal(t, err)
+	err = put("v2", "bogus", "")
+	tassert.Fatalf(t, api.HTTPStatus(err) == http.StatusPreconditionFailed, "expected 412, got %v", err)
+	tassert.CheckFatal(t, put("v2", props.Checksum.Value, ""))
+	err = put("v3", props.Checksum.Value, "") // stale
+	tassert.Fatalf(t, api.HTTPStatus(err) == http.StatusPreconditionFailed, "expected 412, got %v", err)
+
+	tutils.Logln("Conditional GET")
+	props, err = api.HeadObject(baseParams, bck, objName)
+	tassert.CheckFatal(t, err)
+	hdr := http.Header{cmn.HeaderIfNoneMatch: {`"` + props.Checksum.Value + `"`}}
+	resp, n, err := api.GetObjectWithResp(baseParams, bck, objName, api.GetObjectInput{Header: hdr})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, resp.StatusCode == http.StatusNotModified && n == 0,
+		"expected 304 and no content, got %d (%d bytes)", resp.StatusCode, n)
+
+	hdr = http.Header{cmn.HeaderIfMatch: {"bogus"}}
+	_, err = api.GetObject(baseParams, bck, objName, api.GetObjectInput{Header: hdr})
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusPreconditionFailed, "expected 412, got %v", err)
+
+	hdr = http.Header{cmn.HeaderIfModifiedSince: {time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat)}}
+	n, err = api.GetObject(baseParams, bck, objName, api.GetObjectInput{Header: hdr})
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, n == int64(len("v2")), "expected %d bytes, got %d", len("v2"), n)
+}
+
+func TestObjectVersionHistory(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL(t)
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: cmn.RandString(10), Provider: cmn.ProviderAIS}
+		objName    = "versions/" + cmn.RandString(8)
+		props      = &cmn.BucketPropsToUpdate{
+			Versioning: &cmn.VersionConfToUpdate{Enabled: api.Bool(true), Retain: api.Int(2)},
+		}
+	)
+	tutils.CreateFreshBucket(t, proxyURL, bck, props)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	for i := 1; i <= 4; i++ {
+		tassert.CheckFatal(t, api.PutObject(api.PutObjectArgs{
+			BaseParams: baseParams,
+			Bck:        bck,
+			Object:     objName,
+			Reader:     readers.NewBytesReader([]byte("content-" + strconv.Itoa(i))),
+		}))
+	}
+
+	tutils.Logln("Listing prior versions")
+	msg := &cmn.SelectMsg{Props: cmn.GetPropsVersion}
+	msg.SetFlag(cmn.SelectVersions)
+	objList, err := api.ListObjects(baseParams, bck, msg, 0)
+	tassert.CheckFatal(t, err)
+	tassert.Fatalf(t, len(objList.Entries) == 1, "expected 1 object, got %d", len(objList.Entries))
+	entry := objList.Entries[0]
+	tassert.Errorf(t, entry.Version == "4" && entry.Versions == "3,2",
+		"expected version 4 and prior versions 3,2, got %q and %q", entry.Version, entry.Versions)
+
+	tutils.Logln("Reading prior version")
+	get := func(version string) (string, error) {
+		buf := &bytes.Buffer{}
+		_, err := api.GetObject(baseParams, bck, objName, api.GetObjectInput{
+			Writer: buf,
+			Query:  url.Values{cmn.URLParamVersion: {version}},
+		})
+		return buf.String(), err
+	}
+	data, err := get("2")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, data == "content-2", "expected %q, got %q", "content-2", data)
+	_, err = get("1")
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusNotFound, "expected 404 (not retained), got %v", err)
+
+	tutils.Logln("Deleting prior version")
+	tassert.CheckFatal(t, api.DeleteObjectVersion(baseParams, bck, objName, "2"))
+	_, err = get("2")
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusNotFound, "expected 404, got %v", err)
+
+	tutils.Logln("Deleting current version")
+	tassert.CheckFatal(t, api.DeleteObjectVersion(baseParams, bck, objName, "4"))
+	objProps, err := api.HeadObject(baseParams, bck, objName)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, objProps.Version == "3", "expected version 3 to become current, got %q", objProps.Version)
+	data, err = get("3")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, data == "content-3", "expected %q, got %q", "content-3", data)
+}
+
+func TestObjectLock(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL(t)
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{Name: cmn.RandString(10), Provider: cmn.ProviderAIS}
+		objName    = "locked/" + cmn.RandString(8)
+		props      = &cmn.BucketPropsToUpdate{
+			ObjLock: &cmn.ObjLockConfToUpdate{Mode: api.String(cmn.ObjLockGovernance), Days: api.Int64(1)},
+		}
+		put = func() error {
+			return api.PutObject(api.PutObjectArgs{
+				BaseParams: baseParams,
+				Bck:        bck,
+				Object:     objName,
+				Reader:     readers.NewBytesReader([]byte("worm")),
+			})
+		}
+	)
+	tutils.CreateFreshBucket(t, proxyURL, bck, props)
+	defer tutils.DestroyBucket(t, proxyURL, bck)
+
+	tassert.CheckFatal(t, put())
+	objProps, err := api.HeadObject(baseParams, bck, objName)
+	tassert.CheckFatal(t, err)
+	retainUntil := time.Unix(0, objProps.RetainUntil)
+	tassert.Errorf(t, retainUntil.After(time.Now().Add(23*time.Hour)), "unexpected retain-until %v", retainUntil)
+
+	tutils.Logln("Modifying retained object")
+	err = api.DeleteObject(baseParams, bck, objName)
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusForbidden, "delete: expected 403, got %v", err)
+	err = put()
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusForbidden, "overwrite: expected 403, got %v", err)
+	err = api.RenameObject(baseParams, bck, objName, objName+".renamed")
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusForbidden, "rename: expected 403, got %v", err)
+
+	tutils.Logln("Extending and shortening retention")
+	extended := retainUntil.Add(time.Hour)
+	tassert.CheckFatal(t, api.SetObjectRetention(baseParams, bck, objName, extended))
+	objProps, err = api.HeadObject(baseParams, bck, objName)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, objProps.RetainUntil == extended.UnixNano(), "expected retain-until %v, got %v",
+		extended, time.Unix(0, objProps.RetainUntil))
+	err = api.SetObjectRetention(baseParams, bck, objName, time.Now())
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusForbidden, "shorten: expected 403, got %v", err)
+
+	tutils.Logln("Deleting with governance bypass")
+	tassert.CheckFatal(t, api.DeleteLockedObject(baseParams, bck, objName))
+	_, err = api.HeadObject(baseParams, bck, objName)
+	tassert.Errorf(t, api.HTTPStatus(err) == http.StatusNotFound, "expected 404, got %v", err)
+}
+
 func Test_checksum(t *testing.T) {
 	var (
 		m = ioContext{
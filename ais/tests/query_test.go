@@ -14,6 +14,7 @@ import (
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/devtools/tutils"
+	"github.com/NVIDIA/aistore/devtools/tutils/readers"
 	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
 	"github.com/NVIDIA/aistore/query"
 	jsoniter "github.com/json-iterator/go"
@@ -156,6 +157,64 @@ func TestQueryVersionAndAtime(t *testing.T) {
 	checkQueryDone(t, handle)
 }
 
+func TestQueryTagFilter(t *testing.T) {
+	var (
+		proxyURL   = tutils.RandomProxyURL()
+		baseParams = tutils.BaseAPIParams(proxyURL)
+		bck        = cmn.Bck{
+			Name:     "TESTQUERYBUCKET",
+			Provider: cmn.ProviderAIS,
+		}
+		numObjects = 10
+		tagged     = make(cmn.StringSet, numObjects/2)
+		taggedX    = make(cmn.StringSet, numObjects/4)
+	)
+
+	tutils.CreateFreshBucket(t, proxyURL, bck, nil)
+
+	for i := 0; i < numObjects; i++ {
+		objName := fmt.Sprintf("object-%d.txt", i)
+		r, err := readers.NewRandReader(cmn.KiB, cmn.ChecksumNone)
+		tassert.CheckFatal(t, err)
+		putArgs := api.PutObjectArgs{BaseParams: baseParams, Bck: bck, Object: objName, Reader: r}
+		// every other object is tagged, with different values of the tag
+		if i%2 == 0 {
+			value := "y"
+			if i%4 == 0 {
+				value = "x"
+				taggedX.Add(objName)
+			}
+			putArgs.Tags = cmn.SimpleKVs{"project": value}
+			tagged.Add(objName)
+		}
+		err = api.PutObject(putArgs)
+		r.Close()
+		tassert.CheckFatal(t, err)
+	}
+
+	for _, test := range []struct {
+		value    string
+		expected cmn.StringSet
+	}{
+		{value: "x", expected: taggedX},
+		{value: "", expected: tagged}, // any value
+	} {
+		handle, err := api.InitQuery(baseParams, "object-{0..100}.txt", bck, query.TagFilterMsg("project", test.value))
+		tassert.CheckFatal(t, err)
+
+		objects, err := api.NextQueryResults(baseParams, handle, uint(numObjects))
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, len(objects) == len(test.expected), "tag value %q: expected %d to be returned, got %d",
+			test.value, len(test.expected), len(objects))
+		for _, object := range objects {
+			tassert.Errorf(t, test.expected.Contains(object.Name), "tag value %q: unexpected object %s",
+				test.value, object.Name)
+		}
+
+		checkQueryDone(t, handle)
+	}
+}
+
 func TestQueryWorkersTargets(t *testing.T) {
 	var (
 		proxyURL   = tutils.RandomProxyURL()
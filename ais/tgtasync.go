@@ -97,14 +97,24 @@ func (t *targetrunner) bucketSummary(w http.ResponseWriter, r *http.Request, bck
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
-	t.doAsync(w, r, actionMsg.Action, bck, &msg)
+	t.doAsync(w, r, actionMsg.Action, bck, msg.UUID, &msg)
+}
+
+func (t *targetrunner) bucketDiff(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, actionMsg *aisMsg) {
+	var msg cmn.BucketDiffMsg
+	if err := cmn.MorphMarshal(actionMsg.Value, &msg); err != nil {
+		t.invalmsghdlrf(w, r, "unable to unmarshal 'value' in request to a cmn.BucketDiffMsg: %v", actionMsg.Value)
+		return
+	}
+	t.doAsync(w, r, actionMsg.Action, bck, msg.UUID, &msg)
 }
 
 // asynchronous bucket request
 // - creates a new task that runs in background
 // - returns status of a running task by its ID
 // - returns the result of a task by its ID
-func (t *targetrunner) doAsync(w http.ResponseWriter, r *http.Request, action string, bck *cluster.Bck, msg *cmn.BucketSummaryMsg) {
+func (t *targetrunner) doAsync(w http.ResponseWriter, r *http.Request, action string, bck *cluster.Bck,
+	uuid string, msg interface{}) {
 	var (
 		query      = r.URL.Query()
 		taskAction = query.Get(cmn.URLParamTaskAction)
@@ -119,7 +129,9 @@ func (t *targetrunner) doAsync(w http.ResponseWriter, r *http.Request, action st
 
 		switch action {
 		case cmn.ActSummaryBck:
-			err = xreg.RenewBckSummary(ctx, t, bck, msg)
+			err = xreg.RenewBckSummary(ctx, t, bck, msg.(*cmn.BucketSummaryMsg))
+		case cmn.ActDiffBck:
+			err = xreg.RenewBckDiff(ctx, t, bck, msg.(*cmn.BucketDiffMsg))
 		default:
 			t.invalmsghdlrf(w, r, "invalid action: %s", action)
 			return
@@ -134,10 +146,10 @@ func (t *targetrunner) doAsync(w http.ResponseWriter, r *http.Request, action st
 		return
 	}
 
-	xact := xreg.GetXact(msg.UUID)
+	xact := xreg.GetXact(uuid)
 	// task never started
 	if xact == nil {
-		s := fmt.Sprintf("Task %s not found", msg.UUID)
+		s := fmt.Sprintf("Task %s not found", uuid)
 		if silent {
 			t.invalmsghdlrsilent(w, r, s, http.StatusNotFound)
 		} else {
@@ -0,0 +1,178 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/azcompat"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// [METHOD] /az/<container>/<blob>
+func (t *targetrunner) azHandler(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := t.checkRESTItems(w, r, 0, true, cmn.URLPathAZ.L)
+	if err != nil {
+		return
+	}
+	azcompat.SetCommonHeaders(w.Header())
+	switch r.Method {
+	case http.MethodHead:
+		t.headBlobAz(w, r, apiItems)
+	case http.MethodGet:
+		t.getBlobAz(w, r, apiItems)
+	case http.MethodPut:
+		t.putBlobAz(w, r, apiItems)
+	case http.MethodDelete:
+		t.delBlobAz(w, r, apiItems)
+	default:
+		t.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
+	}
+}
+
+func (t *targetrunner) loadBlobAz(w http.ResponseWriter, r *http.Request, lom *cluster.LOM) bool {
+	lom.Lock(false)
+	err := lom.Load(true)
+	lom.Unlock(false)
+	if err == nil {
+		return true
+	}
+	if cmn.IsObjNotExist(err) {
+		w.Header().Set(azcompat.HeaderErrorCode, "BlobNotFound")
+		t.invalmsghdlrsilent(w, r, err.Error(), http.StatusNotFound)
+	} else {
+		t.invalmsghdlr(w, r, err.Error())
+	}
+	return false
+}
+
+// HEAD az/container/blob - get blob properties
+func (t *targetrunner) headBlobAz(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	if !t.loadBlobAz(w, r, lom) {
+		return
+	}
+	azcompat.SetBlobHeaders(w.Header(), lom)
+	w.Header().Set(cmn.HeaderContentLength, strconv.FormatInt(lom.Size(), 10))
+}
+
+// GET az/container/blob
+func (t *targetrunner) getBlobAz(w http.ResponseWriter, r *http.Request, items []string) {
+	started := time.Now()
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	if !t.loadBlobAz(w, r, lom) {
+		return
+	}
+	bwl, ok := t.allowTransfer(w, r, lom.Bck(), r.URL.Query())
+	if !ok {
+		return
+	}
+	// Azure clients may pass the range in either header; `x-ms-range` takes precedence
+	rangeHdr := r.Header.Get(azcompat.HeaderRange)
+	if rangeHdr == "" {
+		rangeHdr = r.Header.Get(cmn.HeaderRange)
+	}
+	goi := allocGetObjInfo()
+	{
+		goi.started = started
+		goi.t = t
+		goi.lom = lom
+		goi.w = bwl.writer(w)
+		goi.ctx = context.Background()
+		goi.ranges = cmn.RangesQuery{Range: rangeHdr, Size: lom.Size()}
+	}
+	azcompat.SetBlobHeaders(w.Header(), lom)
+	if sent, errCode, err := goi.getObject(); err != nil {
+		if sent {
+			// Cannot send error message at this point so we just glog.
+			glog.Errorf("GET %s: %v", lom, err)
+		} else {
+			t.invalmsghdlr(w, r, err.Error(), errCode)
+		}
+	}
+	freeGetObjInfo(goi)
+}
+
+// PUT az/container/blob
+func (t *targetrunner) putBlobAz(w http.ResponseWriter, r *http.Request, items []string) {
+	started := time.Now()
+	if comp := r.URL.Query().Get(azcompat.URLParamComp); comp != "" {
+		t.invalmsghdlrf(w, r, "%s: PUT %s=%s is not supported", t.si, azcompat.URLParamComp, comp)
+		return
+	}
+	if ty := r.Header.Get(azcompat.HeaderBlobType); ty != "" && ty != azcompat.BlobTypeBlock {
+		t.invalmsghdlrf(w, r, "%s: blob type %q is not supported", t.si, ty)
+		return
+	}
+	if cs := fs.GetCapStatus(); cs.OOS {
+		t.invalmsghdlr(w, r, cs.Err.Error(), http.StatusInsufficientStorage)
+		return
+	}
+	userMD, err := azcompat.UserMDFromHeader(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	bwl, ok := t.allowTransfer(w, r, lom.Bck(), r.URL.Query())
+	if !ok {
+		return
+	}
+	if lom.Bck().IsAIS() && lom.VersionConf().Enabled {
+		lom.Load() // need to know the current version if versioning enabled
+	}
+	lom.SetAtimeUnix(started.UnixNano())
+	lom.SetUserMD(userMD)
+	r.Body = bwl.reader(r.Body)
+	if errCode, err := t.doPut(r, lom, started); err != nil {
+		t.fsErr(err, lom.FQN)
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	azcompat.SetPutBlobHeaders(w.Header(), lom)
+	w.WriteHeader(http.StatusCreated)
+}
+
+// DELETE az/container/blob
+func (t *targetrunner) delBlobAz(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	errCode, err := t.DeleteObject(context.Background(), lom, false)
+	if err != nil {
+		if errCode == http.StatusNotFound {
+			w.Header().Set(azcompat.HeaderErrorCode, "BlobNotFound")
+			t.invalmsghdlrsilent(w, r,
+				fmt.Sprintf("object %s/%s doesn't exist", lom.Bck(), lom.ObjName),
+				http.StatusNotFound,
+			)
+		} else {
+			t.invalmsghdlrstatusf(w, r, errCode, "error deleting %s: %v", lom, err)
+		}
+		return
+	}
+	w.WriteHeader(http.StatusAccepted)
+}
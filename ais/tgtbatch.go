@@ -0,0 +1,277 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Batch GET - see cmn.GetBatchMsg
+//
+// The proxy redirects the entire batch to a random target which becomes the
+// designated target for the request. The designated target:
+// * distributes object names between their owning (HRW) targets;
+// * reads its own share locally, in parallel (one goroutine per mountpath);
+// * concurrently, requests each of the other owners to read its share (the
+//   same `ActGetBatch` with `URLParamBatchPeer`) in cmn.BatchFormatLP;
+// * writes all the entries - in the order of arrival - into a single response.
+// Objects that cannot be read (including the entire share of an unreachable
+// target) result in error entries.
+
+type batchGetter struct {
+	t   *targetrunner
+	bck *cluster.Bck
+	ctx context.Context
+	bw  cmn.BatchWriter
+	mu  sync.Mutex
+	err error // failed to write the response - skip the rest
+}
+
+// POST { action: ActGetBatch } /v1/buckets/bucket-name
+func (t *targetrunner) getBatch(w http.ResponseWriter, r *http.Request, bck *cluster.Bck, msg *aisMsg) {
+	batchMsg := &cmn.GetBatchMsg{}
+	if err := cmn.MorphMarshal(msg.Value, batchMsg); err != nil {
+		t.invalmsghdlrf(w, r, "invalid %s action message: %v", msg.Action, err)
+		return
+	}
+	if err := batchMsg.Validate(); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	names, err := batchMsg.Names()
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	var (
+		local  []string
+		peers  = make(map[string][]string, 4) // target ID => names
+		smap   = t.owner.smap.get()
+		isPeer = cmn.IsParseBool(r.URL.Query().Get(cmn.URLParamBatchPeer))
+	)
+	if isPeer {
+		local = names
+	} else {
+		for _, name := range names {
+			tsi, err := cluster.HrwTarget(bck.MakeUname(name), &smap.Smap)
+			if err != nil {
+				t.invalmsghdlr(w, r, err.Error())
+				return
+			}
+			if tsi.ID() == t.si.ID() {
+				local = append(local, name)
+			} else {
+				peers[tsi.ID()] = append(peers[tsi.ID()], name)
+			}
+		}
+	}
+
+	// the designated target throttles the entire response (peers are not limited)
+	bwl, ok := t.allowTransfer(w, r, bck, r.URL.Query())
+	if !ok {
+		return
+	}
+
+	bg := &batchGetter{t: t, bck: bck, ctx: r.Context()}
+	w.Header().Set(cmn.HeaderContentType, cmn.BatchContentType(batchMsg.Format))
+	w.WriteHeader(http.StatusOK)
+	bg.bw = cmn.NewBatchWriter(bwl.writer(w), batchMsg.Format)
+
+	wg := &sync.WaitGroup{}
+	for tid, names := range peers {
+		wg.Add(1)
+		go bg.getFromPeer(smap.GetTarget(tid), names, wg)
+	}
+	bg.getLocal(local)
+	wg.Wait()
+
+	if bg.err == nil {
+		bg.err = bg.bw.Close()
+	}
+	if bg.err != nil {
+		glog.Errorf("%s: batch GET %s (%d objects): %v", t.si, bck, len(names), bg.err)
+	}
+}
+
+func (bg *batchGetter) write(hdr *cmn.BatchEntryHdr, r io.Reader) {
+	bg.mu.Lock()
+	if bg.err == nil {
+		if bg.err = bg.ctx.Err(); bg.err == nil {
+			bg.err = bg.bw.WriteEntry(hdr, r)
+		}
+	}
+	bg.mu.Unlock()
+}
+
+func (bg *batchGetter) writeErr(name string, err error) {
+	bg.write(&cmn.BatchEntryHdr{Name: name, Err: err.Error()}, nil)
+}
+
+func (bg *batchGetter) aborted() (aborted bool) {
+	bg.mu.Lock()
+	aborted = bg.err != nil
+	bg.mu.Unlock()
+	return
+}
+
+//
+// local
+//
+
+func (bg *batchGetter) getLocal(names []string) {
+	if len(names) == 0 {
+		return
+	}
+	var (
+		availablePaths, _ = fs.Get()
+		numWorkers        = cmn.Min(cmn.Max(len(availablePaths), 1), len(names))
+		namesCh           = make(chan string, len(names))
+		wg                = &sync.WaitGroup{}
+	)
+	for _, name := range names {
+		namesCh <- name
+	}
+	close(namesCh)
+	for i := 0; i < numWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			for name := range namesCh {
+				if bg.aborted() {
+					break
+				}
+				bg.getLocalObj(name)
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+}
+
+func (bg *batchGetter) getLocalObj(name string) {
+	lom := cluster.AllocLOM(name)
+	defer cluster.FreeLOM(lom)
+	if err := lom.Init(bg.bck.Bck); err != nil {
+		bg.t.statsT.Add(stats.ErrGetCount, 1)
+		bg.writeErr(name, err)
+		return
+	}
+	lom.Lock(false)
+	err := lom.Load()
+	if err != nil && cmn.IsObjNotExist(err) && lom.Bck().IsRemote() {
+		lom.SetAtimeUnix(time.Now().UnixNano())
+		if _, err = bg.t.GetCold(bg.ctx, lom, cluster.GetCold); err != nil {
+			// (unlocked by GetCold)
+			bg.t.statsT.Add(stats.ErrGetCount, 1)
+			bg.writeErr(name, err)
+			return
+		}
+		bg.t.putMirror(lom)
+	}
+	if err != nil {
+		lom.Unlock(false)
+		bg.t.statsT.Add(stats.ErrGetCount, 1)
+		bg.writeErr(name, err)
+		return
+	}
+	file, err := os.Open(lom.FQN)
+	if err != nil {
+		lom.Unlock(false)
+		bg.t.statsT.Add(stats.ErrGetCount, 1)
+		bg.writeErr(name, err)
+		return
+	}
+	bg.write(&cmn.BatchEntryHdr{Name: name, Size: lom.Size()}, file)
+	cmn.Close(file)
+	lom.Unlock(false)
+	bg.t.statsT.Add(stats.GetCount, 1)
+}
+
+//
+// peers
+//
+
+func (bg *batchGetter) getFromPeer(tsi *cluster.Snode, names []string, wg *sync.WaitGroup) {
+	var (
+		pending = cmn.NewStringSet(names...)
+		err     = bg._getFromPeer(tsi, names, pending)
+	)
+	if err != nil {
+		glog.Errorf("%s: batch GET %s from %s: %v", bg.t.si, bg.bck, tsi, err)
+		err = fmt.Errorf("failed to get from %s: %v", tsi, err)
+	} else if len(pending) > 0 {
+		err = fmt.Errorf("%s: missing in the batch response from %s", cmn.DoesNotExist, tsi)
+	}
+	for name := range pending {
+		bg.writeErr(name, err)
+	}
+	wg.Done()
+}
+
+func (bg *batchGetter) _getFromPeer(tsi *cluster.Snode, names []string, pending cmn.StringSet) error {
+	var (
+		query  = url.Values{}
+		header = make(http.Header)
+		msg    = cmn.ActionMsg{
+			Action: cmn.ActGetBatch,
+			Value:  &cmn.GetBatchMsg{ListMsg: cmn.ListMsg{ObjNames: names}, Format: cmn.BatchFormatLP},
+		}
+	)
+	header.Set(cmn.HeaderCallerID, bg.t.SID())
+	header.Set(cmn.HeaderCallerName, bg.t.Sname())
+	header.Set(cmn.HeaderContentType, cmn.ContentJSON)
+	query.Set(cmn.URLParamBatchPeer, "true")
+	query = cmn.AddBckToQuery(query, bg.bck.Bck)
+	reqArgs := cmn.ReqArgs{
+		Method: http.MethodPost,
+		Base:   tsi.URL(cmn.NetworkIntraData),
+		Header: header,
+		Path:   cmn.URLPathBuckets.Join(bg.bck.Name),
+		Query:  query,
+		Body:   cmn.MustMarshal(msg),
+	}
+	req, err := reqArgs.Req()
+	if err != nil {
+		return err
+	}
+	resp, err := bg.t.client.data.Do(req.WithContext(bg.ctx))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		cmn.DrainReader(resp.Body)
+		resp.Body.Close()
+	}()
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("%s: %s", resp.Status, b)
+	}
+	br := cmn.NewBatchReader(resp.Body, cmn.BatchFormatLP)
+	for !bg.aborted() {
+		hdr, r, err := br.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		pending.Delete(hdr.Name)
+		bg.write(hdr, r)
+	}
+	return nil
+}
@@ -349,6 +349,13 @@ func (t *targetrunner) httpdaeget(w http.ResponseWriter, r *http.Request) {
 		cmn.Assert(ok)
 		aisCloud := t.cloud[cmn.ProviderAIS].(*backend.AISBackendProvider)
 		t.writeJSON(w, r, aisCloud.GetInfo(clusterConf), httpdaeWhat)
+	case cmn.GetWhatWriteBack:
+		bck, err := newBckFromQueryUname(r.URL.Query(), cmn.URLParamBucket)
+		if err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		t.writeJSON(w, r, t.wb.info(bck), httpdaeWhat)
 	default:
 		t.httprunner.httpdaeget(w, r)
 	}
@@ -0,0 +1,46 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+// Bucket lifecycle - see cmn.LifecycleConf
+//
+// Periodically (and independently) each target runs `ActLifecycle` xaction on
+// each bucket that has enabled lifecycle rules. The xaction can also be started
+// on demand (e.g., `ais start xaction lifecycle BUCKET`).
+
+const (
+	lifecycleHkName   = "target.lifecycle"
+	lifecycleInterval = time.Hour
+)
+
+func (t *targetrunner) initLifecycle() {
+	hk.Reg(lifecycleHkName, t.lifecycleHK, lifecycleInterval)
+}
+
+func (t *targetrunner) lifecycleHK() time.Duration {
+	if !t.ClusterStarted() {
+		return lifecycleInterval
+	}
+	t.owner.bmd.get().Range(nil, nil, func(bck *cluster.Bck) bool {
+		if !bck.Props.Lifecycle.Enabled() {
+			return false
+		}
+		if err := xreg.RenewLifecycle(t, cmn.GenUUID(), bck); err != nil {
+			glog.Errorf("%s: failed to start %s on %s: %v", t.si, cmn.ActLifecycle, bck, err)
+		}
+		return false
+	})
+	return lifecycleInterval
+}
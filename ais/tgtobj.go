@@ -12,7 +12,9 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime/multipart"
 	"net/http"
+	"net/textproto"
 	"net/url"
 	"os"
 	"strconv"
@@ -29,6 +31,7 @@ import (
 	"github.com/NVIDIA/aistore/fs"
 	"github.com/NVIDIA/aistore/memsys"
 	"github.com/NVIDIA/aistore/stats"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/xaction/xreg"
 )
@@ -59,6 +62,8 @@ type (
 		recvType cluster.RecvType
 		// if true, poi won't erasure-encode an object when finalizing
 		skipEC bool
+		// HTTP preconditions (If-Match et al.) - compare-and-swap PUT
+		preconds *cmn.Preconds
 	}
 
 	getObjInfo struct {
@@ -76,6 +81,10 @@ type (
 		isGFN bool
 		// true: chunked transfer (en)coding as per https://tools.ietf.org/html/rfc7230#page-36
 		chunked bool
+		// HTTP preconditions (If-None-Match et al.) - conditional GET
+		preconds *cmn.Preconds
+		// true: serving a prior version of the object (see getVersion)
+		prevVersion bool
 	}
 
 	// Contains information packed in append handle.
@@ -117,8 +126,8 @@ func (poi *putObjInfo) putObject() (errCode int, err error) {
 	debug.Assert(cluster.RegularPut <= poi.recvType && poi.recvType <= cluster.Migrated)
 
 	lom := poi.lom
-	// optimize out if the checksums do match
-	if !poi.cksumToUse.IsEmpty() {
+	// optimize out if the checksums do match (and there are no preconditions to evaluate)
+	if !poi.cksumToUse.IsEmpty() && poi.preconds == nil {
 		if lom.Cksum().Equal(poi.cksumToUse) {
 			if glog.FastV(4, glog.SmoduleAIS) {
 				glog.Infof("%s is valid %s: PUT is a no-op", lom, poi.cksumToUse)
@@ -177,12 +186,28 @@ func (poi *putObjInfo) finalize() (errCode int, err error) {
 // poi.workFQN => LOM
 func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 	var (
-		lom = poi.lom
-		bck = lom.Bck()
-		bmd = poi.t.owner.bmd.Get()
+		lom    = poi.lom
+		bck    = lom.Bck()
+		bmd    = poi.t.owner.bmd.Get()
+		locked bool
 	)
+	// compare-and-swap and object lock: evaluate preconditions and retention and
+	// commit under the same (exclusive) lock - for remote buckets, prior to writing remotely
+	if poi.recvType == cluster.RegularPut && (poi.preconds != nil || lom.Bprops().ObjLock.Enabled()) {
+		lom.Lock(true)
+		defer lom.Unlock(true)
+		locked = true
+		if errCode, err = poi.evalCurrent(); err != nil {
+			return
+		}
+	}
+	// write-back: commit locally, upload asynchronously (see tgtwb.go);
+	// an object migrated (rebalanced) prior to being uploaded is re-enqueued by its new target
+	writeBack := bck.IsRemote() &&
+		((poi.recvType == cluster.RegularPut && lom.Bprops().RemoteWrite.IsWriteBack()) ||
+			(poi.recvType == cluster.Migrated && lom.IsWriteBackPending()))
 	// remote versioning
-	if bck.IsRemote() && poi.recvType == cluster.RegularPut {
+	if bck.IsRemote() && poi.recvType == cluster.RegularPut && !writeBack {
 		var version string
 		if bck.IsRemoteAIS() {
 			version, errCode, err = poi.putRemoteAIS()
@@ -204,7 +229,7 @@ func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 	}
 	if poi.recvType == cluster.ColdGet {
 		debug.Assert(!lom.TryLock(true)) // cold GET: caller must take a lock
-	} else {
+	} else if !locked {
 		lom.Lock(true)
 		defer lom.Unlock(true)
 	}
@@ -217,6 +242,27 @@ func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 			}
 		}
 	}
+	if writeBack {
+		// NOTE: enqueue (and persist) prior to committing - a stale queue entry
+		// is harmless while a missing one would leave the object never uploaded
+		if err = poi.t.wb.enqueue(lom); err != nil {
+			errCode = http.StatusInternalServerError
+			return
+		}
+		lom.SetCustomKey(cluster.WriteBackObjMD, strconv.FormatInt(time.Now().UnixNano(), 10))
+	}
+	if poi.recvType == cluster.RegularPut {
+		if objLock := &lom.Bprops().ObjLock; objLock.Enabled() && objLock.Days > 0 {
+			lom.SetRetainUntil(time.Now().Add(objLock.Retention()))
+		}
+	}
+	// last-modified: migrated objects keep theirs, cold GET - the one provided by the backend, if any
+	if poi.recvType == cluster.RegularPut || (poi.recvType == cluster.ColdGet && lom.LastModifiedUnix() == 0) {
+		lom.SetLastModifiedUnix(time.Now().UnixNano())
+	}
+	if bck.IsAIS() && poi.recvType == cluster.RegularPut && lom.VersionConf().Retain > 0 {
+		poi.retainVersion()
+	}
 	if err = cmn.Rename(poi.workFQN, lom.FQN); err != nil {
 		err = fmt.Errorf("PUT %s: failed to rename: %w", lom, err)
 		return
@@ -234,6 +280,78 @@ func (poi *putObjInfo) tryFinalize() (errCode int, err error) {
 	return
 }
 
+// evaluate preconditions and retention (object lock) against the current
+// (committed) version of the object; a remote object that is not present in
+// the cluster is evaluated against its backend metadata (see evalRemote)
+func (poi *putObjInfo) evalCurrent() (errCode int, err error) {
+	cur := cluster.AllocLOM(poi.lom.ObjName)
+	defer cluster.FreeLOM(cur)
+	if err = cur.Init(poi.lom.Bucket()); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	err = cur.Load(false)
+	if err != nil && !cmn.IsObjNotExist(err) {
+		return http.StatusInternalServerError, err
+	}
+	exists := err == nil
+	if exists {
+		if err = cur.CheckRetention(false); err != nil {
+			return http.StatusForbidden, fmt.Errorf("PUT %s: %w", poi.lom, err)
+		}
+	}
+	if poi.preconds == nil {
+		return 0, nil
+	}
+	if !exists && cur.Bck().IsRemote() {
+		return poi.evalRemote()
+	}
+	if status := evalPreconds(poi.preconds, cur, exists, false); status != 0 {
+		return status, fmt.Errorf("PUT %s: %s", poi.lom, http.StatusText(status))
+	}
+	return 0, nil
+}
+
+// NOTE: not atomic - the remote object may change between HEAD and PUT
+func (poi *putObjInfo) evalRemote() (errCode int, err error) {
+	obj := &cmn.PrecondObj{}
+	objMeta, errCode, err := poi.t.Backend(poi.lom.Bck()).HeadObj(poi.ctx, poi.lom)
+	if err != nil {
+		if errCode != http.StatusNotFound {
+			return errCode, fmt.Errorf("PUT %s: %v", poi.lom, err)
+		}
+	} else {
+		obj.Exists = true
+		for _, key := range []string{cmn.HeaderObjVersion, cluster.VersionObjMD, cluster.MD5ObjMD} {
+			obj.ETags = append(obj.ETags, objMeta[key])
+		}
+		obj.Mtime = time.Now() // (not known - assume modified)
+	}
+	if status := poi.preconds.Eval(obj, false); status != 0 {
+		return status, fmt.Errorf("PUT %s: %s", poi.lom, http.StatusText(status))
+	}
+	return 0, nil
+}
+
+// add the current version of the object (if exists) to its history - see cluster.RetainVersion
+func (poi *putObjInfo) retainVersion() {
+	cur := cluster.AllocLOM(poi.lom.ObjName)
+	defer cluster.FreeLOM(cur)
+	if err := cur.Init(poi.lom.Bucket()); err != nil {
+		glog.Errorf("PUT %s: %v", poi.lom, err)
+		return
+	}
+	if err := cur.Load(false); err != nil {
+		if !cmn.IsObjNotExist(err) {
+			glog.Errorf("PUT %s: %v", poi.lom, err)
+		}
+		return
+	}
+	if err := cur.RetainVersion(cur.VersionConf().Retain); err != nil {
+		// not failing the PUT
+		glog.Errorf("PUT %s: failed to retain version %q: %v", poi.lom, cur.Version(), err)
+	}
+}
+
 func (poi *putObjInfo) putCloud() (version string, errCode int, err error) {
 	var (
 		lom = poi.lom
@@ -246,17 +364,24 @@ func (poi *putObjInfo) putCloud() (version string, errCode int, err error) {
 	}
 
 	cloud := poi.t.Backend(bck)
+	version, errCode, err = cloud.PutObj(poi.ctx, file, lom)
+	setCloudMD(lom, cloud.Provider(), version)
+	cmn.Close(file)
+	return
+}
+
+// resets cloud-specific custom metadata while keeping user-defined metadata and tags
+func setCloudMD(lom *cluster.LOM, provider, version string) {
+	userMD, tags := lom.UserMD(), lom.Tags()
 	customMD := cmn.SimpleKVs{
-		cluster.SourceObjMD: cloud.Provider(),
+		cluster.SourceObjMD: provider,
 	}
-
-	version, errCode, err = cloud.PutObj(poi.ctx, file, lom)
 	if version != "" {
 		customMD[cluster.VersionObjMD] = version
 	}
 	lom.SetCustomMD(customMD)
-	cmn.Close(file)
-	return
+	lom.SetUserMD(userMD)
+	lom.SetTags(tags)
 }
 
 func (poi *putObjInfo) putRemoteAIS() (version string, errCode int, err error) {
@@ -466,7 +591,12 @@ do:
 			return sent, http.StatusInsufficientStorage, cs.Err
 		}
 		goi.lom.SetAtimeUnix(goi.started.UnixNano())
-		if errCode, err := goi.t.GetCold(goi.ctx, goi.lom, cluster.GetCold); err != nil {
+		ctx, span := tracing.StartSpan(goi.ctx, "target.get.cold")
+		span.SetAttr("provider", goi.lom.Bck().Provider)
+		errCode, err = goi.t.GetCold(ctx, goi.lom, cluster.GetCold)
+		span.SetErr(err)
+		span.End()
+		if err != nil {
 			return sent, errCode, err
 		}
 		goi.t.putMirror(goi.lom)
@@ -623,7 +753,14 @@ gfn:
 	}
 
 	// restore from existing EC slices if possible
-	if ecErr := ec.ECM.RestoreObject(goi.lom); ecErr == nil {
+	var span *tracing.Span
+	if ecEnabled {
+		_, span = tracing.StartSpan(goi.ctx, "target.get.ec-restore")
+	}
+	ecErr := ec.ECM.RestoreObject(goi.lom, span.TraceParent())
+	span.SetErr(ecErr)
+	span.End()
+	if ecErr == nil {
 		ecErr = goi.lom.Load(true)
 		debug.AssertNoErr(ecErr)
 		if ecErr == nil {
@@ -648,9 +785,13 @@ gfn:
 }
 
 func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) (ok bool) {
+	ctx, span := tracing.StartSpan(goi.ctx, "target.get.gfn")
+	defer span.End()
+	span.SetAttr("target", tsi.ID())
 	header := make(http.Header)
 	header.Add(cmn.HeaderCallerID, goi.t.SID())
 	header.Add(cmn.HeaderCallerName, goi.t.Sname())
+	tracing.Inject(ctx, header)
 	query := url.Values{}
 	query.Set(cmn.URLParamIsGFNRequest, "true")
 	query = cmn.AddBckToQuery(query, lom.Bucket())
@@ -664,6 +805,7 @@ func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) (ok
 	req, _, cancel, err := reqArgs.ReqWithTimeout(cmn.GCO.Get().Timeout.SendFile)
 	if err != nil {
 		glog.Errorf("failed to create request, err: %v", err)
+		span.SetErr(err)
 		return
 	}
 	defer cancel()
@@ -671,6 +813,7 @@ func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) (ok
 	resp, err := goi.t.client.data.Do(req) // nolint:bodyclose // closed by `poi.putObject`
 	if err != nil {
 		glog.Errorf("GFN failure, URL %q, err: %v", reqArgs.URL(), err)
+		span.SetErr(err)
 		return
 	}
 	lom.FromHTTPHdr(resp.Header)
@@ -684,6 +827,7 @@ func (goi *getObjInfo) getFromNeighbor(lom *cluster.LOM, tsi *cluster.Snode) (ok
 	}
 	if _, err := poi.putObject(); err != nil {
 		glog.Error(err)
+		span.SetErr(err)
 		return
 	}
 	ok = true
@@ -730,6 +874,17 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry, sent bool, errCode int, er
 
 	if rw, ok := goi.w.(http.ResponseWriter); ok {
 		hdr = rw.Header()
+		if goi.preconds != nil {
+			switch status := evalPreconds(goi.preconds, goi.lom, true, true); status {
+			case http.StatusNotModified:
+				setETag(hdr, goi.lom)
+				rw.WriteHeader(status)
+				sent = true
+				return
+			case http.StatusPreconditionFailed:
+				return false, false, status, fmt.Errorf("GET %s: %s", goi.lom, http.StatusText(status))
+			}
+		}
 	}
 
 	fqn := goi.lom.FQN
@@ -751,37 +906,38 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry, sent bool, errCode int, er
 	}
 
 	var (
-		r    *cmn.HTTPRange
-		size = goi.lom.Size()
+		r      *cmn.HTTPRange
+		ranges []cmn.HTTPRange
+		size   = goi.lom.Size()
 	)
 	if goi.ranges.Size > 0 {
 		size = goi.ranges.Size
 	}
 
 	if hdr != nil {
-		ranges, err := cmn.ParseMultiRange(goi.ranges.Range, size)
+		ranges, err = cmn.ParseMultiRange(goi.ranges.Range, size)
 		if err != nil {
 			if err == cmn.ErrNoOverlap {
 				hdr.Set(cmn.HeaderContentRange, fmt.Sprintf("%s*/%d", cmn.HeaderContentRangeValPrefix, size))
 			}
 			return false, sent, http.StatusRequestedRangeNotSatisfiable, err
 		}
+		if cmn.RangesSize(ranges) > size {
+			// (same as net/http: the client is likely misbehaving - send the entire object)
+			ranges = nil
+		}
 
 		if len(ranges) > 0 {
-			if len(ranges) > 1 {
-				err = fmt.Errorf("multi-range is not supported")
-				errCode = http.StatusRequestedRangeNotSatisfiable
-				return false, sent, errCode, err
-			}
-			r = &ranges[0]
-
 			hdr.Set(cmn.HeaderAcceptRanges, "bytes")
-			hdr.Set(cmn.HeaderContentRange, r.ContentRange(size))
+			if len(ranges) == 1 {
+				r = &ranges[0]
+				hdr.Set(cmn.HeaderContentRange, r.ContentRange(size))
+			}
 		}
 	}
 
 	cksumConf := goi.lom.CksumConf()
-	cksumRange := cksumConf.Type != cmn.ChecksumNone && r != nil && cksumConf.EnableReadRange
+	cksumRange := cksumConf.Type != cmn.ChecksumNone && len(ranges) > 0 && cksumConf.EnableReadRange
 
 	if hdr != nil {
 		if !goi.lom.Cksum().IsEmpty() && !cksumRange {
@@ -794,15 +950,21 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry, sent bool, errCode int, er
 		}
 		hdr.Set(cmn.HeaderObjSize, strconv.FormatInt(goi.lom.Size(), 10))
 		hdr.Set(cmn.HeaderObjAtime, cmn.UnixNano2S(goi.lom.AtimeUnix()))
-		if r != nil {
+		cmn.KVsToHdr(hdr, cmn.HeaderObjUserMD, goi.lom.UserMD())
+		cmn.KVsToHdr(hdr, cmn.HeaderObjTags, goi.lom.Tags())
+		switch {
+		case r != nil:
 			hdr.Set(cmn.HeaderContentLength, strconv.FormatInt(r.Length, 10))
-		} else {
+		case len(ranges) == 0:
 			hdr.Set(cmn.HeaderContentLength, strconv.FormatInt(size, 10))
 		}
 	}
 
 	w := goi.w
-	if r == nil {
+	switch {
+	case len(ranges) > 1:
+		buf, slab = goi.t.gmm.Alloc()
+	case r == nil:
 		reader = file
 		if goi.chunked {
 			// Explicitly hiding `ReadFrom` implemented for `http.ResponseWriter`
@@ -810,7 +972,7 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry, sent bool, errCode int, er
 			w = cmn.WriterOnly{Writer: goi.w}
 			buf, slab = goi.t.gmm.Alloc(goi.lom.Size())
 		}
-	} else {
+	default:
 		buf, slab = goi.t.gmm.Alloc(r.Length)
 		reader = io.NewSectionReader(file, r.Start, r.Length)
 		if cksumRange {
@@ -826,7 +988,15 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry, sent bool, errCode int, er
 	}
 
 	sent = true // At this point we mark the object as sent, regardless of the outcome.
-	written, err = io.CopyBuffer(w, reader, buf)
+	if len(ranges) > 1 {
+		cksumType := cmn.ChecksumNone
+		if cksumRange {
+			cksumType = cksumConf.Type
+		}
+		written, err = goi.sendRanges(file, ranges, size, cksumType, buf, slab)
+	} else {
+		written, err = io.CopyBuffer(w, reader, buf)
+	}
 	if err != nil {
 		if cmn.IsErrConnectionReset(err) {
 			return
@@ -836,8 +1006,8 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry, sent bool, errCode int, er
 		return retry, sent, http.StatusInternalServerError, fmt.Errorf("failed to GET %s, err: %w", fqn, err)
 	}
 
-	// GFN: atime must be already set
-	if !coldGet && !goi.isGFN {
+	// GFN: atime must be already set; prior versions: not updated
+	if !coldGet && !goi.isGFN && !goi.prevVersion {
 		goi.lom.Load(false)
 		goi.lom.SetAtimeUnix(goi.started.UnixNano())
 		goi.lom.ReCache(true) // GFN and cold GETs already did this
@@ -866,6 +1036,52 @@ func (goi *getObjInfo) finalize(coldGet bool) (retry, sent bool, errCode int, er
 	return
 }
 
+// sendRanges writes multipart/byteranges response (RFC 7233, section 4.1).
+// Unless `cksumType` is none, each part carries its own (range) checksum.
+func (goi *getObjInfo) sendRanges(file *os.File, ranges []cmn.HTTPRange, size int64, cksumType string,
+	buf []byte, slab *memsys.Slab) (written int64, err error) {
+	var (
+		rw  = goi.w.(http.ResponseWriter)
+		mpw = multipart.NewWriter(rw)
+	)
+	rw.Header().Set(cmn.HeaderContentType, cmn.ContentMultiRange+"; boundary="+mpw.Boundary())
+	rw.WriteHeader(http.StatusPartialContent)
+	for _, r := range ranges {
+		var (
+			sgl    *memsys.SGL
+			part   io.Writer
+			n      int64
+			reader io.Reader = io.NewSectionReader(file, r.Start, r.Length)
+			hdr              = make(textproto.MIMEHeader, 4)
+		)
+		hdr.Set(cmn.HeaderContentType, cmn.ContentBinary)
+		hdr.Set(cmn.HeaderContentRange, r.ContentRange(size))
+		if cksumType != cmn.ChecksumNone {
+			var cksum *cmn.CksumHash
+			sgl = slab.MMSA().NewSGL(r.Length, slab.Size())
+			if _, cksum, err = cmn.CopyAndChecksum(sgl, reader, buf, cksumType); err != nil {
+				sgl.Free()
+				return
+			}
+			hdr.Set(cmn.HeaderObjCksumType, cksumType)
+			hdr.Set(cmn.HeaderObjCksumVal, cksum.Value())
+			reader = sgl
+		}
+		if part, err = mpw.CreatePart(hdr); err == nil {
+			n, err = io.CopyBuffer(part, reader, buf)
+			written += n
+		}
+		if sgl != nil {
+			sgl.Free()
+		}
+		if err != nil {
+			return
+		}
+	}
+	err = mpw.Close()
+	return
+}
+
 ///////////////////
 // APPEND OBJECT //
 ///////////////////
@@ -1245,6 +1461,58 @@ func _freeLptr(lomptr unsafe.Pointer, locked bool) {
 	cluster.FreeLOM(lom)
 }
 
+///////////////////
+// preconditions //
+///////////////////
+
+// evalPreconds returns zero if the request is to proceed, otherwise the status
+// to respond with - see cmn.Preconds
+func evalPreconds(preconds *cmn.Preconds, lom *cluster.LOM, exists, read bool) int {
+	obj := &cmn.PrecondObj{Exists: exists}
+	if exists {
+		obj.ETags = lomETags(lom)
+		if preconds.NeedMtime() {
+			if finfo, err := os.Stat(lom.FQN); err == nil {
+				obj.Mtime = finfo.ModTime()
+			} else {
+				glog.Errorf("%s: %v", lom, err)
+				obj.Mtime = time.Now() // (assume modified)
+			}
+		}
+	}
+	return preconds.Eval(obj, read)
+}
+
+// entity tags of the object: version, checksum, and (remote) MD5, if available
+func lomETags(lom *cluster.LOM) []string {
+	tags := make([]string, 0, 3)
+	tags = append(tags, lom.Version())
+	if cksum := lom.Cksum(); cksum != nil {
+		tags = append(tags, cksum.Value())
+	}
+	if md5, ok := lom.GetCustomMD(cluster.MD5ObjMD); ok {
+		tags = append(tags, md5)
+	}
+	return tags
+}
+
+// evaluate preconditions (if any) of GET or HEAD request against the loaded object
+func readPreconds(r *http.Request, lom *cluster.LOM) int {
+	if preconds := cmn.ParsePreconds(r.Header); preconds != nil {
+		return evalPreconds(preconds, lom, true /*exists*/, true /*read*/)
+	}
+	return 0
+}
+
+func setETag(hdr http.Header, lom *cluster.LOM) {
+	if hdr.Get(cmn.HeaderETag) != "" {
+		return // (S3)
+	}
+	if cksum := lom.Cksum(); cksum != nil && cksum.Value() != "" {
+		hdr.Set(cmn.HeaderETag, "\""+cksum.Value()+"\"")
+	}
+}
+
 ///////////////
 // mem pools //
 ///////////////
@@ -0,0 +1,77 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Object lock (WORM) - see cmn.ObjLockConf. The retention of an object is
+// checked under the object's write lock by all the operations that remove or
+// replace it: DELETE, evict, overwriting PUT (including APPEND and promote),
+// and rename. LRU and bucket lifecycle skip the objects that are still retained.
+
+func bypassGovernance(hdr http.Header) bool {
+	return cmn.IsParseBool(hdr.Get(cmn.HeaderBypassGovernance))
+}
+
+func ctxBypassGovernance(ctx context.Context) bool {
+	bypass, _ := ctx.Value(cmn.CtxBypassGovernance).(bool)
+	return bypass
+}
+
+// POST { action: ActSetRetention } /v1/objects/bucket-name/object-name
+//
+// Extends the object's retention period; shortening it is only permitted in
+// governance mode with HeaderBypassGovernance.
+func (t *targetrunner) setObjRetention(w http.ResponseWriter, r *http.Request, msg *cmn.ActionMsg) {
+	request := &apiRequest{after: 2, prefix: cmn.URLPathObjects.L}
+	if err := t.parseAPIRequest(w, r, request); err != nil {
+		return
+	}
+	var val cmn.ActValRetention
+	if err := cmn.MorphMarshal(msg.Value, &val); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom := cluster.AllocLOM(request.items[1])
+	defer cluster.FreeLOM(lom)
+	if err := lom.Init(request.bck.Bck); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if !lom.Bprops().ObjLock.Enabled() {
+		t.invalmsghdlrf(w, r, "%s: object lock is not enabled for bucket %s", t.si, lom.Bck())
+		return
+	}
+	until := time.Unix(0, val.RetainUntil)
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false); err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		} else {
+			t.invalmsghdlr(w, r, err.Error())
+		}
+		return
+	}
+	if until.Before(lom.RetainUntil()) {
+		if err := lom.CheckRetention(bypassGovernance(r.Header)); err != nil {
+			t.invalmsghdlr(w, r, fmt.Sprintf("cannot shorten retention: %v", err), http.StatusForbidden)
+			return
+		}
+	}
+	lom.SetRetainUntil(until)
+	if err := lom.PersistWithCopies(); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+	}
+}
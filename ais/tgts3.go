@@ -6,6 +6,7 @@ package ais
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"path"
@@ -16,7 +17,6 @@ import (
 	"github.com/NVIDIA/aistore/ais/s3compat"
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
-	"github.com/NVIDIA/aistore/ec"
 	"github.com/NVIDIA/aistore/fs"
 )
 
@@ -27,20 +27,88 @@ func (t *targetrunner) s3Handler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	q := r.URL.Query()
+	if cmn.GCO.Get().Auth.Enabled && cmn.IsPresigned(q) {
+		if err := t.validatePresignedS3(r); err != nil {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+	_, mpt := q[s3compat.URLParamMptUploadID]
+	_, tagging := q[s3compat.URLParamTagging]
 	switch r.Method {
 	case http.MethodHead:
 		t.headObjS3(w, r, apiItems)
 	case http.MethodGet:
+		if mpt {
+			t.listPartsS3(w, r, apiItems)
+			return
+		}
+		if tagging {
+			t.getObjTaggingS3(w, r, apiItems)
+			return
+		}
 		t.getObjS3(w, r, apiItems)
 	case http.MethodPut:
+		if cmn.GCO.Get().Auth.Enabled {
+			// the signature (verified by proxy) covers the payload's SHA256 - verify the payload
+			r.Body = cmn.NewSigV4PayloadReader(r.Body, r.Header)
+		}
+		if mpt {
+			t.putObjPartS3(w, r, apiItems)
+			return
+		}
+		if tagging {
+			t.putObjTaggingS3(w, r, apiItems)
+			return
+		}
 		t.putObjS3(w, r, apiItems)
+	case http.MethodPost:
+		if _, start := q[s3compat.URLParamMptUploads]; start {
+			t.startMptS3(w, r, apiItems)
+			return
+		}
+		if mpt {
+			t.completeMptS3(w, r, apiItems)
+			return
+		}
+		t.invalmsghdlr(w, r, "invalid request")
 	case http.MethodDelete:
+		if mpt {
+			t.abortMptS3(w, r, apiItems)
+			return
+		}
+		if tagging {
+			t.putObjTaggingS3(w, r, apiItems)
+			return
+		}
 		t.delObjS3(w, r, apiItems)
 	default:
 		t.invalmsghdlrf(w, r, "Invalid HTTP Method: %v %s", r.Method, r.URL.Path)
 	}
 }
 
+// Validates presigned URL redirected by a proxy (see s3Redirect): the signature
+// covers the original host and query - without parameters added by the proxy.
+func (t *targetrunner) validatePresignedS3(r *http.Request) error {
+	sig, err := cmn.ParseSigV4(r)
+	if err != nil {
+		return err
+	}
+	if _, err := t.authn.validateS3Key(sig.AccessKey); err != nil {
+		glog.Errorf("invalid S3 access key: %v", err)
+		return errInvalidToken
+	}
+	query := r.URL.Query()
+	host := query.Get(cmn.URLParamSigHost)
+	if host == "" {
+		host = r.Host
+	}
+	cmn.DelRedirectParams(query)
+	secretKey := cmn.S3SecretKey(sig.AccessKey, cmn.GCO.Get().Auth.Secret)
+	return sig.VerifyAs(r, host, query, secretKey)
+}
+
 func (t *targetrunner) copyObjS3(w http.ResponseWriter, r *http.Request, items []string) {
 	if len(items) < 2 {
 		t.invalmsghdlr(w, r, "object name is undefined")
@@ -133,10 +201,25 @@ func (t *targetrunner) directPutObjS3(w http.ResponseWriter, r *http.Request, it
 		lom.Load() // need to know the current version if versioning enabled
 	}
 	lom.SetAtimeUnix(started.UnixNano())
+	userMD, tags, err := s3compat.UserMDFromHeader(r.Header)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom.SetUserMD(userMD)
+	lom.SetTags(tags)
+	bwl, ok := t.allowTransfer(w, r, lom.Bck(), r.URL.Query())
+	if !ok {
+		return
+	}
+	r.Body = bwl.reader(r.Body)
 
 	// TODO: lom.SetCustomMD(cluster.AmazonMD5ObjMD, checksum)
 
 	if errCode, err := t.doPut(r, lom, started); err != nil {
+		if errors.Is(err, cmn.ErrSigV4PayloadMismatch) {
+			errCode = http.StatusBadRequest
+		}
 		t.fsErr(err, lom.FQN)
 		t.invalmsghdlr(w, r, err.Error(), errCode)
 		return
@@ -189,21 +272,41 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 		}
 		return
 	}
-	if err = lom.Load(true); err != nil {
-		t.invalmsghdlr(w, r, err.Error())
+	bwl, ok := t.allowTransfer(w, r, lom.Bck(), r.URL.Query())
+	if !ok {
 		return
 	}
-
-	objSize = lom.Size()
 	goi := allocGetObjInfo()
+	defer freeGetObjInfo(goi)
 	{
 		goi.started = started
 		goi.t = t
 		goi.lom = lom
-		goi.w = w
+		goi.w = bwl.writer(w)
 		goi.ctx = context.Background()
-		goi.ranges = cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange), Size: objSize}
+		goi.ranges = cmn.RangesQuery{Range: r.Header.Get(cmn.HeaderRange)}
+		goi.preconds = cmn.ParsePreconds(r.Header)
+	}
+	if version := s3compat.VersionIDFromQuery(r.URL.Query()); version != "" {
+		setHdr := func(hdr http.Header, vlom *cluster.LOM) { s3compat.SetHeaderFromLOM(hdr, vlom, vlom.Size()) }
+		if handled, sent, errCode, err := goi.getVersion(version, setHdr); handled {
+			if err != nil {
+				if sent {
+					glog.Errorf("GET %s version %q: %v", lom, version, err)
+				} else {
+					t.invalmsghdlr(w, r, err.Error(), errCode)
+				}
+			}
+			return
+		}
 	}
+	if err = lom.Load(true); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+
+	objSize = lom.Size()
+	goi.ranges.Size = objSize
 	s3compat.SetHeaderFromLOM(w.Header(), lom, objSize)
 	if sent, errCode, err := goi.getObject(); err != nil {
 		if sent {
@@ -213,7 +316,6 @@ func (t *targetrunner) getObjS3(w http.ResponseWriter, r *http.Request, items []
 			t.invalmsghdlr(w, r, err.Error(), errCode)
 		}
 	}
-	freeGetObjInfo(goi)
 }
 
 // HEAD s3/bckName/objName
@@ -248,6 +350,18 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if version := s3compat.VersionIDFromQuery(r.URL.Query()); version != "" {
+		vlom, errCode, errV := loadPrevVersion(lom, version)
+		if errV != nil {
+			lom.Unlock(false)
+			t.invalmsghdlr(w, r, errV.Error(), errCode)
+			return
+		}
+		if vlom != nil {
+			defer cluster.FreeLOM(vlom)
+			lom, err = vlom, nil
+		}
+	}
 	lom.Unlock(false)
 
 	exists := err == nil
@@ -260,6 +374,15 @@ func (t *targetrunner) headObjS3(w http.ResponseWriter, r *http.Request, items [
 		s3compat.SetETLHeader(w.Header(), lom)
 		return
 	}
+	switch status := readPreconds(r, lom); status {
+	case http.StatusNotModified:
+		s3compat.SetHeaderFromLOM(w.Header(), lom, lom.Size())
+		w.WriteHeader(status)
+		return
+	case http.StatusPreconditionFailed:
+		t.invalmsghdlrstatusf(w, r, status, "%s: %s", lom, http.StatusText(status))
+		return
+	}
 	s3compat.SetHeaderFromLOM(w.Header(), lom, lom.Size())
 }
 
@@ -281,6 +404,12 @@ func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []
 		t.invalmsghdlr(w, r, err.Error())
 		return
 	}
+	if version := s3compat.VersionIDFromQuery(r.URL.Query()); version != "" {
+		if errCode, err := t.delObjVersion(lom, version, false /*bypass*/); err != nil {
+			t.invalmsghdlrstatusf(w, r, errCode, "error deleting %s version %s: %v", lom, version, err)
+		}
+		return
+	}
 	errCode, err := t.DeleteObject(context.Background(), lom, false)
 	if err != nil {
 		if errCode == http.StatusNotFound {
@@ -293,6 +422,4 @@ func (t *targetrunner) delObjS3(w http.ResponseWriter, r *http.Request, items []
 		}
 		return
 	}
-	// EC cleanup if EC is enabled
-	ec.ECM.CleanupObject(lom)
 }
@@ -0,0 +1,364 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+//
+// S3 multipart upload: the parts are stored, along with the state of the upload,
+// in the upload's directory (see fs.MptUploadType) by the target that owns the
+// object and get assembled into the object on CompleteMultipartUpload.
+// Uploads that are neither completed nor aborted within `mptMaxAge` are removed
+// by the housekeeper.
+//
+
+const (
+	mptHkName     = "target.s3-mpt"
+	mptHkInterval = time.Hour
+	mptMaxAge     = 7 * 24 * time.Hour
+)
+
+func (t *targetrunner) initMpt() {
+	hk.Reg(mptHkName, t.mptHK, mptHkInterval)
+}
+
+// Removes stale multipart uploads
+func (t *targetrunner) mptHK() time.Duration {
+	var (
+		provider  = cmn.ProviderAIS
+		before    = time.Now().Add(-mptMaxAge)
+		mpaths, _ = fs.Get()
+	)
+	t.owner.bmd.get().Range(&provider, nil, func(bck *cluster.Bck) bool {
+		for _, mi := range mpaths {
+			ctDir := mi.MakePathCT(bck.Bck, fs.MptUploadType)
+			finfos, err := ioutil.ReadDir(ctDir)
+			if err != nil {
+				if !os.IsNotExist(err) {
+					glog.Error(err)
+				}
+				continue
+			}
+			for _, finfo := range finfos {
+				dir := filepath.Join(ctDir, finfo.Name())
+				removed, err := s3compat.RemoveStaleUpload(dir, before)
+				if err != nil {
+					glog.Errorf("%s: failed to remove stale multipart upload %q: %v", bck, dir, err)
+				} else if removed {
+					glog.Infof("%s: removed stale multipart upload %q", bck, finfo.Name())
+				}
+			}
+		}
+		return false
+	})
+	return mptHkInterval
+}
+
+// Returns the directory of the upload: new uploads are started on the object's
+// mountpath (which may later change, e.g., when mountpaths get added).
+func mptUploadDir(lom *cluster.LOM, uploadID string) (string, error) {
+	if !s3compat.ValidUploadID(uploadID) {
+		return "", fmt.Errorf("invalid upload ID %q", uploadID)
+	}
+	mpaths, _ := fs.Get()
+	for _, mi := range mpaths {
+		dir := mi.MakePathFQN(lom.Bucket(), fs.MptUploadType, uploadID)
+		if _, err := os.Stat(dir); err == nil {
+			return dir, nil
+		}
+	}
+	return lom.MpathInfo().MakePathFQN(lom.Bucket(), fs.MptUploadType, uploadID), nil
+}
+
+// POST s3/bckName/objName?uploads
+// Start multipart upload
+func (t *targetrunner) startMptS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	uploadID := cmn.GenUUID()
+	dir := lom.MpathInfo().MakePathFQN(lom.Bucket(), fs.MptUploadType, uploadID)
+	if err := s3compat.InitUpload(dir, lom.BckName(), lom.ObjName); err != nil {
+		t.fsErr(err, dir)
+		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: started multipart upload %q", lom, uploadID)
+	}
+	result := s3compat.NewInitiateMptUploadResult(lom.BckName(), lom.ObjName, uploadID)
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(result.MustMarshal())
+}
+
+// PUT s3/bckName/objName?partNumber=N&uploadId=ID
+// Upload a part of a multipart upload
+func (t *targetrunner) putObjPartS3(w http.ResponseWriter, r *http.Request, items []string) {
+	if cs := fs.GetCapStatus(); cs.OOS {
+		t.invalmsghdlr(w, r, cs.Err.Error(), http.StatusInsufficientStorage)
+		return
+	}
+	if r.Header.Get(s3compat.HeaderObjSrc) != "" {
+		t.invalmsghdlr(w, r, "multipart upload: copying a part from an existing object is not supported")
+		return
+	}
+	var (
+		q        = r.URL.Query()
+		uploadID = q.Get(s3compat.URLParamMptUploadID)
+	)
+	partNum, err := s3compat.ParsePartNum(q.Get(s3compat.URLParamMptPartNumber))
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	bwl, ok := t.allowTransfer(w, r, lom.Bck(), q)
+	if !ok {
+		return
+	}
+	dir, err := mptUploadDir(lom, uploadID)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+
+	partFQN := s3compat.PartFQN(dir, partNum)
+	file, err := os.OpenFile(partFQN, os.O_CREATE|os.O_EXCL|os.O_WRONLY, cmn.PermRWR)
+	if err != nil {
+		if os.IsNotExist(err) {
+			t.invalmsghdlrstatusf(w, r, http.StatusNotFound, "upload %q %s", uploadID, cmn.DoesNotExist)
+			return
+		}
+		t.fsErr(err, partFQN)
+		t.invalmsghdlr(w, r, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	var (
+		buf  []byte
+		slab *memsys.Slab
+	)
+	if r.ContentLength <= 0 {
+		buf, slab = t.gmm.Alloc()
+	} else {
+		buf, slab = t.gmm.Alloc(r.ContentLength)
+	}
+	size, cksum, err := cmn.CopyAndChecksum(file, bwl.reader(r.Body), buf, cmn.ChecksumMD5)
+	slab.Free(buf)
+	cmn.Close(r.Body)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		t.fsErr(err, partFQN)
+		if errRm := cmn.RemoveFile(partFQN); errRm != nil {
+			glog.Errorf("Nested (%v): failed to remove %s, err: %v", err, partFQN, errRm)
+		}
+		errCode := http.StatusInternalServerError
+		if errors.Is(err, cmn.ErrSigV4PayloadMismatch) {
+			errCode = http.StatusBadRequest
+		}
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	cksum.Finalize()
+	part := &s3compat.MptPart{MD5: cksum.Value(), FQN: partFQN, Size: size, Num: partNum}
+	prev, err := s3compat.AddPart(dir, lom.BckName(), lom.ObjName, part)
+	if err != nil {
+		if errRm := cmn.RemoveFile(partFQN); errRm != nil {
+			glog.Errorf("Nested (%v): failed to remove %s, err: %v", err, partFQN, errRm)
+		}
+		t.invalmsghdlr(w, r, err.Error(), mptErrCode(err))
+		return
+	}
+	if prev != nil {
+		// the part was re-uploaded
+		if err := cmn.RemoveFile(prev.FQN); err != nil {
+			glog.Errorf("%s: failed to remove part %d (%s), err: %v", lom, prev.Num, prev.FQN, err)
+		}
+	}
+	w.Header().Set(s3compat.HeaderETag, part.MD5)
+}
+
+// POST s3/bckName/objName?uploadId=ID
+// Complete multipart upload: assemble the object from its parts
+func (t *targetrunner) completeMptS3(w http.ResponseWriter, r *http.Request, items []string) {
+	started := time.Now()
+	if cs := fs.GetCapStatus(); cs.OOS {
+		t.invalmsghdlr(w, r, cs.Err.Error(), http.StatusInsufficientStorage)
+		return
+	}
+	uploadID := r.URL.Query().Get(s3compat.URLParamMptUploadID)
+	decoder := xml.NewDecoder(r.Body)
+	partList := &s3compat.CompleteMptUpload{}
+	err := decoder.Decode(partList)
+	cmn.Close(r.Body)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	dir, err := mptUploadDir(lom, uploadID)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	// hold the upload (and its parts) until the object is assembled
+	parts, err := s3compat.StartCompletion(dir, lom.BckName(), lom.ObjName, partList.Parts)
+	if err != nil {
+		errCode := http.StatusBadRequest
+		if errors.Is(err, s3compat.ErrMptCompleting) {
+			errCode = http.StatusConflict
+		}
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		if err := s3compat.CancelCompletion(dir, lom.BckName(), lom.ObjName); err != nil {
+			glog.Errorf("%s: %v", lom, err)
+		}
+	}()
+
+	var (
+		size    int64
+		files   = make([]*os.File, 0, len(parts))
+		readers = make([]io.Reader, 0, len(parts))
+	)
+	defer func() {
+		for _, file := range files {
+			cmn.Close(file)
+		}
+	}()
+	for _, part := range parts {
+		file, err := os.Open(part.FQN)
+		if err != nil {
+			t.fsErr(err, part.FQN)
+			t.invalmsghdlrf(w, r, "%s: failed to open part %d, err: %v", lom, part.Num, err)
+			return
+		}
+		files = append(files, file)
+		readers = append(readers, file)
+		size += part.Size
+	}
+
+	if lom.Bck().IsAIS() && lom.VersionConf().Enabled {
+		lom.Load() // need to know the current version if versioning enabled
+	}
+	lom.SetAtimeUnix(started.UnixNano())
+	poi := allocPutObjInfo()
+	{
+		poi.started = started
+		poi.t = t
+		poi.lom = lom
+		poi.r = ioutil.NopCloser(io.MultiReader(readers...))
+		poi.size = size
+		poi.ctx = context.Background()
+		poi.workFQN = fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfilePut)
+	}
+	errCode, err := poi.putObject()
+	freePutObjInfo(poi)
+	if err != nil {
+		t.fsErr(err, lom.FQN)
+		t.invalmsghdlr(w, r, err.Error(), errCode)
+		return
+	}
+	completed = true
+
+	// cleanup: all uploaded parts including those that were not listed by the client
+	if err := s3compat.CompleteUpload(dir); err != nil {
+		glog.Errorf("%s: failed to remove multipart upload %q: %v", lom, uploadID, err)
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: completed multipart upload %q (%d parts, %s)", lom, uploadID, len(parts),
+			time.Since(started))
+	}
+	result := s3compat.NewCompleteMptUploadResult(lom.BckName(), lom.ObjName, s3compat.MptETag(parts))
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(result.MustMarshal())
+}
+
+// DELETE s3/bckName/objName?uploadId=ID
+// Abort multipart upload and remove all uploaded parts
+func (t *targetrunner) abortMptS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	uploadID := r.URL.Query().Get(s3compat.URLParamMptUploadID)
+	dir, err := mptUploadDir(lom, uploadID)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if err := s3compat.AbortUpload(dir, lom.BckName(), lom.ObjName); err != nil {
+		t.invalmsghdlr(w, r, err.Error(), mptErrCode(err))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// GET s3/bckName/objName?uploadId=ID
+// List uploaded parts
+func (t *targetrunner) listPartsS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	uploadID := r.URL.Query().Get(s3compat.URLParamMptUploadID)
+	dir, err := mptUploadDir(lom, uploadID)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	parts, err := s3compat.ListParts(dir, lom.BckName(), lom.ObjName)
+	if err != nil {
+		t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		return
+	}
+	result := s3compat.NewListPartsResult(lom.BckName(), lom.ObjName, uploadID, parts)
+	w.Header().Set(cmn.HeaderContentType, cmn.ContentXML)
+	w.Write(result.MustMarshal())
+}
+
+func mptErrCode(err error) int {
+	if errors.Is(err, s3compat.ErrMptCompleting) {
+		return http.StatusConflict
+	}
+	return http.StatusNotFound
+}
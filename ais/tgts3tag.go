@@ -0,0 +1,87 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"encoding/xml"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/ais/s3compat"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+//
+// S3 object tagging: tags are a part of the object's metadata (see cluster.LOM.Tags)
+// and can be updated in place without rewriting the object.
+//
+
+// GET s3/bckName/objName?tagging
+func (t *targetrunner) getObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	lom.Lock(false)
+	err := lom.Load(true)
+	lom.Unlock(false)
+	if err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		} else {
+			t.invalmsghdlr(w, r, err.Error())
+		}
+		return
+	}
+	tagging := s3compat.NewTagging(lom.Tags())
+	w.Write(tagging.MustMarshal())
+}
+
+// PUT s3/bckName/objName?tagging - replaces all tags of the object
+// DELETE s3/bckName/objName?tagging - removes all tags of the object
+func (t *targetrunner) putObjTaggingS3(w http.ResponseWriter, r *http.Request, items []string) {
+	var tags cmn.SimpleKVs
+	if r.Method == http.MethodPut {
+		tagging := &s3compat.Tagging{}
+		err := xml.NewDecoder(r.Body).Decode(tagging)
+		cmn.Close(r.Body)
+		if err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+		if tags, err = tagging.Tags(); err != nil {
+			t.invalmsghdlr(w, r, err.Error())
+			return
+		}
+	}
+	lom := t.initAISLOM(w, r, items)
+	if lom == nil {
+		return
+	}
+	defer cluster.FreeLOM(lom)
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	if err := lom.Load(false); err != nil {
+		if cmn.IsObjNotExist(err) {
+			t.invalmsghdlr(w, r, err.Error(), http.StatusNotFound)
+		} else {
+			t.invalmsghdlr(w, r, err.Error())
+		}
+		return
+	}
+	if err := cmn.ValidateObjUserMD(lom.UserMD(), tags); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	lom.SetTags(tags)
+	if err := lom.PersistWithCopies(); err != nil {
+		t.invalmsghdlr(w, r, err.Error())
+		return
+	}
+	if r.Method == http.MethodDelete {
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
@@ -0,0 +1,111 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/stats"
+)
+
+// Prior (retained) versions of objects in ais buckets - see cmn.VersionConf.Retain
+// and cluster.RetainVersion. A given version is requested via `URLParamVersion`
+// (S3: `versionId`); the current version is always served as usual.
+
+// returns nil LOM (and no error) if the requested version is the current one;
+// the caller must hold the object's lock
+func loadPrevVersion(lom *cluster.LOM, version string) (vlom *cluster.LOM, errCode int, err error) {
+	if !lom.Bck().IsAIS() {
+		return nil, http.StatusBadRequest, fmt.Errorf("%s: prior versions are supported only for ais buckets", lom)
+	}
+	if err = lom.Load(); err == nil && lom.Version() == version {
+		return nil, 0, nil
+	}
+	if vlom, err = lom.LoadVersion(version); err != nil {
+		errCode = http.StatusInternalServerError
+		if cmn.IsObjNotExist(err) {
+			errCode = http.StatusNotFound
+		}
+	}
+	return
+}
+
+// GET a prior version of the object - same as the current one (preconditions,
+// ranges, bandwidth limits) except for cold GET and recovery; returns false
+// if the requested version is the current one (and is to be served as usual)
+func (goi *getObjInfo) getVersion(version string, setHdr func(hdr http.Header, vlom *cluster.LOM)) (handled,
+	sent bool, errCode int, err error) {
+	lom := goi.lom
+	lom.Lock(false)
+	defer lom.Unlock(false)
+	vlom, errCode, err := loadPrevVersion(lom, version)
+	if vlom == nil && err == nil {
+		return
+	}
+	if err != nil {
+		goi.t.statsT.Add(stats.ErrGetCount, 1)
+		return true, false, errCode, err
+	}
+	defer cluster.FreeLOM(vlom)
+	if rw, ok := goi.w.(http.ResponseWriter); ok && setHdr != nil {
+		setHdr(rw.Header(), vlom)
+	}
+	goi.lom, goi.prevVersion = vlom, true
+	goi.ranges.Size = 0 // (the size of the version)
+	_, sent, errCode, err = goi.finalize(false /*coldGet*/)
+	goi.lom = lom
+	return true, sent, errCode, err
+}
+
+// DELETE a given version of the object; deleting the current version makes
+// the newest prior version (if any) current
+func (t *targetrunner) delObjVersion(lom *cluster.LOM, version string, bypass bool) (int, error) {
+	if !lom.Bck().IsAIS() {
+		return http.StatusBadRequest, fmt.Errorf("%s: prior versions are supported only for ais buckets", lom)
+	}
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	err := lom.Load(false)
+	if err != nil && !cmn.IsObjNotExist(err) {
+		return http.StatusInternalServerError, err
+	}
+	if err != nil || lom.Version() != version {
+		vlom, errCode, err := loadPrevVersion(lom, version)
+		if err != nil {
+			return errCode, err
+		}
+		err = vlom.CheckRetention(bypass)
+		cluster.FreeLOM(vlom)
+		if err != nil {
+			return http.StatusForbidden, err
+		}
+		if err := lom.DelVersion(version); err != nil {
+			if cmn.IsObjNotExist(err) {
+				return http.StatusNotFound, err
+			}
+			return http.StatusInternalServerError, err
+		}
+		t.statsT.Add(stats.DeleteCount, 1)
+		return 0, nil
+	}
+	if err := lom.CheckRetention(bypass); err != nil {
+		return http.StatusForbidden, err
+	}
+	if err := lom.Remove(); err != nil {
+		return http.StatusInternalServerError, err
+	}
+	t.statsT.Add(stats.DeleteCount, 1)
+	versions, err := lom.PrevVersions()
+	if err != nil || len(versions) == 0 {
+		return 0, err
+	}
+	if err := lom.RestoreVersion(versions[0]); err != nil {
+		return http.StatusInternalServerError, fmt.Errorf("%s: failed to restore version %q: %v", lom, versions[0], err)
+	}
+	return 0, nil
+}
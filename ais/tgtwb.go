@@ -0,0 +1,317 @@
+// Package ais provides core functionality for the AIStore object storage.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package ais
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/dbdriver"
+	"github.com/NVIDIA/aistore/hk"
+	"github.com/NVIDIA/aistore/stats"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Write-back (asynchronous) PUT to remote buckets - see cmn.WriteBack
+//
+// PUT of an object into a remote bucket configured with `remote_write=write_back`
+// completes once the object is committed locally. The object is marked with
+// cluster.WriteBackObjMD and queued for upload. The queue is persisted in the
+// target's local DB (one record per object, keyed by uname) and reloaded upon
+// restart; uploads are retried with exponential backoff until they succeed or
+// the object gets deleted. Objects that are pending write-back are not evicted.
+// When rebalance migrates an object that is pending write-back, the receiving
+// target re-enqueues it, while the sender drops its (stale) entry once the
+// object is gone.
+
+const (
+	wbCollection = "writeback"
+	wbHkName     = "target.writeback"
+
+	wbInterval   = time.Second      // dispatch interval
+	wbRetryMin   = 5 * time.Second  // backoff upon upload failure: initial
+	wbRetryMax   = 10 * time.Minute // ditto: max
+	wbMaxWorkers = 16               // max concurrent uploads
+	wbMaxEntries = 1000             // max entries returned by GetWhatWriteBack query
+)
+
+type (
+	wbEntry struct {
+		cmn.WriteBackEntry
+		gen      int64 // incremented upon each (re)enqueue
+		next     int64 // not before (unix nano)
+		inflight bool
+	}
+	writeBack struct {
+		mtx     sync.Mutex
+		t       *targetrunner
+		pending map[string]*wbEntry // by uname
+		gen     int64
+		workers int
+	}
+)
+
+func (wb *writeBack) init(t *targetrunner) {
+	wb.t = t
+	wb.pending = make(map[string]*wbEntry, 64)
+	values, err := t.dbDriver.GetAll(wbCollection, "")
+	if err != nil && !dbdriver.IsErrNotFound(err) {
+		glog.Errorf("%s: failed to load write-back queue: %v", t.si, err)
+	}
+	for uname, value := range values {
+		e := &wbEntry{}
+		if err := jsoniter.UnmarshalFromString(value, &e.WriteBackEntry); err != nil {
+			glog.Errorf("%s: invalid write-back record %q: %v", t.si, uname, err)
+			continue
+		}
+		wb.gen++
+		e.gen = wb.gen
+		wb.pending[uname] = e
+	}
+	if len(wb.pending) > 0 {
+		glog.Infof("%s: %d object(s) pending write-back", t.si, len(wb.pending))
+	}
+	hk.Reg(wbHkName, wb.housekeep, wbInterval)
+}
+
+// NOTE: the caller must write-lock the object
+func (wb *writeBack) enqueue(lom *cluster.LOM) error {
+	uname := lom.Uname()
+	wb.mtx.Lock()
+	defer wb.mtx.Unlock()
+	e, exists := wb.pending[uname]
+	if !exists {
+		e = &wbEntry{}
+		e.Bck, e.ObjName = lom.Bucket(), lom.ObjName
+	}
+	e.Size, e.Queued = lom.Size(), time.Now().UnixNano()
+	e.Retries, e.Err, e.next = 0, "", 0
+	if err := wb.t.dbDriver.Set(wbCollection, uname, &e.WriteBackEntry); err != nil {
+		return fmt.Errorf("PUT %s: failed to queue for write-back: %v", lom, err)
+	}
+	wb.gen++
+	e.gen = wb.gen
+	wb.pending[uname] = e
+	return nil
+}
+
+// cancel removes the object from the queue (the object is being deleted);
+// NOTE: the caller must write-lock the object
+func (wb *writeBack) cancel(lom *cluster.LOM) {
+	uname := lom.Uname()
+	wb.mtx.Lock()
+	if _, exists := wb.pending[uname]; exists {
+		delete(wb.pending, uname)
+		wb.delRecord(uname)
+	}
+	wb.mtx.Unlock()
+}
+
+func (wb *writeBack) info(bck *cluster.Bck) *cmn.WriteBackInfo {
+	out := &cmn.WriteBackInfo{}
+	wb.mtx.Lock()
+	for _, e := range wb.pending {
+		if bck != nil && !bck.Bck.Equal(e.Bck) {
+			continue
+		}
+		entry := e.WriteBackEntry
+		out.Entries = append(out.Entries, &entry)
+	}
+	wb.mtx.Unlock()
+	out.Total = len(out.Entries)
+	sort.Slice(out.Entries, func(i, j int) bool { return out.Entries[i].Queued < out.Entries[j].Queued })
+	if len(out.Entries) > wbMaxEntries {
+		out.Entries = out.Entries[:wbMaxEntries]
+	}
+	return out
+}
+
+func (wb *writeBack) housekeep() time.Duration {
+	now := time.Now().UnixNano()
+	wb.mtx.Lock()
+	for _, e := range wb.pending {
+		if wb.workers >= wbMaxWorkers {
+			break
+		}
+		if !e.inflight && e.next <= now {
+			wb.workers++
+			go wb.work()
+		}
+	}
+	wb.mtx.Unlock()
+	return wbInterval
+}
+
+func (wb *writeBack) work() {
+	for {
+		uname, gen, ok := wb.next()
+		if !ok {
+			return
+		}
+		wb.upload(uname, gen)
+	}
+}
+
+// next returns the next object to upload; the worker exits when there's none
+func (wb *writeBack) next() (uname string, gen int64, ok bool) {
+	now := time.Now().UnixNano()
+	wb.mtx.Lock()
+	defer wb.mtx.Unlock()
+	for uname, e := range wb.pending {
+		if !e.inflight && e.next <= now {
+			e.inflight = true
+			return uname, e.gen, true
+		}
+	}
+	wb.workers--
+	return
+}
+
+func (wb *writeBack) upload(uname string, gen int64) {
+	var (
+		t            = wb.t
+		started      = time.Now()
+		bck, objName = cmn.ParseUname(uname)
+		lom          = cluster.AllocLOM(objName)
+	)
+	defer cluster.FreeLOM(lom)
+	if err := lom.Init(bck); err != nil {
+		// the bucket is gone (destroyed or evicted)
+		glog.Warningf("%s: dropping write-back of %s: %v", t.si, uname, err)
+		wb.done(uname, gen)
+		return
+	}
+	lom.Lock(false)
+	if err := lom.Load(false); err != nil || !lom.IsWriteBackPending() {
+		// deleted or overwritten in the meantime - nothing to do
+		lom.Unlock(false)
+		wb.done(uname, gen)
+		return
+	}
+	fh, err := cmn.NewFileHandle(lom.FQN)
+	lom.Unlock(false)
+	if err != nil {
+		wb.retry(lom, gen, err)
+		return
+	}
+
+	backend := t.Backend(lom.Bck())
+	version, _, err := backend.PutObj(context.Background(), fh, lom)
+	if !lom.Bck().IsRemoteAIS() {
+		cmn.Close(fh) // (remote ais closes it)
+	}
+	if err != nil {
+		wb.retry(lom, gen, err)
+		return
+	}
+
+	lom.Lock(true)
+	defer lom.Unlock(true)
+	wb.mtx.Lock()
+	e, exists := wb.pending[uname]
+	switch {
+	case !exists:
+		// canceled (deleted) while being uploaded - delete the remote copy as well
+		wb.mtx.Unlock()
+		if err := lom.Load(false); err == nil {
+			return // re-PUT in write-through mode
+		}
+		if _, err := backend.DeleteObj(context.Background(), lom); err != nil {
+			glog.Errorf("%s: failed to delete %s (canceled write-back): %v", t.si, lom, err)
+		}
+		return
+	case e.gen != gen:
+		// overwritten while being uploaded - upload the new one
+		e.inflight, e.next = false, 0
+		wb.mtx.Unlock()
+		return
+	}
+	delete(wb.pending, uname)
+	wb.delRecord(uname)
+	wb.mtx.Unlock()
+
+	if err := lom.Load(false); err != nil {
+		return
+	}
+	if lom.Bck().IsRemoteAIS() {
+		lom.DelCustomKey(cluster.WriteBackObjMD)
+	} else {
+		setCloudMD(lom, backend.Provider(), version) // (removes WriteBackObjMD)
+	}
+	if lom.VersionConf().Enabled {
+		lom.SetVersion(version)
+	}
+	if err := lom.PersistWithCopies(); err != nil {
+		t.fsErr(err, lom.FQN)
+		glog.Errorf("%s: failed to persist %s upon write-back: %v", t.si, lom, err)
+	}
+	t.statsT.AddMany(
+		stats.NamedVal64{Name: stats.WriteBackCount, Value: 1},
+		stats.NamedVal64{Name: stats.WriteBackSize, Value: lom.Size()},
+		stats.NamedVal64{Name: stats.WriteBackLatency, Value: int64(time.Since(started))},
+	)
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("write-back %s: %s", lom, time.Since(started))
+	}
+}
+
+func (wb *writeBack) done(uname string, gen int64) {
+	wb.mtx.Lock()
+	if e, exists := wb.pending[uname]; exists {
+		if e.gen == gen {
+			delete(wb.pending, uname)
+			wb.delRecord(uname)
+		} else {
+			e.inflight = false
+		}
+	}
+	wb.mtx.Unlock()
+}
+
+func (wb *writeBack) retry(lom *cluster.LOM, gen int64, err error) {
+	var (
+		uname   = lom.Uname()
+		retries int
+		delay   time.Duration
+	)
+	wb.t.statsT.Add(stats.ErrWriteBackCount, 1)
+	wb.mtx.Lock()
+	e, exists := wb.pending[uname]
+	if !exists {
+		wb.mtx.Unlock()
+		return
+	}
+	e.inflight = false
+	if e.gen != gen {
+		wb.mtx.Unlock() // overwritten in the meantime - upload the new one
+		return
+	}
+	e.Retries++
+	e.Err = err.Error()
+	retries = e.Retries
+	delay = wbRetryMin << uint(cmn.Min(retries-1, 16))
+	if delay > wbRetryMax {
+		delay = wbRetryMax
+	}
+	e.next = time.Now().Add(delay).UnixNano()
+	if errDB := wb.t.dbDriver.Set(wbCollection, uname, &e.WriteBackEntry); errDB != nil {
+		glog.Errorf("%s: failed to update write-back record %s: %v", wb.t.si, uname, errDB)
+	}
+	wb.mtx.Unlock()
+	glog.Errorf("write-back %s: %v (retry #%d in %v)", lom, err, retries, delay)
+}
+
+// NOTE: under lock
+func (wb *writeBack) delRecord(uname string) {
+	if err := wb.t.dbDriver.Delete(wbCollection, uname); err != nil && !dbdriver.IsErrNotFound(err) {
+		glog.Errorf("%s: failed to delete write-back record %s: %v", wb.t.si, uname, err)
+	}
+}
@@ -173,6 +173,8 @@ func (t *targetrunner) cmdXactStart(xactMsg *xaction.XactReqMsg, bck *cluster.Bc
 		go xact.Run()
 	case cmn.ActLoadLomCache:
 		return xreg.RenewBckLoadLomCache(t, xactMsg.ID, bck)
+	case cmn.ActLifecycle:
+		return xreg.RenewLifecycle(t, xactMsg.ID, bck)
 	// 3. cannot start
 	case cmn.ActPutCopies:
 		return fmt.Errorf("cannot start %q (is driven by PUTs into a mirrored bucket)", xactMsg)
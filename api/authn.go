@@ -21,11 +21,18 @@ type (
 
 	loginRec struct {
 		Password string `json:"password"`
+		S3       bool   `json:"s3,omitempty"`
 	}
 
 	AuthCreds struct {
 		Token string `json:"token"`
 	}
+
+	// S3 credentials to sign requests to AIS S3 API (AWS signature V4)
+	S3Creds struct {
+		AccessKey string `json:"access_key"`
+		SecretKey string `json:"secret_key"`
+	}
 )
 
 func AddUser(baseParams BaseParams, newUser *cmn.AuthUser) error {
@@ -71,6 +78,24 @@ func LoginUser(baseParams BaseParams, userID, pass string) (token *AuthCreds, er
 	return token, nil
 }
 
+// LoginUserS3 generates S3 access and secret keys for the user.
+// The keys never expire but can be revoked by key ID (see RevokeS3KeyAuthN).
+func LoginUserS3(baseParams BaseParams, userID, pass string) (creds *S3Creds, err error) {
+	baseParams.Method = http.MethodPost
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathUsers.Join(userID),
+		Body:       cmn.MustMarshal(loginRec{Password: pass, S3: true}),
+	}, &creds)
+	if err != nil {
+		return nil, err
+	}
+	if creds.AccessKey == "" || creds.SecretKey == "" {
+		return nil, errors.New("login failed: empty response from AuthN server")
+	}
+	return creds, nil
+}
+
 func RegisterClusterAuthN(baseParams BaseParams, cluSpec cmn.AuthCluster) error {
 	msg := cmn.MustMarshal(cluSpec)
 	baseParams.Method = http.MethodPost
@@ -158,3 +183,25 @@ func DeleteRoleAuthN(baseParams BaseParams, role string) error {
 		Path:       cmn.URLPathRoles.Join(role),
 	})
 }
+
+// GetS3KeysAuthN returns S3 keys issued for a user (all keys if userID is empty)
+func GetS3KeysAuthN(baseParams BaseParams, userID string) ([]*cmn.AuthS3Key, error) {
+	baseParams.Method = http.MethodGet
+	path := cmn.URLPathS3Keys.S
+	if userID != "" {
+		path = cmn.URLPathS3Keys.Join(userID)
+	}
+	keys := make([]*cmn.AuthS3Key, 0)
+	err := DoHTTPRequest(ReqParams{BaseParams: baseParams, Path: path}, &keys)
+	less := func(i, j int) bool { return keys[i].Issued.Before(keys[j].Issued) }
+	sort.Slice(keys, less)
+	return keys, err
+}
+
+func RevokeS3KeyAuthN(baseParams BaseParams, keyID string) error {
+	baseParams.Method = http.MethodDelete
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathS3Keys.Join(keyID),
+	})
+}
@@ -151,6 +151,48 @@ func GetBucketsSummaries(baseParams BaseParams, query cmn.QueryBcks,
 	return summaries, nil
 }
 
+// GetBucketDiskUsage returns the disk usage of the bucket's (in-cluster) objects
+// with names starting with `prefix`, aggregated by prefixes up to `depth` levels
+// below it - per target and per mountpath (see cmn.PrefixUsage).
+func GetBucketDiskUsage(baseParams BaseParams, bck cmn.Bck, prefix string, depth int) (cmn.BucketSummary, error) {
+	if err := cmn.ValidateBckName(bck.Name); err != nil {
+		return cmn.BucketSummary{}, err
+	}
+	msg := &cmn.BucketSummaryMsg{DiskUsage: true, Prefix: prefix, Depth: depth}
+	summaries, err := GetBucketsSummaries(baseParams, cmn.QueryBcks(bck), msg)
+	if err != nil {
+		return cmn.BucketSummary{}, err
+	}
+	if len(summaries) != 1 {
+		return cmn.BucketSummary{}, fmt.Errorf("expected summary of bucket %s, got %d summaries", bck, len(summaries))
+	}
+	return summaries[0], nil
+}
+
+// DiffBuckets compares objects in two buckets by name, size and (if both
+// buckets are ais buckets with the same checksum type) checksum.
+// With `msg.Backend` set, compares the in-cluster objects of a remote bucket
+// with its backend (and `dst` is ignored).
+func DiffBuckets(baseParams BaseParams, src, dst cmn.Bck, msg *cmn.BucketDiffMsg) (*cmn.BucketDiff, error) {
+	dmsg := cmn.BucketDiffMsg{}
+	if msg != nil {
+		dmsg = *msg
+	}
+	dmsg.Dst = dst
+	baseParams.Method = http.MethodPost
+	reqParams := ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathBuckets.Join(src.Name),
+		Header:     http.Header{cmn.HeaderContentType: []string{cmn.ContentJSON}},
+		Query:      cmn.AddBckToQuery(nil, src),
+	}
+	diff := &cmn.BucketDiff{}
+	if err := waitForAsyncReqComplete(reqParams, cmn.ActDiffBck, &dmsg, diff); err != nil {
+		return nil, err
+	}
+	return diff, nil
+}
+
 // CreateBucket sends a HTTP request to a proxy to create an AIS bucket with the given name.
 func CreateBucket(baseParams BaseParams, bck cmn.Bck, props *cmn.BucketPropsToUpdate) error {
 	if err := cmn.ValidateBckName(bck.Name); err != nil {
@@ -290,8 +332,8 @@ func EvictRemoteBucket(baseParams BaseParams, bck cmn.Bck) error {
 // 3. Breaks loop on error
 // 4. If the destination returns status code StatusOK, it means the response
 //    contains the real data and the function returns the response to the caller
-func waitForAsyncReqComplete(reqParams ReqParams, action string, msg *cmn.BucketSummaryMsg, v interface{}) error {
-	cmn.Assert(action == cmn.ActSummaryBck)
+func waitForAsyncReqComplete(reqParams ReqParams, action string, msg interface{}, v interface{}) error {
+	cmn.Assert(action == cmn.ActSummaryBck || action == cmn.ActDiffBck)
 	var (
 		uuid   string
 		sleep  = initialPollInterval
@@ -311,9 +353,8 @@ func waitForAsyncReqComplete(reqParams ReqParams, action string, msg *cmn.Bucket
 		}
 		return fmt.Errorf("invalid response code: %d", resp.StatusCode)
 	}
-	if msg.UUID == "" {
-		msg.UUID = uuid
-	}
+	// subsequent requests refer to the task by its ID (see also cmn.URLParamUUID)
+	reqParams.Query.Set(cmn.URLParamUUID, uuid)
 
 	// Poll async task for http.StatusOK completion
 	for {
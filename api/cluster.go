@@ -89,6 +89,23 @@ func GetRemoteAIS(baseParams BaseParams) (aisInfo cmn.BackendInfoAIS, err error)
 	return
 }
 
+// GetWriteBackPending returns, for each target, objects that are pending
+// asynchronous upload to their remote buckets (see cmn.WriteBack).
+// Empty bucket means all buckets.
+func GetWriteBackPending(baseParams BaseParams, bck cmn.Bck) (wbInfo map[string]*cmn.WriteBackInfo, err error) {
+	baseParams.Method = http.MethodGet
+	query := url.Values{cmn.URLParamWhat: []string{cmn.GetWhatWriteBack}}
+	if !bck.IsEmpty() {
+		query = cmn.AddBckUnameToQuery(query, bck, cmn.URLParamBucket)
+	}
+	err = DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathCluster.S,
+		Query:      query,
+	}, &wbInfo)
+	return
+}
+
 // JoinCluster add a node to a cluster.
 func JoinCluster(baseParams BaseParams, nodeInfo *cluster.Snode) (rebID string, err error) {
 	baseParams.Method = http.MethodPost
@@ -36,3 +36,7 @@ func AccessAttrs(v cmn.AccessAttrs) *cmn.AccessAttrs {
 func MDWritePolicy(v cmn.MDWritePolicy) *cmn.MDWritePolicy {
 	return &v
 }
+
+func RemoteWritePolicy(v cmn.RemoteWritePolicy) *cmn.RemoteWritePolicy {
+	return &v
+}
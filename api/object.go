@@ -9,6 +9,8 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"mime"
+	"mime/multipart"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -49,7 +51,14 @@ type PutObjectArgs struct {
 	Object     string
 	Cksum      *cmn.Cksum
 	Reader     cmn.ReadOpenCloser
-	Size       uint64 // optional
+	Size       uint64        // optional
+	UserMD     cmn.SimpleKVs // optional: user-defined metadata
+	Tags       cmn.SimpleKVs // optional: object tags
+	// optional: compare-and-swap (see cmn.Preconds) - PUT only if the current
+	// version or checksum of the object does (IfMatch) or does not (IfNoneMatch)
+	// match any of the comma-separated values; "*" matches any existing object
+	IfMatch     string
+	IfNoneMatch string
 }
 
 type PromoteArgs struct {
@@ -119,6 +128,12 @@ func HeadObject(baseParams BaseParams, bck cmn.Bck, object string, checkExists .
 	if err != nil {
 		return nil, err
 	}
+	if objProps.UserMD, err = cmn.KVsFromHdr(resp.Header, cmn.HeaderObjUserMD); err != nil {
+		return nil, err
+	}
+	if objProps.Tags, err = cmn.KVsFromHdr(resp.Header, cmn.HeaderObjTags); err != nil {
+		return nil, err
+	}
 	return objProps, nil
 }
 
@@ -132,6 +147,52 @@ func DeleteObject(baseParams BaseParams, bck cmn.Bck, object string) error {
 	})
 }
 
+// DeleteLockedObject deletes an object that is still inside its governance-mode
+// retention period (requires admin permissions) - see `cmn.ObjLockConf`.
+func DeleteLockedObject(baseParams BaseParams, bck cmn.Bck, object string) error {
+	baseParams.Method = http.MethodDelete
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathObjects.Join(bck.Name, object),
+		Query:      cmn.AddBckToQuery(nil, bck),
+		Header:     http.Header{cmn.HeaderBypassGovernance: []string{"true"}},
+	})
+}
+
+// SetObjectRetention sets the end of the object's retention period. The
+// retention can only be extended unless `bypassGovernance` is specified (and the
+// bucket is in governance mode) - see `cmn.ObjLockConf`.
+func SetObjectRetention(baseParams BaseParams, bck cmn.Bck, object string, until time.Time,
+	bypassGovernance ...bool) error {
+	var hdr http.Header
+	if len(bypassGovernance) > 0 && bypassGovernance[0] {
+		hdr = http.Header{cmn.HeaderBypassGovernance: []string{"true"}}
+	}
+	baseParams.Method = http.MethodPost
+	actMsg := cmn.ActionMsg{Action: cmn.ActSetRetention, Value: cmn.ActValRetention{RetainUntil: until.UnixNano()}}
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathObjects.Join(bck.Name, object),
+		Body:       cmn.MustMarshal(actMsg),
+		Query:      cmn.AddBckToQuery(nil, bck),
+		Header:     hdr,
+	})
+}
+
+// DeleteObjectVersion deletes a given version of the object. Deleting the current
+// version makes the newest prior version (if any) current - see `versioning.retain`.
+// To read a prior version, set `cmn.URLParamVersion` in `GetObjectInput.Query`.
+func DeleteObjectVersion(baseParams BaseParams, bck cmn.Bck, object, version string) error {
+	baseParams.Method = http.MethodDelete
+	query := url.Values{cmn.URLParamVersion: []string{version}}
+	query = cmn.AddBckToQuery(query, bck)
+	return DoHTTPRequest(ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathObjects.Join(bck.Name, object),
+		Query:      query,
+	})
+}
+
 // EvictObject evicts an object specified by bucket/object.
 func EvictObject(baseParams BaseParams, bck cmn.Bck, object string) error {
 	baseParams.Method = http.MethodDelete
@@ -181,9 +242,8 @@ func GetObjectReader(baseParams BaseParams, bck cmn.Bck, object string, options
 		hdr http.Header
 	)
 	if len(options) != 0 {
-		var w io.Writer
-		w, q, hdr = getObjectOptParams(options[0])
-		cmn.Assert(w == nil)
+		cmn.Assert(options[0].Writer == nil)
+		_, q, hdr = getObjectOptParams(options[0])
 	}
 
 	q = cmn.AddBckToQuery(q, bck)
@@ -265,6 +325,135 @@ func GetObjectWithResp(baseParams BaseParams, bck cmn.Bck, object string, option
 	return resp.Response, resp.n, nil
 }
 
+// ObjectRange is a single byte range of the object returned by GetObjectRanges.
+type ObjectRange struct {
+	cmn.HTTPRange
+	Data []byte
+	// Range checksum: set only when the bucket has `checksum.enable_read_range`
+	// enabled and the response contains multiple ranges.
+	Cksum *cmn.Cksum
+}
+
+// GetObjectRanges reads multiple byte ranges of the object in a single request
+// (the response is `multipart/byteranges`) and returns them in the order of the
+// response parts. Range checksums, if present, are validated.
+//
+// NOTE: if the total size of the ranges exceeds the size of the object, the
+// entire object is returned (as a single range).
+func GetObjectRanges(baseParams BaseParams, bck cmn.Bck, object string,
+	ranges []cmn.HTTPRange) (parts []*ObjectRange, err error) {
+	baseParams.Method = http.MethodGet
+	reqParams := ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathObjects.Join(bck.Name, object),
+		Query:      cmn.AddBckToQuery(nil, bck),
+		Header:     cmn.MultiRangeHdr(ranges),
+	}
+	resp, err := doHTTPRequestGetHTTPResp(reqParams)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		cmn.DrainReader(resp.Body)
+		resp.Body.Close()
+	}()
+	if err := checkResp(reqParams, resp); err != nil {
+		return nil, err
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(resp.Header.Get(cmn.HeaderContentType))
+	if mediaType != cmn.ContentMultiRange {
+		// single range (or the entire object)
+		part := &ObjectRange{}
+		if part.Data, err = ioutil.ReadAll(resp.Body); err != nil {
+			return nil, err
+		}
+		part.Length = int64(len(part.Data))
+		if contentRange := resp.Header.Get(cmn.HeaderContentRange); contentRange != "" {
+			if part.HTTPRange, _, err = cmn.ParseContentRange(contentRange); err != nil {
+				return nil, err
+			}
+		}
+		return []*ObjectRange{part}, nil
+	}
+
+	mpr := multipart.NewReader(resp.Body, params["boundary"])
+	parts = make([]*ObjectRange, 0, len(ranges))
+	for {
+		p, err := mpr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		part := &ObjectRange{}
+		if part.HTTPRange, _, err = cmn.ParseContentRange(p.Header.Get(cmn.HeaderContentRange)); err != nil {
+			return nil, err
+		}
+		if part.Data, err = ioutil.ReadAll(p); err != nil {
+			return nil, err
+		}
+		if int64(len(part.Data)) != part.Length {
+			return nil, fmt.Errorf("range %s: expected %d bytes, got %d",
+				p.Header.Get(cmn.HeaderContentRange), part.Length, len(part.Data))
+		}
+		if cksumType := p.Header.Get(cmn.HeaderObjCksumType); cksumType != "" && cksumType != cmn.ChecksumNone {
+			hdrCksumValue := p.Header.Get(cmn.HeaderObjCksumVal)
+			cksum, err := cmn.ChecksumBytes(part.Data, cksumType)
+			if err != nil {
+				return nil, err
+			}
+			if cksum.Value() != hdrCksumValue {
+				return nil, cmn.NewInvalidCksumError(hdrCksumValue, cksum.Value())
+			}
+			part.Cksum = cksum
+		}
+		parts = append(parts, part)
+	}
+	return parts, nil
+}
+
+// GetBatch reads multiple objects of the bucket in a single request (see
+// cmn.GetBatchMsg) and calls `cb` for each entry of the streamed response,
+// in the order of arrival. Objects that could not be read are reported as
+// entries with non-empty `hdr.Err`. The reader passed to `cb` is valid only
+// until the callback returns.
+func GetBatch(baseParams BaseParams, bck cmn.Bck, msg *cmn.GetBatchMsg,
+	cb func(hdr *cmn.BatchEntryHdr, r io.Reader) error) error {
+	if err := msg.Validate(); err != nil {
+		return err
+	}
+	baseParams.Method = http.MethodPost
+	reqParams := ReqParams{
+		BaseParams: baseParams,
+		Path:       cmn.URLPathBuckets.Join(bck.Name),
+		Body:       cmn.MustMarshal(cmn.ActionMsg{Action: cmn.ActGetBatch, Value: msg}),
+		Header: http.Header{
+			cmn.HeaderContentType: []string{cmn.ContentJSON},
+		},
+		Query: cmn.AddBckToQuery(nil, bck),
+	}
+	body, err := doHTTPRequestGetRespReader(reqParams)
+	if err != nil {
+		return err
+	}
+	defer body.Close()
+	br := cmn.NewBatchReader(body, msg.Format)
+	for {
+		hdr, r, err := br.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := cb(hdr, r); err != nil {
+			return err
+		}
+	}
+}
+
 // PutObject creates an object from the body of the reader argument and puts
 // it in the specified bucket.
 //
@@ -305,6 +494,14 @@ func PutObject(args PutObjectArgs) (err error) {
 		if args.Size != 0 {
 			req.ContentLength = int64(args.Size) // as per https://tools.ietf.org/html/rfc7230#section-3.3.2
 		}
+		cmn.KVsToHdr(req.Header, cmn.HeaderObjUserMD, args.UserMD)
+		cmn.KVsToHdr(req.Header, cmn.HeaderObjTags, args.Tags)
+		if args.IfMatch != "" {
+			req.Header.Set(cmn.HeaderIfMatch, args.IfMatch)
+		}
+		if args.IfNoneMatch != "" {
+			req.Header.Set(cmn.HeaderIfNoneMatch, args.IfNoneMatch)
+		}
 
 		setAuthToken(req, args.BaseParams)
 		return req, nil
@@ -5,9 +5,12 @@
 package api
 
 import (
+	"errors"
+	"fmt"
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/NVIDIA/aistore/cmn"
 )
@@ -35,3 +38,23 @@ func GetObjectS3(baseParams BaseParams, bck cmn.Bck, objectName string, options
 	}
 	return resp.n, nil
 }
+
+// PresignObjectS3 returns S3 URL of the object that allows anyone to perform
+// the `method` (GET, HEAD, or PUT) on the object without any other credentials
+// until the URL expires. Requires S3 credentials issued by AuthN (see LoginUserS3).
+func PresignObjectS3(baseParams BaseParams, bck cmn.Bck, objName, method string, creds *S3Creds,
+	expires time.Duration) (string, error) {
+	if !bck.IsAIS() || !bck.Ns.IsGlobal() {
+		return "", fmt.Errorf("S3 API does not support bucket %s", bck)
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPut:
+	default:
+		return "", fmt.Errorf("cannot presign %s request (expecting GET, HEAD, or PUT)", method)
+	}
+	if creds == nil || creds.AccessKey == "" || creds.SecretKey == "" {
+		return "", errors.New("S3 credentials are required to presign URL")
+	}
+	rawURL := baseParams.URL + cmn.URLPathS3.Join(bck.Name, objName)
+	return cmn.PresignURL(method, rawURL, creds.AccessKey, creds.SecretKey, "", expires)
+}
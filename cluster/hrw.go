@@ -6,6 +6,8 @@ package cluster
 
 import (
 	"fmt"
+	"math"
+	"sort"
 
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
@@ -16,12 +18,20 @@ import (
 // A variant of consistent hash based on rendezvous algorithm by Thaler and Ravishankar,
 // aka highest random weight (HRW)
 
+// Targets may have different weights (see Snode.Weight) - in which case the
+// selection is done by weighted rendezvous hashing: each target's HRW is mapped
+// onto (0, 1) and scaled as `weight / -ln(hrw)`, so that each target receives
+// a share of objects proportional to its weight. Changing the weight of
+// a given target only moves objects to or from this target.
+
 // Returns the target with highest HRW that is "available"(e.g, is not under maintenance).
 func HrwTarget(uname string, smap *Smap, inMaintenance ...bool) (si *Snode, err error) {
 	var (
 		max             uint64
+		maxScore        float64
 		digest          = xxhash.ChecksumString64S(uname, cmn.MLCG32)
 		skipMaintenance = true
+		weighted        = smap.weighted()
 	)
 	if len(inMaintenance) != 0 {
 		skipMaintenance = !inMaintenance[0]
@@ -32,7 +42,12 @@ func HrwTarget(uname string, smap *Smap, inMaintenance ...bool) (si *Snode, err
 			continue
 		}
 		cs := xoshiro256.Hash(tsi.idDigest ^ digest)
-		if cs >= max {
+		if weighted {
+			if score := hrwScore(cs, tsi); score >= maxScore {
+				maxScore = score
+				si = tsi
+			}
+		} else if cs >= max {
 			max = cs
 			si = tsi
 		}
@@ -85,23 +100,45 @@ func (hl *hrwList) get() Nodes {
 // returns resulting subset (aka slice) that has the requested length = count.
 // Returns error if the cluster does not have enough targets.
 // If count == length of Smap.Tmap, the function returns as many targets as possible.
+//
+// When targets are labeled with failure domains (see Snode.Zone and Snode.Rack)
+// the list spreads across zones first and racks within each zone second - see hrwDomainList.
+// Either way, the first target in the list is the one returned by HrwTarget.
 func HrwTargetList(uname string, smap *Smap, count int) (sis Nodes, err error) {
 	cnt := smap.CountTargets()
 	if cnt < count {
 		err = fmt.Errorf("insufficient targets: required %d, available %d, %s", count, cnt, smap)
 		return
 	}
-	digest := xxhash.ChecksumString64S(uname, cmn.MLCG32)
-	hlist := newHrwList(count)
-
-	for _, tsi := range smap.Tmap {
-		cs := xoshiro256.Hash(tsi.idDigest ^ digest)
-		if tsi.inMaintenance() {
-			continue
+	var (
+		digest   = xxhash.ChecksumString64S(uname, cmn.MLCG32)
+		domains  = smap.hasDomains()
+		weighted = smap.weighted()
+	)
+	if domains || weighted {
+		all := hrwSorted(digest, smap, weighted)
+		if domains {
+			hrwDomainList(all)
 		}
-		hlist.add(cs, tsi)
+		n := count
+		if n > len(all) {
+			n = len(all) // (checked below)
+		}
+		sis = make(Nodes, n)
+		for i := range sis {
+			sis[i] = all[i].tsi
+		}
+	} else {
+		hlist := newHrwList(count)
+		for _, tsi := range smap.Tmap {
+			cs := xoshiro256.Hash(tsi.idDigest ^ digest)
+			if tsi.inMaintenance() {
+				continue
+			}
+			hlist.add(cs, tsi)
+		}
+		sis = hlist.get()
 	}
-	sis = hlist.get()
 	if count != cnt && len(sis) < count {
 		err = fmt.Errorf("insufficient targets: required %d, available %d, %s", count, len(sis), smap)
 		return nil, err
@@ -109,6 +146,68 @@ func HrwTargetList(uname string, smap *Smap, count int) (sis Nodes, err error) {
 	return sis, nil
 }
 
+type hrwTarget struct {
+	tsi            *Snode
+	hrw            uint64
+	score          float64 // weighted HRW (see hrwScore)
+	zoneRank, rank int     // see hrwDomainList
+}
+
+// Returns weighted HRW of the target.
+func hrwScore(hrw uint64, tsi *Snode) float64 {
+	// Map the top 53 bits onto (0, 1) - ln(0) is not defined.
+	x := (float64(hrw>>11) + 0.5) / (1 << 53)
+	return float64(tsi.weight()) / -math.Log(x)
+}
+
+// Returns all targets (except those under maintenance) sorted by their
+// respective HRW (weighted or not) in a descending order.
+func hrwSorted(digest uint64, smap *Smap, weighted bool) []*hrwTarget {
+	all := make([]*hrwTarget, 0, len(smap.Tmap))
+	for _, tsi := range smap.Tmap {
+		if tsi.inMaintenance() {
+			continue
+		}
+		ht := &hrwTarget{tsi: tsi, hrw: xoshiro256.Hash(tsi.idDigest ^ digest)}
+		if weighted {
+			ht.score = hrwScore(ht.hrw, tsi)
+		}
+		all = append(all, ht)
+	}
+	if weighted {
+		sort.Slice(all, func(i, j int) bool { return all[i].score > all[j].score })
+	} else {
+		sort.Slice(all, func(i, j int) bool { return all[i].hrw > all[j].hrw })
+	}
+	return all
+}
+
+// Reorders targets (sorted by HRW) so that each next target comes from
+// the least used zone and, within the zone, from the least used rack.
+// Ties are resolved by HRW, so that the order remains consistent
+// across the cluster and changes minimally when targets join or leave.
+func hrwDomainList(all []*hrwTarget) {
+	var (
+		zones = make(map[string][]*hrwTarget)
+		racks = make(map[string]int)
+	)
+	// Within each zone: the k-th (by HRW) target of a rack is ranked k.
+	for _, ht := range all {
+		domain := ht.tsi.Domain()
+		ht.rank = racks[domain]
+		racks[domain]++
+		zones[ht.tsi.Zone] = append(zones[ht.tsi.Zone], ht)
+	}
+	// Across zones: the k-th target of a zone (ordered by rack rank) is ranked k.
+	for _, hts := range zones {
+		sort.SliceStable(hts, func(i, j int) bool { return hts[i].rank < hts[j].rank })
+		for k, ht := range hts {
+			ht.zoneRank = k
+		}
+	}
+	sort.SliceStable(all, func(i, j int) bool { return all[i].zoneRank < all[j].zoneRank })
+}
+
 func HrwProxy(smap *Smap, idToSkip string) (pi *Snode, err error) {
 	var max uint64
 	for pid, psi := range smap.Pmap {
@@ -0,0 +1,154 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func newDomainSmap(zones, racks, targets int) *Smap {
+	smap := &Smap{Tmap: make(NodeMap)}
+	for z := 0; z < zones; z++ {
+		for r := 0; r < racks; r++ {
+			for t := 0; t < targets; t++ {
+				id := fmt.Sprintf("t-%d-%d-%d", z, r, t)
+				tsi := NewSnode(id, cmn.Target, NetInfo{}, NetInfo{}, NetInfo{})
+				tsi.Zone, tsi.Rack = fmt.Sprintf("zone%d", z), fmt.Sprintf("rack%d", r)
+				smap.Tmap[id] = tsi
+			}
+		}
+	}
+	smap.InitDigests()
+	return smap
+}
+
+func TestHrwTargetListDomains(t *testing.T) {
+	const zones, racks = 3, 2
+	smap := newDomainSmap(zones, racks, 3)
+	for i := 0; i < 100; i++ {
+		uname := cmn.RandString(10)
+		sis, err := HrwTargetList(uname, smap, zones*racks)
+		tassert.CheckFatal(t, err)
+		tassert.Fatalf(t, len(sis) == zones*racks, "expected %d targets, got %d", zones*racks, len(sis))
+
+		tsi, err := HrwTarget(uname, smap)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, sis[0].ID() == tsi.ID(), "expected %s to be the first, got %s", tsi, sis[0])
+
+		// The first `zones` targets come from different zones, all of them - from different racks.
+		var (
+			zoneSet = make(cmn.StringSet)
+			rackSet = make(cmn.StringSet)
+		)
+		for i, si := range sis {
+			if i < zones {
+				zoneSet.Add(si.Zone)
+			}
+			rackSet.Add(si.Domain())
+		}
+		tassert.Errorf(t, len(zoneSet) == zones, "expected %d zones, got %v", zones, zoneSet)
+		tassert.Errorf(t, len(rackSet) == zones*racks, "expected %d racks, got %v", zones*racks, rackSet)
+	}
+
+	// Targets under maintenance do not count.
+	smap = newDomainSmap(1, 2, 3)
+	smap.Tmap["t-0-0-0"].Flags = SnodeMaintenance
+	smap.Tmap["t-0-1-0"].Flags = SnodeDecomission
+	smap.InitDigests()
+	sis, err := HrwTargetList("uname", smap, 5)
+	tassert.Errorf(t, err != nil, "expected insufficient targets, got %v", sis)
+	sis, err = HrwTargetList("uname", smap, 4)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(sis) == 4, "expected 4 targets, got %d", len(sis))
+}
+
+func TestHrwTargetListNoDomains(t *testing.T) {
+	smap := newDomainSmap(1, 1, 10)
+	for _, tsi := range smap.Tmap {
+		tsi.Zone, tsi.Rack = "", ""
+	}
+	smap.InitDigests()
+	for i := 0; i < 100; i++ {
+		uname := cmn.RandString(10)
+		sis, err := HrwTargetList(uname, smap, 4)
+		tassert.CheckFatal(t, err)
+
+		// Without failure domains the list is the same as the one ordered by weight.
+		smap.Tmap[sis[0].ID()].Zone = "zone"
+		smap.InitDigests()
+		domainSis, err := HrwTargetList(uname, smap, 4)
+		tassert.CheckFatal(t, err)
+		smap.Tmap[sis[0].ID()].Zone = ""
+		smap.InitDigests()
+		for j := range sis {
+			tassert.Errorf(t, sis[j].ID() == domainSis[j].ID(), "expected %s at %d, got %s", sis[j], j, domainSis[j])
+		}
+	}
+}
+
+func TestHrwTargetWeighted(t *testing.T) {
+	const num = 30000
+	smap := newDomainSmap(1, 1, 4)
+	weights := map[string]uint64{"t-0-0-0": 100, "t-0-0-1": 100, "t-0-0-2": 200, "t-0-0-3": 400}
+	for id, tsi := range smap.Tmap {
+		tsi.Zone, tsi.Rack = "", ""
+		tsi.Weight = weights[id]
+	}
+	smap.InitDigests()
+	var (
+		unames = make([]string, num)
+		prev   = make([]string, num)
+		counts = make(map[string]int)
+	)
+	for i := range unames {
+		unames[i] = cmn.RandString(16)
+		tsi, err := HrwTarget(unames[i], smap)
+		tassert.CheckFatal(t, err)
+		prev[i] = tsi.ID()
+		counts[tsi.ID()]++
+
+		sis, err := HrwTargetList(unames[i], smap, 2)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, sis[0].ID() == tsi.ID(), "expected %s to be the first, got %s", tsi, sis[0])
+	}
+	// Each target receives a share of objects proportional to its weight (+/- 10%).
+	for id, weight := range weights {
+		expected := num * int(weight) / 800
+		tassert.Errorf(t, counts[id] > expected*9/10 && counts[id] < expected*11/10,
+			"%s (weight %d): expected about %d objects, got %d", id, weight, expected, counts[id])
+	}
+
+	// Increasing the weight of a target only moves objects to this target.
+	smap.Tmap["t-0-0-0"].Weight = 400
+	smap.InitDigests()
+	for i, uname := range unames {
+		tsi, err := HrwTarget(uname, smap)
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, tsi.ID() == prev[i] || tsi.ID() == "t-0-0-0",
+			"object %q moved from %s to %s", uname, prev[i], tsi)
+	}
+}
+
+// HRW properties are computed once - by InitDigests.
+func TestHrwPropsCached(t *testing.T) {
+	smap := newDomainSmap(1, 1, 3)
+	for _, tsi := range smap.Tmap {
+		tsi.Zone, tsi.Rack = "", ""
+	}
+	tassert.Errorf(t, smap.hasDomains(), "expected domains before InitDigests")
+	smap.InitDigests()
+	tassert.Errorf(t, !smap.hasDomains() && !smap.weighted(), "expected neither domains nor weights")
+
+	smap.Tmap["t-0-0-0"].Weight = 400
+	tassert.Errorf(t, !smap.weighted(), "expected cached HRW properties")
+	smap.ResetHRW()
+	tassert.Errorf(t, smap.weighted(), "expected weights after ResetHRW")
+	smap.InitDigests()
+	tassert.Errorf(t, smap.weighted(), "expected weights after InitDigests")
+}
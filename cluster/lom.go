@@ -188,6 +188,108 @@ func (lom *LOM) GetCustomMD(key string) (string, bool) {
 	value, exists := lom.md.customMD[key]
 	return value, exists
 }
+
+// SetCustomKey and DelCustomKey update a single custom metadata entry;
+// the rest of the custom metadata remains intact.
+func (lom *LOM) SetCustomKey(key, value string) {
+	customMD := make(cmn.SimpleKVs, len(lom.md.customMD)+1)
+	for k, v := range lom.md.customMD {
+		customMD[k] = v
+	}
+	customMD[key] = value
+	lom.md.customMD = customMD
+}
+
+func (lom *LOM) DelCustomKey(key string) {
+	if _, exists := lom.md.customMD[key]; !exists {
+		return
+	}
+	customMD := make(cmn.SimpleKVs, len(lom.md.customMD))
+	for k, v := range lom.md.customMD {
+		if k != key {
+			customMD[k] = v
+		}
+	}
+	lom.md.customMD = customMD
+}
+
+// IsWriteBackPending returns true if the object is yet to be uploaded
+// to its remote bucket (write-back mode).
+func (lom *LOM) IsWriteBackPending() bool {
+	_, pending := lom.md.customMD[WriteBackObjMD]
+	return pending
+}
+
+// RetainUntil returns the end of the object's retention period (zero time if none).
+func (lom *LOM) RetainUntil() (until time.Time) {
+	if v, ok := lom.md.customMD[RetainUntilObjMD]; ok {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			until = time.Unix(0, n)
+		}
+	}
+	return
+}
+
+func (lom *LOM) SetRetainUntil(until time.Time) {
+	lom.SetCustomKey(RetainUntilObjMD, strconv.FormatInt(until.UnixNano(), 10))
+}
+
+// LastModifiedUnix returns the time the object was last written (zero if unknown) -
+// unlike the file's mtime, it survives migration (rebalance, resilver) and copying.
+func (lom *LOM) LastModifiedUnix() (mtime int64) {
+	if v, ok := lom.md.customMD[LastModifiedObjMD]; ok {
+		mtime, _ = strconv.ParseInt(v, 10, 64)
+	}
+	return
+}
+
+func (lom *LOM) SetLastModifiedUnix(mtime int64) {
+	lom.SetCustomKey(LastModifiedObjMD, strconv.FormatInt(mtime, 10))
+}
+
+// CheckRetention returns cmn.ErrObjLocked if the (loaded) object cannot be
+// deleted, overwritten, renamed, or evicted as yet.
+func (lom *LOM) CheckRetention(bypass bool) error {
+	return lom.Bprops().ObjLock.CheckRetention(lom.String(), lom.RetainUntil(), time.Now(), bypass)
+}
+
+// User-defined metadata and tags are kept in the custom metadata under their
+// respective prefixes - the rest of the custom metadata remains intact.
+func (lom *LOM) UserMD() cmn.SimpleKVs      { return lom.prefixedMD(UserObjMDPrefix) }
+func (lom *LOM) SetUserMD(md cmn.SimpleKVs) { lom.setPrefixedMD(UserObjMDPrefix, md) }
+func (lom *LOM) Tags() cmn.SimpleKVs        { return lom.prefixedMD(TagObjMDPrefix) }
+func (lom *LOM) SetTags(tags cmn.SimpleKVs) { lom.setPrefixedMD(TagObjMDPrefix, tags) }
+func (lom *LOM) GetTag(key string) (string, bool) {
+	value, exists := lom.md.customMD[TagObjMDPrefix+key]
+	return value, exists
+}
+
+func (lom *LOM) prefixedMD(prefix string) (md cmn.SimpleKVs) {
+	for k, v := range lom.md.customMD {
+		if !strings.HasPrefix(k, prefix) {
+			continue
+		}
+		if md == nil {
+			md = make(cmn.SimpleKVs, 4)
+		}
+		md[k[len(prefix):]] = v
+	}
+	return
+}
+
+func (lom *LOM) setPrefixedMD(prefix string, md cmn.SimpleKVs) {
+	customMD := make(cmn.SimpleKVs, len(lom.md.customMD)+len(md))
+	for k, v := range lom.md.customMD {
+		if !strings.HasPrefix(k, prefix) {
+			customMD[k] = v
+		}
+	}
+	for k, v := range md {
+		customMD[prefix+k] = v
+	}
+	lom.md.customMD = customMD
+}
+
 func (lom *LOM) ECEnabled() bool { return lom.Bprops().EC.Enabled }
 func (lom *LOM) IsHRW() bool     { return lom.HrwFQN == lom.FQN } // subj to resilvering
 
@@ -224,6 +326,8 @@ func (lom *LOM) ToHTTPHdr(hdr http.Header) http.Header {
 	if v := lom.md.version; v != "" {
 		hdr.Set(cmn.HeaderObjVersion, v)
 	}
+	cmn.KVsToHdr(hdr, cmn.HeaderObjUserMD, lom.UserMD())
+	cmn.KVsToHdr(hdr, cmn.HeaderObjTags, lom.Tags())
 	return hdr
 }
 
@@ -385,6 +489,16 @@ func (lom *LOM) DelExtraCopies(fqn ...string) (removed bool, err error) {
 	return
 }
 
+// PersistWithCopies persists in-place updates of the object's metadata
+// (e.g., user-defined tags) on the object and all its copies.
+// NOTE: caller is responsible for write-locking
+func (lom *LOM) PersistWithCopies() error {
+	if err := lom.syncMetaWithCopies(); err != nil {
+		return err
+	}
+	return lom.Persist()
+}
+
 // syncMetaWithCopies tries to make sure that all copies have identical metadata.
 // NOTE: uname for LOM must be already locked.
 // NOTE: changes _may_ be made - the caller must call lom.Persist() upon return
@@ -68,6 +68,7 @@ var _ = Describe("LOM", func() {
 
 	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
 	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
 
 	var (
 		bmd = cluster.NewBaseBownerMock(
@@ -990,6 +991,28 @@ var _ = Describe("LOM", func() {
 				Expect(lom.GetCopies()).To(BeNil())
 			})
 		})
+
+		Describe("MoveVersions", func() {
+			It("should move prior versions to the HRW mountpath", func() {
+				lom := prepareLOM(copyFQNs[1])
+				lom.Lock(true)
+				defer lom.Unlock(true)
+				Expect(lom.RetainVersion(2)).NotTo(HaveOccurred())
+				Expect(lom.PrevVersions()).To(Equal([]string{desiredVersion}))
+
+				hrwLom := NewBasicLom(copyFQNs[0])
+				Expect(lom.MoveVersions(hrwLom, make([]byte, testFileSize))).NotTo(HaveOccurred())
+				Expect(lom.PrevVersions()).To(BeEmpty())
+				Expect(hrwLom.PrevVersions()).To(Equal([]string{desiredVersion}))
+
+				vlom, err := hrwLom.LoadVersion(desiredVersion)
+				Expect(err).NotTo(HaveOccurred())
+				defer cluster.FreeLOM(vlom)
+				Expect(vlom.Version()).To(Equal(desiredVersion))
+				Expect(vlom.Size()).To(BeEquivalentTo(testFileSize))
+				Expect(getTestFileHash(vlom.FQN)).To(Equal(getTestFileHash(lom.FQN)))
+			})
+		})
 	})
 
 	Describe("local and cloud bucket with the same name", func() {
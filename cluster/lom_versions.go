@@ -0,0 +1,259 @@
+// Package cluster provides common interfaces and local access to cluster-level metadata
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cluster
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/cmn/debug"
+	"github.com/NVIDIA/aistore/fs"
+)
+
+// Versioning history (see cmn.VersionConf.Retain)
+//
+// When an object in an ais bucket gets overwritten, its current version is
+// hard-linked into the object's history directory (fs.ObjVersionType) on the
+// same mountpath. Each prior version keeps its own metadata (size, checksum,
+// atime, custom metadata) in its xattrs.
+// The history follows the object when the latter gets resilvered to another
+// mountpath (see MoveVersions) or migrates to another target (see SaveVersion).
+// All methods below expect the caller to hold the object's lock.
+
+func (lom *LOM) versionsDir() string {
+	return lom.mpathInfo.MakePathFQN(lom.Bucket(), fs.ObjVersionType, fs.ObjVersionsDir(lom.ObjName))
+}
+
+func (lom *LOM) VersionFQN(version string) string {
+	return fs.CSM.GenContentFQN(lom, fs.ObjVersionType, version)
+}
+
+// PrevVersions returns retained prior versions of the object, newest first.
+func (lom *LOM) PrevVersions() (versions []string, err error) {
+	dir, err := os.Open(lom.versionsDir())
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	infos, err := dir.Readdir(-1)
+	cmn.Close(dir)
+	if err != nil {
+		return
+	}
+	nums := make([]uint64, 0, len(infos))
+	for _, info := range infos {
+		if !info.Mode().IsRegular() {
+			continue // (history of the objects named "<object name>/...")
+		}
+		version, ok := fs.ParseObjVersion(info.Name())
+		if !ok {
+			continue
+		}
+		if n, err := strconv.ParseUint(version, 10, 64); err == nil {
+			nums = append(nums, n)
+		}
+	}
+	sort.Slice(nums, func(i, j int) bool { return nums[i] > nums[j] })
+	versions = make([]string, len(nums))
+	for i, n := range nums {
+		versions[i] = strconv.FormatUint(n, 10)
+	}
+	return
+}
+
+// RetainVersion adds the current (loaded) version of the object to its history
+// and removes the oldest versions beyond the `retain` limit.
+// Must be called under write lock prior to overwriting the object.
+func (lom *LOM) RetainVersion(retain int) (err error) {
+	debug.Assert(lom.Bck().IsAIS())
+	version := lom.md.version
+	if version == "" || retain <= 0 {
+		return
+	}
+	vfqn := lom.VersionFQN(version)
+	if err = cmn.CreateDir(filepath.Dir(vfqn)); err != nil {
+		return
+	}
+	// (the version may have been restored and then overwritten again)
+	if err = cmn.RemoveFile(vfqn); err != nil {
+		return
+	}
+	if err = os.Link(lom.FQN, vfqn); err != nil {
+		return
+	}
+	// NOTE: persisted metadata may be stale (see cmn.MDWritePolicy) - store the loaded one
+	buf, mm := lom.marshal()
+	err = fs.SetXattr(vfqn, XattrLOM, buf)
+	mm.Free(buf)
+	if err != nil {
+		if errRm := cmn.RemoveFile(vfqn); errRm != nil {
+			glog.Errorf("%s: failed to remove version %s: %v", lom, version, errRm)
+		}
+		return
+	}
+	return lom.trimVersions(retain)
+}
+
+func (lom *LOM) trimVersions(retain int) error {
+	versions, err := lom.PrevVersions()
+	if err != nil {
+		return err
+	}
+	for i := retain; i < len(versions); i++ {
+		if err := cmn.RemoveFile(lom.VersionFQN(versions[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// LoadVersion returns the given prior version of the object. The returned LOM
+// can be used to read the version's metadata and content (FQN) - but not to
+// update either; the caller must free it (see FreeLOM).
+func (lom *LOM) LoadVersion(version string) (*LOM, error) {
+	vlom := lom.Clone(lom.VersionFQN(version))
+	if _, err := vlom.lmfs(true); err != nil {
+		FreeLOM(vlom)
+		if os.IsNotExist(err) {
+			err = cmn.NewNotFoundError("%s version %q", lom, version)
+		}
+		return nil, err
+	}
+	vlom.md.copies = nil // (copies belong to the current version)
+	return vlom, nil
+}
+
+// CheckVersionsRetention returns cmn.ErrObjLocked if any of the prior versions
+// of the object is still retained (see CheckRetention).
+func (lom *LOM) CheckVersionsRetention(bypass bool) error {
+	if !lom.Bprops().ObjLock.Enabled() {
+		return nil
+	}
+	versions, err := lom.PrevVersions()
+	if err != nil {
+		return err
+	}
+	for _, version := range versions {
+		vlom, err := lom.LoadVersion(version)
+		if err != nil {
+			if cmn.IsObjNotExist(err) {
+				continue
+			}
+			return err
+		}
+		err = vlom.CheckRetention(bypass)
+		FreeLOM(vlom)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RestoreVersion makes the given prior version current. The current version,
+// if exists, must be removed beforehand.
+func (lom *LOM) RestoreVersion(version string) error {
+	if err := os.Rename(lom.VersionFQN(version), lom.FQN); err != nil {
+		return err
+	}
+	lom.Uncache(true /*delDirty*/)
+	if _, err := lom.lmfs(true); err != nil {
+		return err
+	}
+	lom.md.copies = nil
+	return lom.Persist()
+}
+
+// DelVersion removes the given prior version of the object.
+func (lom *LOM) DelVersion(version string) error {
+	if err := os.Remove(lom.VersionFQN(version)); err != nil {
+		if os.IsNotExist(err) {
+			return cmn.NewNotFoundError("%s version %q", lom, version)
+		}
+		return err
+	}
+	return nil
+}
+
+// DelAllVersions removes the entire history of the object.
+func (lom *LOM) DelAllVersions() error {
+	versions, err := lom.PrevVersions()
+	if err != nil || len(versions) == 0 {
+		return err
+	}
+	for _, version := range versions {
+		if err := cmn.RemoveFile(lom.VersionFQN(version)); err != nil {
+			return err
+		}
+	}
+	// (not empty if there's history of the objects named "<object name>/...")
+	_ = os.Remove(lom.versionsDir())
+	return nil
+}
+
+// MoveVersions moves the history of the (misplaced) object to the mountpath
+// of `dst` - the same object at its HRW location.
+func (lom *LOM) MoveVersions(dst *LOM, buf []byte) error {
+	debug.Assert(lom.Uname() == dst.Uname())
+	versions, err := lom.PrevVersions()
+	if err != nil || len(versions) == 0 {
+		return err
+	}
+	for _, version := range versions {
+		srcFQN, dstFQN := lom.VersionFQN(version), dst.VersionFQN(version)
+		if err := cmn.CreateDir(filepath.Dir(dstFQN)); err != nil {
+			return err
+		}
+		if _, _, err := cmn.CopyFile(srcFQN, dstFQN, buf, cmn.ChecksumNone); err != nil {
+			return err
+		}
+		md, err := fs.GetXattr(srcFQN, XattrLOM)
+		if err == nil {
+			err = fs.SetXattr(dstFQN, XattrLOM, md)
+		}
+		if err != nil {
+			if errRm := cmn.RemoveFile(dstFQN); errRm != nil {
+				glog.Errorf("%s: failed to remove version %s: %v", dst, version, errRm)
+			}
+			return err
+		}
+	}
+	return lom.DelAllVersions()
+}
+
+// VersionMD returns the (serialized) metadata of the given prior version -
+// to migrate the version to another target (see SaveVersion).
+func (lom *LOM) VersionMD(version string) ([]byte, error) {
+	return fs.GetXattr(lom.VersionFQN(version), XattrLOM)
+}
+
+// SaveVersion stores the prior version of the object migrated from another target:
+// the version's metadata (see VersionMD) and content of the given size.
+func (lom *LOM) SaveVersion(version string, md []byte, r io.Reader, size int64, buf []byte) (err error) {
+	vfqn := lom.VersionFQN(version)
+	if err = cmn.CreateDir(filepath.Dir(vfqn)); err != nil {
+		return
+	}
+	workFQN := fs.CSM.GenContentFQN(lom, fs.WorkfileType, fs.WorkfileVersion)
+	if _, err = cmn.SaveReader(workFQN, r, buf, cmn.ChecksumNone, size, ""); err != nil {
+		return
+	}
+	if err = fs.SetXattr(workFQN, XattrLOM, md); err == nil {
+		err = cmn.Rename(workFQN, vfqn)
+	}
+	if err != nil {
+		if errRm := cmn.RemoveFile(workFQN); errRm != nil {
+			glog.Errorf("%s: failed to remove %s: %v", lom, workFQN, errRm)
+		}
+	}
+	return
+}
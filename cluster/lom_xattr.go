@@ -74,6 +74,7 @@ const (
 	SourceGoogleObjMD = cmn.ProviderGoogle
 	SourceHDFSObjMD   = cmn.ProviderHDFS
 	SourceHTTPObjMD   = cmn.ProviderHTTP
+	SourcePOSIXObjMD  = cmn.ProviderPOSIX
 	SourceWebObjMD    = "web"
 
 	VersionObjMD = "v"
@@ -81,6 +82,20 @@ const (
 	MD5ObjMD     = cmn.ChecksumMD5
 
 	OrigURLObjMD = "orig_url"
+
+	// set while the object awaits asynchronous upload (see cmn.WriteBack)
+	WriteBackObjMD = "wb"
+
+	// end of the object's retention period, unix nano (see cmn.ObjLockConf)
+	RetainUntilObjMD = "retain_until"
+
+	// time the object was last written by a user or - if cached - its last-modified
+	// time in the remote bucket, unix nano (see cmn.LifecycleConf)
+	LastModifiedObjMD = "mtime"
+
+	// prefixes of user-defined metadata and tags (see LOM.UserMD and LOM.Tags)
+	UserObjMDPrefix = "user."
+	TagObjMDPrefix  = "tag."
 )
 
 // NOTE: used in tests, ignores `dirty`
@@ -66,6 +66,9 @@ type (
 		IntraControlNet NetInfo    `json:"intra_control_net"` // cmn.NetworkIntraControl
 		IntraDataNet    NetInfo    `json:"intra_data_net"`    // cmn.NetworkIntraData
 		Flags           SnodeFlags `json:"flags"`             // enum cmn.Snode*
+		Zone            string     `json:"zone,omitempty"`    // failure domain: zone (e.g., availability zone)
+		Rack            string     `json:"rack,omitempty"`    // failure domain: rack within the zone
+		Weight          uint64     `json:"weight,omitempty"`  // HRW weight of the target (see HrwTarget); 0 is the same as 1
 		idDigest        uint64
 		name            string
 		LocalNet        *net.IPNet `json:"-"`
@@ -80,6 +83,13 @@ type (
 		Version      int64   `json:"version,string"` // version
 		UUID         string  `json:"uuid"`           // UUID (assigned once at creation time)
 		CreationTime string  `json:"creation_time"`  // creation time
+
+		hrw *hrwProps // computed by InitDigests
+	}
+	// properties of the map that define how HRW selects targets
+	hrwProps struct {
+		domains  bool // some targets are labeled with failure domains
+		weighted bool // targets (except those under maintenance) have different weights
 	}
 
 	// Smap on-change listeners
@@ -191,7 +201,21 @@ func (d *Snode) Equals(other *Snode) bool {
 	return d.ID() == other.ID() && d.DaemonType == other.DaemonType &&
 		d.PublicNet.Equals(other.PublicNet) &&
 		d.IntraControlNet.Equals(other.IntraControlNet) &&
-		d.IntraDataNet.Equals(other.IntraDataNet)
+		d.IntraDataNet.Equals(other.IntraDataNet) &&
+		d.Zone == other.Zone && d.Rack == other.Rack && d.Weight == other.Weight
+}
+
+// Returns true if the node is labeled with its failure domain (zone and/or rack).
+func (d *Snode) HasDomain() bool { return d.Zone != "" || d.Rack != "" }
+
+// Returns "zone/rack" - the failure domain of the node.
+func (d *Snode) Domain() string { return d.Zone + "/" + d.Rack }
+
+func (d *Snode) weight() uint64 {
+	if d.Weight == 0 {
+		return 1
+	}
+	return d.Weight
 }
 
 func (d *Snode) Validate() error {
@@ -287,6 +311,9 @@ func (ni *NetInfo) Equals(other NetInfo) bool {
 // Smap uniquely and solely defines the primary proxy
 //
 //===============================================================
+
+// InitDigests must be called once the map is built - before it is used
+// to select targets. Besides node digests, it computes HRW properties of the map.
 func (m *Smap) InitDigests() {
 	for _, node := range m.Tmap {
 		node.Digest()
@@ -294,8 +321,13 @@ func (m *Smap) InitDigests() {
 	for _, node := range m.Pmap {
 		node.Digest()
 	}
+	m.hrw = &hrwProps{domains: m.calcDomains(), weighted: m.calcWeighted()}
 }
 
+// ResetHRW drops HRW properties computed by InitDigests - to be called
+// on a copy of the map that is going to be modified.
+func (m *Smap) ResetHRW() { m.hrw = nil }
+
 func (m *Smap) String() string {
 	if m == nil {
 		return "Smap <nil>"
@@ -322,6 +354,46 @@ func (m *Smap) CountActiveTargets() (count int) {
 	return
 }
 
+// Returns true if any of the targets is labeled with its failure domain.
+func (m *Smap) hasDomains() bool {
+	if m.hrw != nil {
+		return m.hrw.domains
+	}
+	return m.calcDomains()
+}
+
+// Returns true if the targets (except those under maintenance) have different weights.
+func (m *Smap) weighted() bool {
+	if m.hrw != nil {
+		return m.hrw.weighted
+	}
+	return m.calcWeighted()
+}
+
+func (m *Smap) calcDomains() bool {
+	for _, t := range m.Tmap {
+		if t.HasDomain() {
+			return true
+		}
+	}
+	return false
+}
+
+func (m *Smap) calcWeighted() bool {
+	var weight uint64
+	for _, t := range m.Tmap {
+		if t.inMaintenance() {
+			continue
+		}
+		if weight == 0 {
+			weight = t.weight()
+		} else if t.weight() != weight {
+			return true
+		}
+	}
+	return false
+}
+
 func (m *Smap) CountNonElectable() (count int) {
 	for _, p := range m.Pmap {
 		if p.nonElectable() {
@@ -30,7 +30,11 @@ type (
 )
 
 func NewBucket(name string, apiParams api.BaseParams) (bck Bucket, err error) {
-	b := cmn.Bck{Name: name}
+	return OpenBucket(cmn.Bck{Name: name}, apiParams)
+}
+
+// OpenBucket is NewBucket for a bucket of any provider and namespace.
+func OpenBucket(b cmn.Bck, apiParams api.BaseParams) (bck Bucket, err error) {
 	b.Props, err = api.HeadBucket(apiParams, b)
 	if err != nil {
 		return &bucketAPI{bck: b, apiParams: apiParams}, err
@@ -38,6 +42,44 @@ func NewBucket(name string, apiParams api.BaseParams) (bck Bucket, err error) {
 	return &bucketAPI{bck: b, apiParams: apiParams}, nil
 }
 
+func ListBuckets(apiParams api.BaseParams, query cmn.QueryBcks) (bcks cmn.BucketNames, err error) {
+	bcks, err = api.ListBuckets(apiParams, query)
+	if err != nil {
+		err = newBucketIOError(err, "ListBuckets")
+	}
+	return
+}
+
+func CreateBucket(apiParams api.BaseParams, bck cmn.Bck) (err error) {
+	if err = api.CreateBucket(apiParams, bck, nil); err != nil {
+		err = newBucketIOError(err, "CreateBucket", bck.Name)
+	}
+	return
+}
+
+func DestroyBucket(apiParams api.BaseParams, bck cmn.Bck) (err error) {
+	if err = api.DestroyBucket(apiParams, bck); err != nil {
+		err = newBucketIOError(err, "DestroyBucket", bck.Name)
+	}
+	return
+}
+
+// IsEmptyBucket lists (at most) one object to check that the bucket is empty.
+func IsEmptyBucket(apiParams api.BaseParams, bck cmn.Bck) (empty bool, err error) {
+	list, err := api.ListObjects(apiParams, bck, &cmn.SelectMsg{PageSize: 1}, 1)
+	if err != nil {
+		return false, newBucketIOError(err, "ListObjects", bck.Name)
+	}
+	return len(list.Entries) == 0, nil
+}
+
+// IsNotFound returns true if the error (as returned by the API) means that
+// the bucket or object does not exist.
+func IsNotFound(err error) bool {
+	httpErr := &cmn.HTTPError{}
+	return errors.As(err, &httpErr) && httpErr.Status == http.StatusNotFound
+}
+
 func (bck *bucketAPI) Name() string              { return bck.bck.Name }
 func (bck *bucketAPI) Bck() cmn.Bck              { return bck.bck }
 func (bck *bucketAPI) APIParams() api.BaseParams { return bck.apiParams }
@@ -45,8 +87,7 @@ func (bck *bucketAPI) APIParams() api.BaseParams { return bck.apiParams }
 func (bck *bucketAPI) HeadObject(objName string) (obj *Object, exists bool, err error) {
 	objProps, err := api.HeadObject(bck.apiParams, bck.Bck(), objName)
 	if err != nil {
-		httpErr := &cmn.HTTPError{}
-		if errors.As(err, &httpErr) && httpErr.Status == http.StatusNotFound {
+		if IsNotFound(err) {
 			return nil, false, nil
 		}
 		return nil, false, newBucketIOError(err, "HeadObject")
@@ -58,24 +99,29 @@ func (bck *bucketAPI) HeadObject(objName string) (obj *Object, exists bool, err
 		Name:      objName,
 		Size:      objProps.Size,
 		Atime:     time.Unix(0, objProps.Atime),
+		Version:   objProps.Version,
+		Checksum:  objProps.Checksum.Value,
 	}, true, nil
 }
 
 func (bck *bucketAPI) ListObjects(prefix, token string, pageSize uint) (objs []*Object, nextToken string, err error) {
 	selectMsg := &cmn.SelectMsg{
 		Prefix:            prefix,
-		Props:             cmn.GetPropsSize,
 		PageSize:          pageSize,
 		ContinuationToken: token,
 	}
+	selectMsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum, cmn.GetPropsVersion)
 	listResult, err := api.ListObjects(bck.apiParams, bck.Bck(), selectMsg, 0)
 	if err != nil {
 		return nil, "", newBucketIOError(err, "ListObjects")
 	}
 
 	objs = make([]*Object, 0, len(listResult.Entries))
-	for _, obj := range listResult.Entries {
-		objs = append(objs, NewObject(obj.Name, bck, obj.Size))
+	for _, entry := range listResult.Entries {
+		obj := NewObject(entry.Name, bck, entry.Size)
+		obj.Version = entry.Version
+		obj.Checksum = entry.Checksum
+		objs = append(objs, obj)
 	}
 	nextToken = listResult.ContinuationToken
 	return
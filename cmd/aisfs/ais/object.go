@@ -18,6 +18,8 @@ type Object struct {
 	Name      string
 	Size      int64
 	Atime     time.Time
+	Version   string
+	Checksum  string
 }
 
 func NewObject(objName string, bucket Bucket, sizes ...int64) *Object {
@@ -37,6 +39,18 @@ func NewObject(objName string, bucket Bucket, sizes ...int64) *Object {
 
 func (obj *Object) Bck() cmn.Bck { return obj.bck }
 
+// Uname returns the name of the object that is unique across buckets.
+func (obj *Object) Uname() string { return obj.bck.MakeUname(obj.Name) }
+
+// CacheTag identifies the content of the object for the purposes of caching
+// it on the client side. Empty tag means that the content is not known.
+func (obj *Object) CacheTag() string {
+	if obj.Version == "" && obj.Checksum == "" {
+		return ""
+	}
+	return obj.Version + "/" + obj.Checksum
+}
+
 func (obj *Object) Put(r cmn.ReadOpenCloser) (err error) {
 	putArgs := api.PutObjectArgs{
 		BaseParams: obj.apiParams,
@@ -43,16 +43,22 @@ var defaultConfig = Config{
 	},
 	// By default we allow unlimited memory to be used by the cache.
 	MemoryLimit: "0B",
+	DataCache: DataCacheConfig{
+		MemSize:  "256MB",
+		DiskSize: "0B",
+		Dir:      "",
+	},
 }
 
 type (
 	Config struct {
-		Cluster     ClusterConfig  `json:"cluster"`
-		Timeout     TimeoutConfig  `json:"timeout"`
-		Periodic    PeriodicConfig `json:"periodic"`
-		Log         LogConfig      `json:"log"`
-		IO          IOConfig       `json:"io"`
-		MemoryLimit string         `json:"memory_limit"`
+		Cluster     ClusterConfig   `json:"cluster"`
+		Timeout     TimeoutConfig   `json:"timeout"`
+		Periodic    PeriodicConfig  `json:"periodic"`
+		Log         LogConfig       `json:"log"`
+		IO          IOConfig        `json:"io"`
+		MemoryLimit string          `json:"memory_limit"`
+		DataCache   DataCacheConfig `json:"data_cache"`
 	}
 
 	ClusterConfig struct {
@@ -80,6 +86,12 @@ type (
 	IOConfig struct {
 		WriteBufSize int64 `json:"write_buf_size"`
 	}
+
+	DataCacheConfig struct {
+		MemSize  string `json:"mem_size"`
+		DiskSize string `json:"disk_size"`
+		Dir      string `json:"dir"` // empty: user's cache directory (see dataCacheDir)
+	}
 )
 
 func (c *Config) validate() (err error) {
@@ -106,6 +118,19 @@ func (c *Config) validate() (err error) {
 	} else if v < 0 {
 		return fmt.Errorf("invalid memory_limit value: %q: expected non-negative value", c.MemoryLimit)
 	}
+	if v, err := cmn.S2B(c.DataCache.MemSize); err != nil {
+		return fmt.Errorf("invalid data_cache.mem_size value: %q: %v", c.DataCache.MemSize, err)
+	} else if v < 0 {
+		return fmt.Errorf("invalid data_cache.mem_size value: %q: expected non-negative value", c.DataCache.MemSize)
+	}
+	if v, err := cmn.S2B(c.DataCache.DiskSize); err != nil {
+		return fmt.Errorf("invalid data_cache.disk_size value: %q: %v", c.DataCache.DiskSize, err)
+	} else if v < 0 {
+		return fmt.Errorf("invalid data_cache.disk_size value: %q: expected non-negative value", c.DataCache.DiskSize)
+	}
+	if c.DataCache.Dir != "" && !filepath.IsAbs(c.DataCache.Dir) {
+		return fmt.Errorf("invalid data_cache.dir format %q: path needs to be absolute", c.DataCache.Dir)
+	}
 	return nil
 }
 
@@ -117,11 +142,28 @@ func (c *Config) writeTo(srvCfg *fs.ServerConfig) {
 	srvCfg.SyncInterval.Store(c.Periodic.SyncInterval)
 	srvCfg.MemoryLimit.Store(uint64(memoryLimit))
 	srvCfg.MaxWriteBufSize.Store(c.IO.WriteBufSize)
+	dataCacheMemSize, _ := cmn.S2B(c.DataCache.MemSize)
+	dataCacheDiskSize, _ := cmn.S2B(c.DataCache.DiskSize)
+	srvCfg.DataCacheMemSize.Store(dataCacheMemSize)
+	srvCfg.DataCacheDiskSize.Store(dataCacheDiskSize)
+}
+
+// Location of the data cache blocks on disk. Unlike the sizes, it can only
+// be set at mount time.
+func (c *Config) dataCacheDir(name string) string {
+	if c.DataCache.Dir != "" {
+		return c.DataCache.Dir
+	}
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		cacheDir = os.TempDir()
+	}
+	return filepath.Join(cacheDir, configDirName, name)
 }
 
-func loadConfig(bucket string) (cfg *Config, err error) {
+func loadConfig(name string) (cfg *Config, err error) {
 	cfg = &Config{}
-	configFileName := bucket + "_mount.json"
+	configFileName := name + "_mount.json"
 	if err = jsp.LoadAppConfig(configDirName, configFileName, &cfg); err != nil {
 		if !os.IsNotExist(err) {
 			return nil, fmt.Errorf("failed to load config: %v", err)
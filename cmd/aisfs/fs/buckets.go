@@ -0,0 +1,191 @@
+// Package fs implements an AIStore file system.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"fmt"
+	"log"
+	"sort"
+	"sync"
+	"syscall"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmd/aisfs/ais"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/jacobsa/fuse/fuseops"
+	"github.com/jacobsa/fuse/fuseutil"
+)
+
+// When mounting multiple buckets (see ServerConfig.Bck) the root directory
+// lists all buckets selected by the query, each bucket being a subdirectory
+// with its own namespace. Buckets are mounted lazily - upon the first lookup.
+// Making and removing directories in the root creates and destroys buckets,
+// respectively.
+
+type (
+	bucketsDir struct {
+		query     cmn.QueryBcks
+		apiParams api.BaseParams
+		cfg       *ServerConfig
+		logger    *log.Logger
+
+		mu      sync.Mutex
+		mounted map[string]*mountedBucket // by bucket name
+	}
+
+	mountedBucket struct {
+		bck ais.Bucket
+		ns  *namespace
+		id  fuseops.InodeID // invalidInodeID until the directory inode is created
+	}
+)
+
+func newBucketsDir(cfg *ServerConfig, apiParams api.BaseParams, logger *log.Logger) *bucketsDir {
+	return &bucketsDir{
+		query:     cmn.QueryBcks(cfg.Bck),
+		apiParams: apiParams,
+		cfg:       cfg,
+		logger:    logger,
+		mounted:   make(map[string]*mountedBucket),
+	}
+}
+
+func (b *bucketsDir) bck(name string) cmn.Bck {
+	return cmn.Bck{Name: name, Provider: b.query.Provider, Ns: b.query.Ns}
+}
+
+// Returns names of the buckets (sorted) that belong in the root directory.
+func (b *bucketsDir) selectNames(bcks cmn.BucketNames) (names []string) {
+	for _, bck := range bcks {
+		// Only the buckets of the queried namespace - to avoid name clashes.
+		if bck.Provider == b.query.Provider && bck.Ns == b.query.Ns {
+			names = append(names, bck.Name)
+		}
+	}
+	sort.Strings(names)
+	return
+}
+
+func (b *bucketsDir) get(name string) *mountedBucket {
+	b.mu.Lock()
+	mb := b.mounted[name]
+	b.mu.Unlock()
+	return mb
+}
+
+// Returns nil if the bucket does not exist.
+func (b *bucketsDir) mount(name string) (*mountedBucket, error) {
+	if mb := b.get(name); mb != nil {
+		return mb, nil
+	}
+	bck, err := ais.OpenBucket(b.bck(name), b.apiParams)
+	if err != nil {
+		if ais.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	ns, err := newNamespace(bck, b.logger, b.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if mb, ok := b.mounted[name]; ok {
+		// Mounted concurrently.
+		ns.stop()
+		return mb, nil
+	}
+	mb := &mountedBucket{bck: bck, ns: ns, id: invalidInodeID}
+	b.mounted[name] = mb
+	return mb, nil
+}
+
+func (b *bucketsDir) lookup(name string) (res EntryLookupResult) {
+	mb, err := b.mount(name)
+	if err != nil {
+		b.logger.Printf("failed to mount bucket %s: %v", b.bck(name), err)
+		return
+	}
+	if mb == nil {
+		return
+	}
+	res.Entry = &fuseutil.Dirent{
+		Inode: mb.id,
+		Name:  name,
+		Type:  fuseutil.DT_Directory,
+	}
+	return
+}
+
+func (b *bucketsDir) listEntries() (entries []fuseutil.Dirent, err error) {
+	bcks, err := ais.ListBuckets(b.apiParams, b.query)
+	if err != nil {
+		return nil, err
+	}
+	for i, name := range b.selectNames(bcks) {
+		id := invalidInodeID
+		if mb := b.get(name); mb != nil {
+			id = mb.id
+		}
+		entries = append(entries, fuseutil.Dirent{
+			Inode:  id,
+			Offset: fuseops.DirOffset(i + 1),
+			Name:   name,
+			Type:   fuseutil.DT_Directory,
+		})
+	}
+	return entries, nil
+}
+
+func (b *bucketsDir) setID(name string, id fuseops.InodeID) {
+	b.mu.Lock()
+	if mb, ok := b.mounted[name]; ok {
+		mb.id = id
+	}
+	b.mu.Unlock()
+}
+
+// Only ais buckets can be created and destroyed.
+func (b *bucketsDir) create(name string) error {
+	if !b.bck(name).IsAIS() {
+		return syscall.EPERM
+	}
+	if err := ais.CreateBucket(b.apiParams, b.bck(name)); err != nil {
+		return err
+	}
+	mb, err := b.mount(name)
+	if err == nil && mb == nil {
+		err = fmt.Errorf("bucket %s does not exist after creating it", b.bck(name))
+	}
+	return err
+}
+
+// The bucket is destroyed only if it is empty in the cluster - the cached
+// namespace may be stale.
+func (b *bucketsDir) destroy(name string) error {
+	if !b.bck(name).IsAIS() {
+		return syscall.EPERM
+	}
+	empty, err := ais.IsEmptyBucket(b.apiParams, b.bck(name))
+	if err != nil {
+		return err
+	}
+	if !empty {
+		return syscall.ENOTEMPTY
+	}
+	return ais.DestroyBucket(b.apiParams, b.bck(name))
+}
+
+func (b *bucketsDir) forget(name string) {
+	b.mu.Lock()
+	mb, ok := b.mounted[name]
+	delete(b.mounted, name)
+	b.mu.Unlock()
+	if ok {
+		mb.ns.stop()
+	}
+}
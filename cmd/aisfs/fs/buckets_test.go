@@ -0,0 +1,78 @@
+// Package fs implements an AIStore file system.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/jacobsa/fuse/fuseops"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Buckets", func() {
+	var (
+		ns      = cmn.Ns{Name: "ns"}
+		buckets *bucketsDir
+	)
+
+	newBuckets := func(query cmn.Bck) *bucketsDir {
+		return newBucketsDir(&ServerConfig{Bck: query}, api.BaseParams{}, nil)
+	}
+
+	mount := func(name string) *mountedBucket {
+		bck := newBucketMock()
+		bckNs, err := newNamespace(bck, nil, buckets.cfg)
+		Expect(err).NotTo(HaveOccurred())
+		mb := &mountedBucket{bck: bck, ns: bckNs, id: invalidInodeID}
+		buckets.mounted[name] = mb
+		return mb
+	}
+
+	bcks := cmn.BucketNames{
+		{Name: "b", Provider: cmn.ProviderAIS},
+		{Name: "a", Provider: cmn.ProviderAIS},
+		{Name: "c", Provider: cmn.ProviderAIS, Ns: ns},
+		{Name: "d", Provider: cmn.ProviderAmazon},
+	}
+
+	It("should select buckets of the provider", func() {
+		buckets = newBuckets(cmn.Bck{Provider: cmn.ProviderAIS})
+		Expect(buckets.selectNames(bcks)).To(Equal([]string{"a", "b"}))
+		Expect(buckets.bck("a")).To(Equal(cmn.Bck{Name: "a", Provider: cmn.ProviderAIS}))
+	})
+
+	It("should select buckets of the namespace", func() {
+		buckets = newBuckets(cmn.Bck{Provider: cmn.ProviderAIS, Ns: ns})
+		Expect(buckets.selectNames(bcks)).To(Equal([]string{"c"}))
+		Expect(buckets.bck("c")).To(Equal(cmn.Bck{Name: "c", Provider: cmn.ProviderAIS, Ns: ns}))
+	})
+
+	It("should track inode of mounted bucket", func() {
+		buckets = newBuckets(cmn.Bck{Provider: cmn.ProviderAIS})
+		mount("a")
+
+		res := buckets.lookup("a")
+		Expect(res.NoEntry()).To(BeFalse())
+		Expect(res.IsDir()).To(BeTrue())
+		Expect(res.NoInode()).To(BeTrue())
+
+		buckets.setID("a", fuseops.InodeID(100))
+		res = buckets.lookup("a")
+		Expect(res.Entry.Inode).To(Equal(fuseops.InodeID(100)))
+
+		buckets.setID("a", invalidInodeID)
+		Expect(buckets.lookup("a").NoInode()).To(BeTrue())
+
+		buckets.forget("a")
+		Expect(buckets.get("a")).To(BeNil())
+	})
+
+	It("should not allow creating and destroying remote buckets", func() {
+		buckets = newBuckets(cmn.Bck{Provider: cmn.ProviderAmazon})
+		Expect(buckets.create("a")).To(HaveOccurred())
+		Expect(buckets.destroy("a")).To(HaveOccurred())
+	})
+})
@@ -39,7 +39,7 @@ const (
 )
 
 var (
-	ns *namespace // Namespace for files and directories.
+	dcache *dataCache // Cache for objects' data (blocks read from the cluster).
 
 	glMem2 *memsys.MMSA // Global memory manager
 )
@@ -55,7 +55,7 @@ type (
 
 		// Cluster
 		AISURL        string
-		BucketName    string
+		Bck           cmn.Bck // bucket to mount or, if the name is empty, query selecting buckets to mount
 		SkipVerifyCrt bool
 
 		// Access
@@ -67,6 +67,11 @@ type (
 		SyncInterval    atomic.Duration
 		MemoryLimit     atomic.Uint64
 		MaxWriteBufSize atomic.Int64
+
+		// Data cache
+		DataCacheDir      string
+		DataCacheMemSize  atomic.Int64
+		DataCacheDiskSize atomic.Int64
 	}
 
 	// File system implementation.
@@ -81,9 +86,6 @@ type (
 		// Config
 		cfg *ServerConfig
 
-		// Bucket
-		bck ais.Bucket
-
 		// HTTP client
 		httpClient *http.Client
 
@@ -145,27 +147,35 @@ func NewAISFileSystemServer(cfg *ServerConfig, errLog *log.Logger) (srv fuse.Ser
 		errLog: errLog,
 	}
 
-	// Initialize a bucket.
+	// Create the root inode: either the bucket or the directory of buckets.
 	apiParams := aisfs.aisAPIParams()
-	bucket, err := ais.NewBucket(cfg.BucketName, apiParams)
-	if err != nil {
-		return nil, err
+	rootAttrs := aisfs.dirAttrs(aisfs.modeBits.Directory)
+	if cfg.Bck.Name == "" {
+		aisfs.root = newBucketsRootInode(rootAttrs, newBucketsDir(cfg, apiParams, aisfs.errLog))
+	} else {
+		// Initialize a bucket.
+		bucket, err := ais.OpenBucket(cfg.Bck, apiParams)
+		if err != nil {
+			return nil, err
+		}
+		ns, err := newNamespace(bucket, aisfs.errLog, aisfs.cfg)
+		if err != nil {
+			return nil, err
+		}
+		aisfs.root = NewDirectoryInode(
+			fuseops.RootInodeID,
+			rootAttrs,
+			rootPath,
+			nil, /*parent*/
+			bucket,
+			ns,
+		).(*DirectoryInode)
 	}
-	aisfs.bck = bucket
-
-	// Create the root inode.
-	aisfs.root = NewDirectoryInode(
-		fuseops.RootInodeID,
-		aisfs.dirAttrs(aisfs.modeBits.Directory),
-		rootPath,
-		nil, /*parent*/
-		aisfs.bck,
-	).(*DirectoryInode)
 
 	aisfs.root.IncLookupCount()
 	aisfs.inodeTable[fuseops.RootInodeID] = aisfs.root
 
-	ns, err = newNamespace(bucket, aisfs.errLog, aisfs.cfg)
+	dcache, err = newDataCache(aisfs.cfg)
 	if err != nil {
 		return nil, err
 	}
@@ -276,9 +286,19 @@ func (fs *aisfs) createFileInode(inodeID fuseops.InodeID, parent *DirectoryInode
 
 // REQUIRES_LOCK(fs.mu)
 func (fs *aisfs) createDirectoryInode(inodeID fuseops.InodeID, parent *DirectoryInode, entryName string, mode os.FileMode) Inode {
-	attrs := fs.dirAttrs(mode)
-	fspath := path.Join(parent.Path(), entryName) + separator
-	inode := NewDirectoryInode(inodeID, attrs, fspath, parent, fs.bck)
+	var (
+		attrs = fs.dirAttrs(mode)
+		inode Inode
+	)
+	if parent.IsBucketsRoot() {
+		// The bucket has been mounted upon lookup (see bucketsDir.lookup).
+		mb := parent.buckets.get(entryName)
+		cmn.Assert(mb != nil)
+		inode = NewDirectoryInode(inodeID, attrs, rootPath, parent, mb.bck, mb.ns)
+	} else {
+		fspath := path.Join(parent.Path(), entryName) + separator
+		inode = NewDirectoryInode(inodeID, attrs, fspath, parent, parent.bucket, parent.ns)
+	}
 	fs.inodeTable[inodeID] = inode
 	return inode
 }
@@ -384,6 +404,9 @@ func (fs *aisfs) ForgetInode(ctx context.Context, req *fuseops.ForgetInodeOp) (e
 
 		// Remove entryName to inode ID mapping in parent.
 		name := path.Base(inode.Path())
+		if parent.IsBucketsRoot() {
+			name = inode.(*DirectoryInode).bucket.Name()
+		}
 		parent.Lock()
 		parent.InvalidateInode(name, inode.IsDir())
 		parent.Unlock()
@@ -0,0 +1,269 @@
+// Package fs implements an AIStore file system.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"container/list"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"syscall"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Data cache keeps blocks of objects' content (as read from the cluster) in
+// memory and, once the memory limit is reached, on local disk. Both tiers are
+// bounded in size and evict the least recently used blocks first: blocks
+// evicted from memory are demoted to disk, blocks read from disk are promoted
+// back to memory.
+//
+// Blocks are aligned to dataBlockSize, so that reads of any offset and size
+// share the cached blocks. Blocks are keyed by the object's version and checksum
+// (see ais.Object.CacheTag) so that a changed object never hits stale data.
+// Blocks of objects that are (re)written, deleted or changed in the cluster are
+// invalidated.
+//
+// Each process keeps its blocks on disk in a separate subdirectory (named after
+// its PID) - mounts may share the data cache directory.
+
+const (
+	dataBlockExt  = ".blk"
+	dataBlockSize = maxBlockSize
+)
+
+type (
+	dataBlockKey struct {
+		obj string // unique name of the object (see ais.Object.Uname)
+		tag string
+		idx int64 // the block starts at idx * dataBlockSize
+	}
+
+	dataBlock struct {
+		key  dataBlockKey
+		id   uint64      // unique ID - determines the name of the file on disk
+		sgl  *memsys.SGL // content of the block when in memory, nil otherwise
+		size int64
+	}
+
+	dataCache struct {
+		mu       sync.Mutex
+		cfg      *ServerConfig
+		dir      string // subdirectory of cfg.DataCacheDir
+		blocks   map[dataBlockKey]*list.Element
+		mem      *list.List // LRU: front is the most recently used
+		disk     *list.List // ditto
+		memSize  int64
+		diskSize int64
+		lastID   uint64
+	}
+)
+
+// Removes blocks left on disk by the previous mounts - by processes that
+// are no longer running.
+func newDataCache(cfg *ServerConfig) (*dataCache, error) {
+	var dir string
+	if cfg.DataCacheDir != "" {
+		if err := cmn.CreateDir(cfg.DataCacheDir); err != nil {
+			return nil, err
+		}
+		entries, err := ioutil.ReadDir(cfg.DataCacheDir)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			pid, err := strconv.Atoi(entry.Name())
+			if err != nil || !entry.IsDir() || processRunning(pid) {
+				continue
+			}
+			if err := os.RemoveAll(filepath.Join(cfg.DataCacheDir, entry.Name())); err != nil {
+				return nil, err
+			}
+		}
+		dir = filepath.Join(cfg.DataCacheDir, strconv.Itoa(os.Getpid()))
+		if err := os.RemoveAll(dir); err != nil {
+			return nil, err
+		}
+		if err := cmn.CreateDir(dir); err != nil {
+			return nil, err
+		}
+	}
+	return &dataCache{
+		cfg:    cfg,
+		dir:    dir,
+		blocks: make(map[dataBlockKey]*list.Element),
+		mem:    list.New(),
+		disk:   list.New(),
+	}, nil
+}
+
+func (c *dataCache) enabled() bool {
+	if c == nil {
+		return false
+	}
+	return c.cfg.DataCacheMemSize.Load() > 0 || (c.dir != "" && c.cfg.DataCacheDiskSize.Load() > 0)
+}
+
+func (c *dataCache) blockPath(block *dataBlock) string {
+	return filepath.Join(c.dir, strconv.FormatUint(block.id, 16)+dataBlockExt)
+}
+
+// Writes `length` bytes of the cached block (if any) starting at `off` to w.
+func (c *dataCache) get(key dataBlockKey, w io.Writer, off, length int64) (n int64, ok bool, err error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	elem, ok := c.blocks[key]
+	if !ok {
+		return 0, false, nil
+	}
+	block := elem.Value.(*dataBlock)
+	if block.sgl != nil {
+		c.mem.MoveToFront(elem)
+		n, err = writeRange(w, block.sgl, off, length)
+		return n, true, err
+	}
+	if !c.promote(elem) {
+		return 0, false, nil
+	}
+	n, err = writeRange(w, block.sgl, off, length)
+	c.evict()
+	return n, true, err
+}
+
+// Takes ownership of the sgl.
+func (c *dataCache) put(key dataBlockKey, sgl *memsys.SGL) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if _, ok := c.blocks[key]; ok {
+		// Loaded concurrently by another handle.
+		sgl.Free()
+		return
+	}
+	c.lastID++
+	block := &dataBlock{key: key, id: c.lastID, sgl: sgl, size: sgl.Size()}
+	c.blocks[key] = c.mem.PushFront(block)
+	c.memSize += block.size
+	c.evict()
+}
+
+// Removes all cached blocks of the object (given its unique name).
+func (c *dataCache) invalidate(objName string) {
+	if c == nil {
+		return
+	}
+	c.mu.Lock()
+	for key, elem := range c.blocks {
+		if key.obj == objName {
+			c.remove(elem)
+		}
+	}
+	c.mu.Unlock()
+}
+
+// REQUIRES_LOCK(c.mu)
+func (c *dataCache) evict() {
+	memLimit := c.cfg.DataCacheMemSize.Load()
+	for c.memSize > memLimit {
+		elem := c.mem.Back()
+		block := elem.Value.(*dataBlock)
+		c.mem.Remove(elem)
+		c.memSize -= block.size
+		if !c.demote(block) {
+			delete(c.blocks, block.key)
+		}
+		block.sgl.Free()
+		block.sgl = nil
+	}
+
+	diskLimit := c.cfg.DataCacheDiskSize.Load()
+	for c.diskSize > diskLimit {
+		c.remove(c.disk.Back())
+	}
+}
+
+// Saves the block on disk, returns false if it cannot be done.
+// REQUIRES_LOCK(c.mu)
+func (c *dataCache) demote(block *dataBlock) bool {
+	if c.dir == "" || block.size > c.cfg.DataCacheDiskSize.Load() {
+		return false
+	}
+	fqn := c.blockPath(block)
+	file, err := os.Create(fqn)
+	if err != nil {
+		return false
+	}
+	_, err = block.sgl.WriteTo(file)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	if err != nil {
+		os.Remove(fqn)
+		return false
+	}
+	c.blocks[block.key] = c.disk.PushFront(block)
+	c.diskSize += block.size
+	return true
+}
+
+// Loads the block from disk back to memory, returns false (and forgets the
+// block) if it cannot be done. The caller is expected to evict afterwards.
+// REQUIRES_LOCK(c.mu)
+func (c *dataCache) promote(elem *list.Element) bool {
+	block := elem.Value.(*dataBlock)
+	fqn := c.blockPath(block)
+	c.disk.Remove(elem)
+	c.diskSize -= block.size
+	delete(c.blocks, block.key)
+
+	file, err := os.Open(fqn)
+	if err != nil {
+		return false
+	}
+	sgl := glMem2.NewSGL(block.size)
+	_, err = sgl.ReadFrom(file)
+	file.Close()
+	os.Remove(fqn)
+	if err != nil || sgl.Size() != block.size {
+		sgl.Free()
+		return false
+	}
+	block.sgl = sgl
+	c.blocks[block.key] = c.mem.PushFront(block)
+	c.memSize += block.size
+	return true
+}
+
+// REQUIRES_LOCK(c.mu)
+func (c *dataCache) remove(elem *list.Element) {
+	block := elem.Value.(*dataBlock)
+	if block.sgl != nil {
+		c.mem.Remove(elem)
+		c.memSize -= block.size
+		block.sgl.Free()
+		block.sgl = nil
+	} else {
+		c.disk.Remove(elem)
+		c.diskSize -= block.size
+		os.Remove(c.blockPath(block))
+	}
+	delete(c.blocks, block.key)
+}
+
+func writeRange(w io.Writer, sgl *memsys.SGL, off, length int64) (int64, error) {
+	reader := memsys.NewReader(sgl)
+	if _, err := reader.Seek(off, io.SeekStart); err != nil {
+		return 0, err
+	}
+	return io.CopyN(w, reader, length)
+}
+
+func processRunning(pid int) bool {
+	err := syscall.Kill(pid, 0)
+	return err == nil || err == syscall.EPERM
+}
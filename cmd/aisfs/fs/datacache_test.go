@@ -0,0 +1,196 @@
+// Package fs implements an AIStore file system.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package fs
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/NVIDIA/aistore/cmn"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("DataCache", func() {
+	const blockSize = 4096
+
+	var (
+		dir   string
+		cfg   *ServerConfig
+		cache *dataCache
+	)
+
+	newBlock := func(b byte) []byte { return bytes.Repeat([]byte{b}, blockSize) }
+
+	put := func(key dataBlockKey, data []byte) {
+		sgl := glMem2.NewSGL(int64(len(data)))
+		sgl.Write(data)
+		cache.put(key, sgl)
+	}
+
+	get := func(key dataBlockKey) ([]byte, bool) {
+		buf := &bytes.Buffer{}
+		n, ok, err := cache.get(key, buf, 0, blockSize)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(n).To(BeEquivalentTo(buf.Len()))
+		return buf.Bytes(), ok
+	}
+
+	blockFiles := func() []string {
+		files, err := filepath.Glob(filepath.Join(cache.dir, "*"+dataBlockExt))
+		Expect(err).NotTo(HaveOccurred())
+		return files
+	}
+
+	key := func(obj string, idx int64) dataBlockKey {
+		return dataBlockKey{obj: obj, tag: "1/abc", idx: idx}
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "aisfs-datacache")
+		Expect(err).NotTo(HaveOccurred())
+		cfg = &ServerConfig{DataCacheDir: dir}
+		cfg.DataCacheMemSize.Store(2 * blockSize)
+		cfg.DataCacheDiskSize.Store(2 * blockSize)
+		cache, err = newDataCache(cfg)
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	It("should return cached block", func() {
+		put(key("a", 0), newBlock('a'))
+		data, ok := get(key("a", 0))
+		Expect(ok).To(BeTrue())
+		Expect(data).To(Equal(newBlock('a')))
+
+		_, ok = get(key("a", 1))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should not return block with different tag", func() {
+		put(key("a", 0), newBlock('a'))
+		k := key("a", 0)
+		k.tag = "2/def"
+		_, ok := get(k)
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should demote blocks to disk and promote them back", func() {
+		put(key("a", 0), newBlock('a'))
+		put(key("b", 0), newBlock('b'))
+		put(key("c", 0), newBlock('c'))
+		Expect(cache.memSize).To(BeEquivalentTo(2 * blockSize))
+		Expect(cache.diskSize).To(BeEquivalentTo(blockSize))
+		Expect(blockFiles()).To(HaveLen(1))
+
+		// The least recently used block ("a") is on disk.
+		data, ok := get(key("a", 0))
+		Expect(ok).To(BeTrue())
+		Expect(data).To(Equal(newBlock('a')))
+
+		// ...and now it is "b".
+		Expect(cache.blocks[key("b", 0)].Value.(*dataBlock).sgl).To(BeNil())
+		Expect(cache.memSize).To(BeEquivalentTo(2 * blockSize))
+		Expect(cache.diskSize).To(BeEquivalentTo(blockSize))
+		Expect(blockFiles()).To(HaveLen(1))
+	})
+
+	It("should evict blocks when disk limit is reached", func() {
+		for i := int64(0); i < 6; i++ {
+			put(key("a", i), newBlock('a'))
+		}
+		Expect(cache.blocks).To(HaveLen(4))
+		Expect(blockFiles()).To(HaveLen(2))
+		_, ok := get(key("a", 0))
+		Expect(ok).To(BeFalse())
+		_, ok = get(key("a", 5))
+		Expect(ok).To(BeTrue())
+	})
+
+	It("should not use disk when disk size is zero", func() {
+		cfg.DataCacheDiskSize.Store(0)
+		for i := int64(0); i < 3; i++ {
+			put(key("a", i), newBlock('a'))
+		}
+		Expect(cache.blocks).To(HaveLen(2))
+		Expect(blockFiles()).To(BeEmpty())
+	})
+
+	It("should invalidate all blocks of the object", func() {
+		put(key("a", 0), newBlock('a'))
+		put(key("a", 1), newBlock('a'))
+		put(key("b", 0), newBlock('b'))
+		put(key("a", 2), newBlock('a'))
+		Expect(blockFiles()).To(HaveLen(2))
+
+		cache.invalidate("a")
+		Expect(cache.blocks).To(HaveLen(1))
+		Expect(cache.memSize).To(BeEquivalentTo(blockSize))
+		Expect(cache.diskSize).To(BeEquivalentTo(0))
+		Expect(blockFiles()).To(BeEmpty())
+		data, ok := get(key("b", 0))
+		Expect(ok).To(BeTrue())
+		Expect(data).To(Equal(newBlock('b')))
+	})
+
+	It("should remove blocks left by previous mount", func() {
+		for i := int64(0); i < 4; i++ {
+			put(key("a", i), newBlock('a'))
+		}
+		Expect(blockFiles()).To(HaveLen(2))
+		other := filepath.Join(dir, "other")
+		Expect(ioutil.WriteFile(other, []byte("data"), cmn.PermRWR)).To(Succeed())
+
+		_, err := newDataCache(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(blockFiles()).To(BeEmpty())
+		Expect(other).To(BeAnExistingFile())
+	})
+
+	It("should keep blocks of other running mounts", func() {
+		// NOTE: PIDs never exceed 2^22 (Linux)
+		dead := filepath.Join(dir, strconv.Itoa(1<<22+1))
+		running := filepath.Join(dir, strconv.Itoa(os.Getppid()))
+		for _, d := range []string{dead, running} {
+			Expect(cmn.CreateDir(d)).To(Succeed())
+			Expect(ioutil.WriteFile(filepath.Join(d, "1"+dataBlockExt), []byte("data"), cmn.PermRWR)).To(Succeed())
+		}
+
+		_, err := newDataCache(cfg)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(dead).NotTo(BeADirectory())
+		Expect(filepath.Join(running, "1"+dataBlockExt)).To(BeAnExistingFile())
+	})
+
+	It("should return a range of the block", func() {
+		data := append(newBlock('a'), newBlock('b')...)
+		put(key("a", 0), data)
+		buf := &bytes.Buffer{}
+		n, ok, err := cache.get(key("a", 0), buf, blockSize-10, 20)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(n).To(BeEquivalentTo(20))
+		Expect(buf.Bytes()).To(Equal(data[blockSize-10 : blockSize+10]))
+	})
+
+	It("should be disabled when nil or without limits", func() {
+		var nilCache *dataCache
+		Expect(nilCache.enabled()).To(BeFalse())
+		nilCache.invalidate("a")
+
+		Expect(cache.enabled()).To(BeTrue())
+		cfg.DataCacheMemSize.Store(0)
+		Expect(cache.enabled()).To(BeTrue())
+		cfg.DataCacheDiskSize.Store(0)
+		Expect(cache.enabled()).To(BeFalse())
+	})
+})
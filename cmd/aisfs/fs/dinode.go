@@ -25,15 +25,28 @@ type DirectoryInode struct {
 
 	parent *DirectoryInode
 	bucket ais.Bucket
+	ns     *namespace
+
+	// Set only for the root directory when mounting multiple buckets
+	// (in which case bucket and ns are not set).
+	buckets *bucketsDir
 
 	entries []fuseutil.Dirent
 }
 
-func NewDirectoryInode(id fuseops.InodeID, attrs fuseops.InodeAttributes, path string, parent *DirectoryInode, bucket ais.Bucket) Inode {
+func NewDirectoryInode(id fuseops.InodeID, attrs fuseops.InodeAttributes, path string, parent *DirectoryInode, bucket ais.Bucket, ns *namespace) Inode {
 	return &DirectoryInode{
 		baseInode: newBaseInode(id, attrs, path),
 		parent:    parent,
 		bucket:    bucket,
+		ns:        ns,
+	}
+}
+
+func newBucketsRootInode(attrs fuseops.InodeAttributes, buckets *bucketsDir) *DirectoryInode {
+	return &DirectoryInode{
+		baseInode: newBaseInode(fuseops.RootInodeID, attrs, rootPath),
+		buckets:   buckets,
 	}
 }
 
@@ -45,6 +58,11 @@ func (dir *DirectoryInode) IsDir() bool {
 	return true
 }
 
+// IsBucketsRoot returns true if directory lists buckets (rather than objects).
+func (dir *DirectoryInode) IsBucketsRoot() bool {
+	return dir.buckets != nil
+}
+
 // REQUIRES_LOCK(dir)
 func (dir *DirectoryInode) UpdateAttributes(req *AttrUpdateReq) fuseops.InodeAttributes {
 	attrs := dir.Attributes()
@@ -59,7 +77,7 @@ func (dir *DirectoryInode) UpdateAttributes(req *AttrUpdateReq) fuseops.InodeAtt
 // REQUIRES_LOCK(dir)
 func (dir *DirectoryInode) NewFileEntry(entryName string, id fuseops.InodeID, object *ais.Object) {
 	entryName = path.Join(dir.Path(), entryName)
-	ns.add(entryFileTy, dtAttrs{id: id, path: entryName, obj: object})
+	dir.ns.add(entryFileTy, dtAttrs{id: id, path: entryName, obj: object})
 
 	// TODO: improve caching entries for `ReadEntries`
 	dir.entries = nil
@@ -68,7 +86,7 @@ func (dir *DirectoryInode) NewFileEntry(entryName string, id fuseops.InodeID, ob
 // REQUIRES_LOCK(dir)
 func (dir *DirectoryInode) ForgetFile(entryName string) {
 	entryName = path.Join(dir.Path(), entryName)
-	ns.remove(entryName)
+	dir.ns.remove(entryName)
 
 	// TODO: improve caching entries for `ReadEntries`
 	dir.entries = nil
@@ -76,8 +94,13 @@ func (dir *DirectoryInode) ForgetFile(entryName string) {
 
 // REQUIRES_LOCK(dir)
 func (dir *DirectoryInode) NewDirEntry(entryName string, id fuseops.InodeID) {
+	if dir.IsBucketsRoot() {
+		dir.buckets.setID(entryName, id)
+		dir.entries = nil
+		return
+	}
 	entryName = path.Join(dir.Path(), entryName) + separator
-	ns.add(entryDirTy, dtAttrs{id: id, path: entryName})
+	dir.ns.add(entryDirTy, dtAttrs{id: id, path: entryName})
 
 	// TODO: improve caching entries for `ReadEntries`
 	dir.entries = nil
@@ -85,25 +108,35 @@ func (dir *DirectoryInode) NewDirEntry(entryName string, id fuseops.InodeID) {
 
 // REQUIRES_LOCK(dir)
 func (dir *DirectoryInode) ForgetDir(entryName string) {
+	if dir.IsBucketsRoot() {
+		dir.buckets.forget(entryName)
+		dir.entries = nil
+		return
+	}
 	entryName = path.Join(dir.Path(), entryName) + separator
-	ns.remove(entryName)
+	dir.ns.remove(entryName)
 
 	// TODO: improve caching entries for `ReadEntries`
 	dir.entries = nil
 }
 
 func (dir *DirectoryInode) InvalidateInode(entryName string, isDir bool) {
+	if dir.IsBucketsRoot() {
+		dir.buckets.setID(entryName, invalidInodeID)
+		dir.entries = nil
+		return
+	}
 	entryName = path.Join(dir.Path(), entryName)
 	ty := entryFileTy
 	if isDir {
 		entryName += separator
 		ty = entryDirTy
 	}
-	_, exists := ns.lookup(entryName)
+	_, exists := dir.ns.lookup(entryName)
 	if !exists {
 		return
 	}
-	ns.add(ty, dtAttrs{id: invalidInodeID, path: entryName})
+	dir.ns.add(ty, dtAttrs{id: invalidInodeID, path: entryName})
 }
 
 func (dir *DirectoryInode) LinkNewFile(fileName string) (*ais.Object, error) {
@@ -117,8 +150,15 @@ func (dir *DirectoryInode) LinkNewFile(fileName string) (*ais.Object, error) {
 
 // REQUIRES_LOCK(dir)
 func (dir *DirectoryInode) ReadEntries() (entries []fuseutil.Dirent, err error) {
+	if dir.IsBucketsRoot() {
+		if dir.entries == nil {
+			dir.entries, err = dir.buckets.listEntries()
+		}
+		return dir.entries, err
+	}
+
 	// Traverse files and subdirectories of dir read from the bucket.
-	_, exists := ns.lookup(dir.Path())
+	_, exists := dir.ns.lookup(dir.Path())
 	if !exists {
 		return nil, fuse.ENOENT
 	}
@@ -128,7 +168,7 @@ func (dir *DirectoryInode) ReadEntries() (entries []fuseutil.Dirent, err error)
 	}
 
 	var offset fuseops.DirOffset = 1
-	ns.listEntries(dir.Path(), func(child nsEntry) {
+	dir.ns.listEntries(dir.Path(), func(child nsEntry) {
 		dir.entries = append(dir.entries, fuseutil.Dirent{
 			Inode:  child.ID(),
 			Offset: offset,
@@ -146,6 +186,7 @@ func (dir *DirectoryInode) UnlinkEntry(entryName string) error {
 	if err := dir.bucket.DeleteObject(objName); err != nil {
 		return err
 	}
+	dcache.invalidate(dir.bucket.Bck().MakeUname(objName))
 
 	dir.Lock()
 	dir.ForgetFile(entryName)
@@ -160,12 +201,16 @@ func (dir *DirectoryInode) LookupEntry(entryName string) (res EntryLookupResult)
 		dirEntryName = objEntryName + separator
 	)
 
+	if dir.IsBucketsRoot() {
+		return dir.buckets.lookup(entryName)
+	}
+
 	// First check for directories
-	res, exists = ns.lookup(dirEntryName)
+	res, exists = dir.ns.lookup(dirEntryName)
 	if exists {
 		return res
 	}
 
-	res, _ = ns.lookup(objEntryName)
+	res, _ = dir.ns.lookup(objEntryName)
 	return res
 }
@@ -13,6 +13,16 @@ import (
 )
 
 func (fs *aisfs) OpenDir(ctx context.Context, req *fuseops.OpenDirOp) (err error) {
+	fs.mu.RLock()
+	dir := fs.lookupDirMustExist(req.Inode)
+	fs.mu.RUnlock()
+
+	// Buckets can be created and destroyed by others - list them anew.
+	if dir.IsBucketsRoot() {
+		dir.Lock()
+		dir.entries = nil
+		dir.Unlock()
+	}
 	return
 }
 
@@ -61,6 +71,12 @@ func (fs *aisfs) MkDir(ctx context.Context, req *fuseops.MkDirOp) (err error) {
 		return fuse.EEXIST
 	}
 
+	if parent.IsBucketsRoot() {
+		if err = parent.buckets.create(req.Name); err != nil {
+			return fs.handleIOError(err)
+		}
+	}
+
 	fs.mu.Lock()
 	inodeID := fs.nextInodeID()
 	newDir = fs.createDirectoryInode(inodeID, parent, req.Name, req.Mode)
@@ -110,6 +126,11 @@ func (fs *aisfs) RmDir(ctx context.Context, req *fuseops.RmDirOp) (err error) {
 	if len(entries) > 0 {
 		return fuse.ENOTEMPTY
 	}
+	if parent.IsBucketsRoot() {
+		if err = parent.buckets.destroy(req.Name); err != nil {
+			return fs.handleIOError(err)
+		}
+	}
 	parent.ForgetDir(req.Name)
 	return
 }
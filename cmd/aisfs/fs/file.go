@@ -36,6 +36,11 @@ func (fs *aisfs) CreateFile(ctx context.Context, req *fuseops.CreateFileOp) (err
 	parent := fs.lookupDirMustExist(req.Parent)
 	fs.mu.RUnlock()
 
+	// Only buckets (directories) can be created in the root of multiple buckets.
+	if parent.IsBucketsRoot() {
+		return syscall.EPERM
+	}
+
 	fileName := path.Join(parent.Path(), req.Name)
 	object, err := parent.LinkNewFile(fileName)
 	if err != nil {
@@ -88,6 +88,9 @@ func (file *FileInode) UpdateBackingObject(obj *ais.Object) {
 	if file.object.Atime.After(obj.Atime) {
 		return
 	}
+	if tag := file.object.CacheTag(); tag != "" && tag != obj.CacheTag() {
+		dcache.invalidate(obj.Uname())
+	}
 
 	size := uint64(obj.Size)
 	updReq := &AttrUpdateReq{
@@ -103,13 +106,63 @@ func (file *FileInode) UpdateBackingObject(obj *ais.Object) {
 
 // REQUIRES_READ_LOCK(file)
 func (file *FileInode) Load(w io.Writer, offset, length int64) (n int64, err error) {
-	n, err = file.object.GetChunk(w, offset, length)
+	if tag := file.object.CacheTag(); tag != "" && dcache.enabled() {
+		n, err = file.loadCached(w, tag, offset, length)
+	} else {
+		n, err = file.object.GetChunk(w, offset, length)
+	}
 	if err != nil {
 		return 0, err
 	}
-	now := time.Now()
-	file.attrs.Atime = now
-	file.object.Atime = now
+	// NOTE: object's atime is not updated - it tells how recent the object's
+	// metadata is (see UpdateBackingObject).
+	file.attrs.Atime = time.Now()
+	return n, nil
+}
+
+// Reads the range block by block (see dataBlockSize); the range is trimmed
+// to the object's size.
+func (file *FileInode) loadCached(w io.Writer, tag string, offset, length int64) (n int64, err error) {
+	length = cmn.MinI64(length, file.object.Size-offset)
+	for length > 0 {
+		var (
+			key     = dataBlockKey{obj: file.object.Uname(), tag: tag, idx: offset / dataBlockSize}
+			off     = offset % dataBlockSize
+			cnt     = cmn.MinI64(length, dataBlockSize-off)
+			written int64
+		)
+		written, err = file.loadBlock(w, key, off, cnt)
+		n += written
+		if err != nil {
+			return
+		}
+		offset += cnt
+		length -= cnt
+	}
+	return
+}
+
+func (file *FileInode) loadBlock(w io.Writer, key dataBlockKey, off, length int64) (int64, error) {
+	n, ok, err := dcache.get(key, w, off, length)
+	if ok {
+		return n, err
+	}
+	var (
+		start = key.idx * dataBlockSize
+		size  = cmn.MinI64(dataBlockSize, file.object.Size-start)
+		sgl   = glMem2.NewSGL(size)
+	)
+	if _, err := file.object.GetChunk(sgl, start, size); err != nil {
+		sgl.Free()
+		return 0, err
+	}
+	n, err = writeRange(w, sgl, off, length)
+	if err != nil || sgl.Size() != size {
+		// the object has changed in the meantime
+		sgl.Free()
+		return n, err
+	}
+	dcache.put(key, sgl)
 	return n, nil
 }
 
@@ -133,6 +186,10 @@ func (file *FileInode) Flush(handle string, cksum *cmn.Cksum) error {
 	if err != nil {
 		return err
 	}
+	// The content has changed and its new version is not known until the
+	// next sync - stop caching it until then.
+	file.object.Version, file.object.Checksum = "", ""
+	dcache.invalidate(file.object.Uname())
 	now := time.Now()
 	file.object.Atime = now
 	file.attrs.Atime = now
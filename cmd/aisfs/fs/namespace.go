@@ -63,6 +63,9 @@ type (
 		// In case we do have all objects in memory we can enable some of the
 		// performance improvements.
 		cacheHasAllObjects atomic.Bool
+
+		// Stops syncing with AIS (when the bucket is no longer mounted).
+		stopCh chan struct{}
 	}
 )
 
@@ -85,9 +88,10 @@ func newNamespace(bck ais.Bucket, logger *log.Logger, cfg *ServerConfig) (*names
 	}
 
 	ns := &namespace{
-		bck:   bck,
-		cfg:   cfg,
-		cache: nsCache,
+		bck:    bck,
+		cfg:    cfg,
+		cache:  nsCache,
+		stopCh: make(chan struct{}),
 	}
 	ns.cacheHasAllObjects.Store(hasAllObjects)
 
@@ -103,8 +107,12 @@ func newNamespace(bck ais.Bucket, logger *log.Logger, cfg *ServerConfig) (*names
 				return
 			}
 
-			time.Sleep(interval)
-			logger.Printf("syncing with AIS...")
+			select {
+			case <-time.After(interval):
+			case <-ns.stopCh:
+				return
+			}
+			logger.Printf("syncing %s with AIS...", bck.Bck())
 			if hasAllObjects, err := ns.cache.refresh(); err != nil {
 				logger.Printf("failed to sync, err: %v", err)
 			} else {
@@ -116,6 +124,10 @@ func newNamespace(bck ais.Bucket, logger *log.Logger, cfg *ServerConfig) (*names
 	return ns, nil
 }
 
+func (ns *namespace) stop() {
+	close(ns.stopCh)
+}
+
 func (ns *namespace) add(ty entryType, dta dtAttrs) {
 	if !ns.cacheHasAllObjects.Load() {
 		// TODO: maybe we have enough memory to store this given entry - we should
@@ -36,7 +36,7 @@ USAGE:
 	{{ .Name }} [OPTION...] BUCKET MOUNTPOINT
 
 ARGUMENTS:
-	BUCKET      bucket name
+	BUCKET      bucket name, or provider to mount all its buckets (e.g. ais://, ais://#namespace)
 	MOUNTPOINT  empty directory for mounting the file system
 
 OPTIONS: (must appear before arguments, see USAGE)
@@ -90,7 +90,7 @@ func runDaemon(mountPath string, errorSink io.Writer) (err error) {
 	return
 }
 
-func dispatchSignalHandlers(mountPath string, mntCfg *fuse.MountConfig, serverCfg *fs.ServerConfig) {
+func dispatchSignalHandlers(mountPath, name string, mntCfg *fuse.MountConfig, serverCfg *fs.ServerConfig) {
 	signalCh := make(chan os.Signal, 1)
 	signal.Notify(signalCh, os.Interrupt, syscall.SIGHUP)
 	go func() {
@@ -108,7 +108,7 @@ func dispatchSignalHandlers(mountPath string, mntCfg *fuse.MountConfig, serverCf
 				}
 				mntCfg.ErrorLogger.Printf("failed to unmount upon SIGINT: %v", err)
 			case syscall.SIGHUP:
-				cfg, err := loadConfig(serverCfg.BucketName)
+				cfg, err := loadConfig(name)
 				if err != nil {
 					mntCfg.ErrorLogger.Printf("failed to reload config upon SIGHUP: %v", err)
 					break
@@ -179,7 +179,8 @@ func appMain(c *cli.Context) (err error) {
 		flags     *flags
 		cfg       *Config
 		cluURL    string
-		bucket    string
+		bck       cmn.Bck
+		name      string
 		mountDir  string
 		mountPath string
 		errorLog  *log.Logger
@@ -207,7 +208,10 @@ func appMain(c *cli.Context) (err error) {
 	}
 
 	flags = parseFlags(c)
-	bucket = c.Args().Get(0)
+	if bck, err = parseBucketArg(c.Args().Get(0)); err != nil {
+		return
+	}
+	name = mountName(bck)
 	mountDir = c.Args().Get(1)
 
 	mountPath, err = filepath.Abs(mountDir)
@@ -243,40 +247,45 @@ func appMain(c *cli.Context) (err error) {
 	}
 
 	// Try to load existing config from file or use default one.
-	cfg, err = loadConfig(bucket)
+	cfg, err = loadConfig(name)
 	if err != nil {
 		return
 	}
 
 	// Validate and test cluster URL.
-	cluURL, err = determineClusterURL(c, cfg, cmn.Bck{Name: bucket})
+	cluURL, err = determineClusterURL(c, cfg, bck)
 	if err != nil {
 		return
 	}
 
-	errorLog, err = prepareLogFile(cfg.Log.ErrorFile, "ERROR: ", bucket)
+	errorLog, err = prepareLogFile(cfg.Log.ErrorFile, "ERROR: ", name)
 	if err != nil {
 		return
 	}
 
 	// If cfg.Log.DebugFile == "" no debug logging is performed.
 	if cfg.Log.DebugFile != "" {
-		debugLog, err = prepareLogFile(cfg.Log.DebugFile, "DEBUG: ", bucket)
+		debugLog, err = prepareLogFile(cfg.Log.DebugFile, "DEBUG: ", name)
 		if err != nil {
 			return
 		}
 	}
 
 	// Useful message describing some fs params, printed only if --wait flag was given by the user.
-	fmt.Fprintf(c.App.Writer, "Connecting to proxy at %q\nMounting bucket %q to %q\nuid %d\ngid %d\n",
-		cluURL, bucket, mountPath, fsowner.UID, fsowner.GID)
+	what := fmt.Sprintf("bucket %q", bck)
+	if bck.Name == "" {
+		what = fmt.Sprintf("buckets %q", bck)
+	}
+	fmt.Fprintf(c.App.Writer, "Connecting to proxy at %q\nMounting %s to %q\nuid %d\ngid %d\n",
+		cluURL, what, mountPath, fsowner.UID, fsowner.GID)
 
 	// Init a server configuration object.
 	serverCfg := &fs.ServerConfig{
-		MountPath:  mountPath,
-		AISURL:     cluURL,
-		BucketName: bucket,
-		Owner:      fsowner,
+		MountPath:    mountPath,
+		AISURL:       cluURL,
+		Bck:          bck,
+		Owner:        fsowner,
+		DataCacheDir: cfg.dataCacheDir(name),
 	}
 	cfg.writeTo(serverCfg)
 
@@ -306,7 +315,7 @@ func appMain(c *cli.Context) (err error) {
 
 	// Start signal dispatcher which catches different signals and reacts upon
 	// receiving them.
-	dispatchSignalHandlers(mountPath, mountCfg, serverCfg)
+	dispatchSignalHandlers(mountPath, name, mountCfg, serverCfg)
 
 	// Wait for the file system to be unmounted.
 	err = mfs.Join(context.Background())
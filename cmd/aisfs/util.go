@@ -99,10 +99,48 @@ func tryAccessBucket(url string, bck cmn.Bck) bool {
 		URL:    url,
 	}
 
+	if bck.Name == "" {
+		_, err := api.ListBuckets(baseParams, cmn.QueryBcks(bck))
+		return err == nil
+	}
 	_, err := api.HeadBucket(baseParams, bck)
 	return err == nil
 }
 
+// Parses BUCKET argument: either a bucket (e.g. "mybucket", "aws://mybucket")
+// or a provider, optionally with a namespace (e.g. "ais://", "ais://#ns"),
+// to mount all buckets of the provider.
+func parseBucketArg(arg string) (bck cmn.Bck, err error) {
+	bck, objName, err := cmn.ParseBckObjectURI(arg, true /*query*/)
+	if err != nil {
+		return bck, incorrectUsageError(err)
+	}
+	if objName != "" {
+		return bck, incorrectUsageError(fmt.Errorf("invalid BUCKET %q: unexpected object name %q", arg, objName))
+	}
+	if bck.Name != "" {
+		return bck, nil
+	}
+	if bck.Provider == "" {
+		return bck, incorrectUsageError(fmt.Errorf("invalid BUCKET %q: expecting bucket name or provider (e.g. \"ais://\")", arg))
+	}
+	if bck.Ns.IsAnyRemote() {
+		return bck, incorrectUsageError(fmt.Errorf("invalid BUCKET %q: expecting specific namespace", arg))
+	}
+	return bck, nil
+}
+
+// Name of the mount, used to name its config and log files.
+func mountName(bck cmn.Bck) string {
+	if bck.Name != "" {
+		return bck.Name
+	}
+	if bck.Ns.IsGlobal() {
+		return bck.Provider
+	}
+	return bck.Provider + "_" + bck.Ns.Uname()
+}
+
 //////////////////
 // ERROR HANDLING
 //////////////////
@@ -12,6 +12,7 @@ import (
 	"os/signal"
 	"path/filepath"
 	"syscall"
+	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/cmn"
@@ -86,6 +87,8 @@ func main() {
 		cmn.ExitLogf("Failed to set up logger: %v", err)
 	}
 
+	cmn.InitShortID(uint64(time.Now().UnixNano())) // to generate S3 key IDs
+
 	dbPath := filepath.Join(conf.ConfDir, authDB)
 	driver, err := dbdriver.NewBuntDB(dbPath)
 	if err != nil {
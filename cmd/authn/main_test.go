@@ -23,6 +23,7 @@ var (
 )
 
 func init() {
+	cmn.InitShortID(0)
 	// Set default expiration time to 30 minutes
 	if conf.Auth.ExpirePeriod == 0 {
 		conf.Auth.ExpirePeriod = time.Minute * 30
@@ -168,3 +169,72 @@ func TestToken(t *testing.T) {
 		t.Errorf("Invalid error(must be 'token expired'): %v", err)
 	}
 }
+
+func TestS3Creds(t *testing.T) {
+	secret := conf.Auth.Secret
+	driver := dbdriver.NewDBMock()
+	mgr, err := newUserManager(driver)
+	tassert.CheckError(t, err)
+	createUsers(mgr, t)
+	defer deleteUsers(mgr, true, t)
+
+	accessKey, secretKey, err := mgr.issueS3Creds(users[0], passs[0])
+	tassert.CheckFatal(t, err)
+	info, err := cmn.DecryptToken(accessKey, secret)
+	tassert.CheckFatal(t, err)
+	if info.UserID != users[0] || !info.S3 || info.KeyID == "" {
+		t.Errorf("Invalid S3 access key issued for %s: %+v", users[0], info)
+	}
+	if len(info.Clusters) != 0 || len(info.Buckets) != 0 || info.IsAdmin {
+		t.Errorf("S3 access key must not carry permissions: %+v", info)
+	}
+	if secretKey == "" || secretKey != cmn.S3SecretKey(accessKey, secret) {
+		t.Errorf("Invalid S3 secret key issued for %s", users[0])
+	}
+
+	_, _, err = mgr.issueS3Creds(users[0], passs[1])
+	if err == nil {
+		t.Errorf("S3 credentials generated for incorrect user creds")
+	}
+
+	// the key is stored, and its owner's permissions are pushed to clusters
+	keys, err := mgr.s3KeyList(users[0])
+	tassert.CheckFatal(t, err)
+	if len(keys) != 1 || keys[0].ID != info.KeyID || keys[0].AccessKey != "" {
+		t.Errorf("Invalid S3 key list for %s: %+v", users[0], keys)
+	}
+	s3Users, err := mgr.s3Users()
+	tassert.CheckFatal(t, err)
+	if len(s3Users) != 1 || s3Users[0].UserID != users[0] {
+		t.Errorf("Invalid S3 users: %+v", s3Users)
+	}
+
+	// revoke by key ID
+	tassert.CheckFatal(t, mgr.revokeS3Key(info.KeyID))
+	keys, err = mgr.s3KeyList("")
+	tassert.CheckFatal(t, err)
+	if len(keys) != 0 {
+		t.Errorf("S3 key %q was not revoked: %+v", info.KeyID, keys)
+	}
+	if _, err := mgr.db.GetString(revokedCollection, accessKey); err != nil {
+		t.Errorf("S3 access key %q is not in the revoked list", info.KeyID)
+	}
+	s3Users, err = mgr.s3Users()
+	tassert.CheckFatal(t, err)
+	if len(s3Users) != 0 {
+		t.Errorf("Invalid S3 users after revoking the key: %+v", s3Users)
+	}
+	if err := mgr.revokeS3Key(info.KeyID); err == nil {
+		t.Errorf("S3 key %q revoked twice", info.KeyID)
+	}
+
+	// deleting a user removes the user's keys
+	_, _, err = mgr.issueS3Creds(users[1], passs[1])
+	tassert.CheckFatal(t, err)
+	tassert.CheckFatal(t, mgr.delUser(users[1]))
+	keys, err = mgr.s3KeyList(users[1])
+	tassert.CheckFatal(t, err)
+	if len(keys) != 0 {
+		t.Errorf("S3 keys of deleted user %s: %+v", users[1], keys)
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"github.com/NVIDIA/aistore/3rdparty/glog"
 	"github.com/NVIDIA/aistore/ais"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/dgrijalva/jwt-go"
 )
 
 // update list of revoked token on all clusters
@@ -23,6 +24,20 @@ func (m *userManager) broadcastRevoked(token string) {
 	m.broadcast(http.MethodDelete, cmn.Tokens, body)
 }
 
+// push the current permissions of S3 users to all clusters
+func (m *userManager) broadcastS3Users() {
+	m.s3mtx.Lock()
+	defer m.s3mtx.Unlock()
+	users, err := m.s3Users()
+	if err != nil {
+		glog.Errorf("Failed to read S3 users: %v", err)
+		return
+	}
+	tokenList := ais.TokenList{S3Users: users}
+	body := cmn.MustMarshal(tokenList)
+	m.broadcast(http.MethodPut, cmn.Tokens, body)
+}
+
 // broadcast the request to all clusters. If a cluster has a few URLS,
 // it sends to the first working one. Clusters are processed in parallel.
 func (m *userManager) broadcast(method, path string, body []byte) {
@@ -31,6 +46,16 @@ func (m *userManager) broadcast(method, path string, body []byte) {
 		glog.Errorf("Failed to read cluster list: %v", err)
 		return
 	}
+	// clusters accept pushed data only from an administrator
+	token, err := signToken(jwt.MapClaims{
+		"expires":  time.Now().Add(proxyTimeout),
+		"username": adminID,
+		"admin":    true,
+	})
+	if err != nil {
+		glog.Error(err)
+		return
+	}
 	wg := &sync.WaitGroup{}
 	for _, clu := range cluList {
 		wg.Add(1)
@@ -38,12 +63,12 @@ func (m *userManager) broadcast(method, path string, body []byte) {
 			defer wg.Done()
 			var err error
 			for _, u := range clu.URLs {
-				if err = m.proxyRequest(method, u, path, body); err == nil {
+				if err = m.proxyRequest(method, u, path, body, token); err == nil {
 					break
 				}
 			}
 			if err != nil {
-				glog.Errorf("Failed to sync revoked tokens with %q: %v", clu.ID, err)
+				glog.Errorf("Failed to sync tokens with %q: %v", clu.ID, err)
 			}
 		}(clu)
 	}
@@ -51,7 +76,7 @@ func (m *userManager) broadcast(method, path string, body []byte) {
 }
 
 // Generic function to send everything to a proxy
-func (m *userManager) proxyRequest(method, proxyURL, path string, injson []byte) error {
+func (m *userManager) proxyRequest(method, proxyURL, path string, injson []byte, token string) error {
 	startRequest := time.Now()
 	for {
 		url := proxyURL + cmn.JoinWords(cmn.Version, path)
@@ -65,6 +90,7 @@ func (m *userManager) proxyRequest(method, proxyURL, path string, injson []byte)
 			client = m.clientHTTPS
 		}
 		request.Header.Set(cmn.HeaderContentType, cmn.ContentJSON)
+		request.Header.Set(cmn.HeaderAuthorization, cmn.MakeHeaderAuthnToken(token))
 		response, err := client.Do(request)
 		var respCode int
 		if response != nil {
@@ -21,6 +21,13 @@ import (
 //	Returns: <tokenMsg>
 type loginMsg struct {
 	Password string `json:"password"`
+	S3       bool   `json:"s3,omitempty"` // true: generate S3 access and secret keys instead of token
+}
+
+// S3 credentials generated by AuthN
+type s3CredsMsg struct {
+	AccessKey string `json:"access_key"`
+	SecretKey string `json:"secret_key"`
 }
 
 // a message to test token validity and to revoke existing token
@@ -102,6 +109,7 @@ func (a *authServ) registerPublicHandlers() {
 	a.registerHandler(cmn.URLPathTokens.S, a.tokenHandler)
 	a.registerHandler(cmn.URLPathClusters.S, a.clusterHandler)
 	a.registerHandler(cmn.URLPathRoles.S, a.roleHandler)
+	a.registerHandler(cmn.URLPathS3Keys.S, a.s3KeyHandler)
 }
 
 func (a *authServ) userHandler(w http.ResponseWriter, r *http.Request) {
@@ -143,6 +151,56 @@ func (a *authServ) clusterHandler(w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func (a *authServ) s3KeyHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		a.httpS3KeyGet(w, r)
+	case http.MethodDelete:
+		a.httpS3KeyDel(w, r)
+	default:
+		cmn.InvalidHandlerWithMsg(w, r, "Unsupported method for /s3keys handler")
+	}
+}
+
+// Returns the list of S3 keys: of all users or of a given one
+//	GET <version>/<pathS3Keys>[/<userID>]
+func (a *authServ) httpS3KeyGet(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := checkRESTItems(w, r, 0, cmn.URLPathS3Keys.L)
+	if err != nil {
+		return
+	}
+	if err = a.checkAuthorization(w, r); err != nil {
+		glog.Errorf("Not authorized: %v\n", err)
+		return
+	}
+	var userID string
+	if len(apiItems) != 0 {
+		userID = apiItems[0]
+	}
+	keys, err := a.users.s3KeyList(userID)
+	if err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, err.Error())
+		return
+	}
+	a.writeJSON(w, keys, "list S3 keys")
+}
+
+// Revokes S3 key by its ID
+//	DELETE <version>/<pathS3Keys>/<keyID>
+func (a *authServ) httpS3KeyDel(w http.ResponseWriter, r *http.Request) {
+	apiItems, err := checkRESTItems(w, r, 1, cmn.URLPathS3Keys.L)
+	if err != nil {
+		return
+	}
+	if err = a.checkAuthorization(w, r); err != nil {
+		glog.Errorf("Not authorized: %v\n", err)
+		return
+	}
+	if err := a.users.revokeS3Key(apiItems[0]); err != nil {
+		cmn.InvalidHandlerWithMsg(w, r, err.Error(), http.StatusNotFound)
+	}
+}
+
 // Deletes existing token, a.k.a log out
 func (a *authServ) httpRevokeToken(w http.ResponseWriter, r *http.Request) {
 	if _, err := checkRESTItems(w, r, 0, cmn.URLPathTokens.L); err != nil {
@@ -302,6 +360,17 @@ func (a *authServ) userLogin(w http.ResponseWriter, r *http.Request) {
 		glog.Infof("User: %s, pass: %s\n", userID, pass)
 	}
 
+	if msg.S3 {
+		accessKey, secretKey, err := a.users.issueS3Creds(userID, pass)
+		if err != nil {
+			glog.Errorf("Failed to generate S3 credentials: %v\n", err)
+			cmn.InvalidHandlerWithMsg(w, r, "Not authorized", http.StatusUnauthorized)
+			return
+		}
+		a.writeJSON(w, &s3CredsMsg{AccessKey: accessKey, SecretKey: secretKey}, "auth")
+		return
+	}
+
 	tokenString, err := a.users.issueToken(userID, pass)
 	if err != nil {
 		glog.Errorf("Failed to generate token: %v\n", err)
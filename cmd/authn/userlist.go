@@ -10,6 +10,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sync"
 	"time"
 
 	"github.com/NVIDIA/aistore/3rdparty/glog"
@@ -26,6 +27,7 @@ const (
 	rolesCollection    = "role"
 	revokedCollection  = "revoked"
 	clustersCollection = "cluster"
+	s3KeysCollection   = "s3key"
 
 	adminID   = "admin"
 	adminPass = "admin"
@@ -40,6 +42,7 @@ type (
 		clientHTTP  *http.Client
 		clientHTTPS *http.Client
 		db          dbdriver.Driver
+		s3mtx       sync.Mutex // serializes pushing S3 users to clusters
 	}
 )
 
@@ -197,6 +200,7 @@ func (m *userManager) addCluster(info *cmn.AuthCluster) error {
 		return err
 	}
 	m.createRolesForCluster(info)
+	go m.broadcastS3Users()
 	return nil
 }
 
@@ -205,7 +209,20 @@ func (m *userManager) delUser(userID string) error {
 	if userID == adminID {
 		return errors.New("cannot remove built-in administrator account")
 	}
-	return m.db.Delete(usersCollection, userID)
+	if err := m.db.Delete(usersCollection, userID); err != nil {
+		return err
+	}
+	keys, err := m.s3KeyList(userID)
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := m.revokeS3Key(key.ID); err != nil {
+			return err
+		}
+	}
+	go m.broadcastS3Users()
+	return nil
 }
 
 // Unregister an existing cluster
@@ -222,7 +239,11 @@ func (m *userManager) delRole(role string) error {
 	if role == cmn.AuthAdminRole {
 		return errors.New("cannot remove built-in administrator role")
 	}
-	return m.db.Delete(rolesCollection, role)
+	if err := m.db.Delete(rolesCollection, role); err != nil {
+		return err
+	}
+	go m.broadcastS3Users()
+	return nil
 }
 
 // Updates an existing user. The function invalidates user tokens after
@@ -246,7 +267,11 @@ func (m *userManager) updateUser(userID string, updateReq *cmn.AuthUser) error {
 	uInfo.Clusters = cmn.MergeClusterACLs(uInfo.Clusters, updateReq.Clusters)
 	uInfo.Buckets = cmn.MergeBckACLs(uInfo.Buckets, updateReq.Buckets)
 
-	return m.db.Set(usersCollection, userID, uInfo)
+	if err := m.db.Set(usersCollection, userID, uInfo); err != nil {
+		return err
+	}
+	go m.broadcastS3Users()
+	return nil
 }
 
 // Updates an existing role
@@ -269,7 +294,11 @@ func (m *userManager) updateRole(role string, updateReq *cmn.AuthRole) error {
 	rInfo.Clusters = cmn.MergeClusterACLs(rInfo.Clusters, updateReq.Clusters)
 	rInfo.Buckets = cmn.MergeBckACLs(rInfo.Buckets, updateReq.Buckets)
 
-	return m.db.Set(rolesCollection, role, rInfo)
+	if err := m.db.Set(rolesCollection, role, rInfo); err != nil {
+		return err
+	}
+	go m.broadcastS3Users()
+	return nil
 }
 
 func (m *userManager) updateCluster(cluID string, info *cmn.AuthCluster) error {
@@ -291,7 +320,12 @@ func (m *userManager) updateCluster(cluID string, info *cmn.AuthCluster) error {
 	if len(info.URLs) != 0 {
 		clu.URLs = info.URLs
 	}
-	return m.db.Set(clustersCollection, cluID, clu)
+	if err := m.db.Set(clustersCollection, cluID, clu); err != nil {
+		return err
+	}
+	// resync S3 users, e.g., after the cluster restarted
+	go m.broadcastS3Users()
+	return nil
 }
 
 // Before putting a list of cluster permissions to a token, cluster aliases
@@ -318,72 +352,195 @@ func (m *userManager) fixClusterIDs(lst []*cmn.AuthCluster) {
 // Token includes user ID, permissions, and token expiration time.
 // If a new token was generated then it sends the proxy a new valid token list
 func (m *userManager) issueToken(userID, pwd string, ttl ...time.Duration) (string, error) {
-	var (
-		err     error
-		expires time.Time
-	)
-
-	uInfo := &cmn.AuthUser{}
-	err = m.db.Get(usersCollection, userID, uInfo)
-	if err != nil {
-		glog.Error(err)
-		return "", errInvalidCredentials
-	}
-	if !isSamePassword(pwd, uInfo.Password) {
-		return "", errInvalidCredentials
+	expDelta := conf.Auth.ExpirePeriod
+	if len(ttl) != 0 {
+		expDelta = ttl[0]
 	}
-
-	// update ACLs with roles's ones
-	for _, role := range uInfo.Roles {
-		rInfo := &cmn.AuthRole{}
-		err := m.db.Get(rolesCollection, role, rInfo)
-		if err != nil {
-			continue
-		}
-		uInfo.Clusters = cmn.MergeClusterACLs(uInfo.Clusters, rInfo.Clusters)
-		uInfo.Buckets = cmn.MergeBckACLs(uInfo.Buckets, rInfo.Buckets)
+	uInfo, err := m.checkCreds(userID, pwd)
+	if err != nil {
+		return "", err
 	}
+	m.mergeRoles(uInfo)
 
 	// generate token
 	issued := time.Now()
-	expDelta := conf.Auth.ExpirePeriod
-	if len(ttl) != 0 {
-		expDelta = ttl[0]
-	}
 	if expDelta == 0 {
 		expDelta = foreverTokenTime
 	}
-	expires = issued.Add(expDelta)
+	expires := issued.Add(expDelta)
 
 	// put all useful info into token: who owns the token, when it was issued,
 	// when it expires and credentials to log in AWS, GCP etc.
 	// If a user is a super user, it is enough to pass only isAdmin marker
-	var t *jwt.Token
+	var claims jwt.MapClaims
 	if uInfo.IsAdmin() {
-		t = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		claims = jwt.MapClaims{
 			"expires":  expires,
 			"username": userID,
 			"admin":    true,
-		})
+		}
 	} else {
 		m.fixClusterIDs(uInfo.Clusters)
-		t = jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		claims = jwt.MapClaims{
 			"expires":  expires,
 			"username": userID,
 			"buckets":  uInfo.Buckets,
 			"clusters": uInfo.Clusters,
+		}
+	}
+	return signToken(claims)
+}
+
+// Generates S3 credentials for a user if user credentials are valid.
+// The access key is a never-expiring token marked as S3 one (so that it cannot
+// be used as a regular bearer token), and the secret key is derived from
+// the access key and the secret shared by AuthN and AIS clusters.
+// The access key carries only the user ID and the key ID: AuthN stores
+// the key and pushes the current permissions of its owner to the clusters,
+// so that the permissions are checked when the key is used.
+func (m *userManager) issueS3Creds(userID, pwd string) (accessKey, secretKey string, err error) {
+	if _, err = m.checkCreds(userID, pwd); err != nil {
+		return
+	}
+	key := &cmn.AuthS3Key{
+		ID:     cmn.GenUUID(),
+		UserID: userID,
+		Issued: time.Now(),
+	}
+	claims := jwt.MapClaims{
+		"expires":  key.Issued.Add(foreverTokenTime),
+		"username": userID,
+		"s3":       true,
+		"kid":      key.ID,
+	}
+	if key.AccessKey, err = signToken(claims); err != nil {
+		return
+	}
+	if err = m.db.Set(s3KeysCollection, key.ID, key); err != nil {
+		return
+	}
+	go m.broadcastS3Users()
+	return key.AccessKey, cmn.S3SecretKey(key.AccessKey, conf.Auth.Secret), nil
+}
+
+// Returns S3 keys of a user (all keys if `userID` is empty).
+// Access keys are not included.
+func (m *userManager) s3KeyList(userID string) ([]*cmn.AuthS3Key, error) {
+	recs, err := m.db.GetAll(s3KeysCollection, "")
+	if err != nil {
+		return nil, err
+	}
+	keys := make([]*cmn.AuthS3Key, 0, len(recs))
+	for _, str := range recs {
+		key := &cmn.AuthS3Key{}
+		if err := jsoniter.Unmarshal([]byte(str), key); err != nil {
+			return nil, err
+		}
+		if userID != "" && key.UserID != userID {
+			continue
+		}
+		key.AccessKey = ""
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// Removes S3 key and revokes its access key
+func (m *userManager) revokeS3Key(keyID string) error {
+	key := &cmn.AuthS3Key{}
+	if err := m.db.Get(s3KeysCollection, keyID, key); err != nil {
+		return fmt.Errorf("S3 key %q not found", keyID)
+	}
+	if err := m.db.Delete(s3KeysCollection, keyID); err != nil {
+		return err
+	}
+	if err := m.db.Set(revokedCollection, key.AccessKey, "!"); err != nil {
+		return err
+	}
+	go func() {
+		m.broadcastRevoked(key.AccessKey)
+		m.broadcastS3Users()
+	}()
+	return nil
+}
+
+// Returns the current permissions of all users that own S3 keys
+func (m *userManager) s3Users() ([]*cmn.AuthToken, error) {
+	keys, err := m.s3KeyList("")
+	if err != nil {
+		return nil, err
+	}
+	var (
+		users = make([]*cmn.AuthToken, 0, len(keys))
+		added = make(cmn.StringSet, len(keys))
+	)
+	for _, key := range keys {
+		if added.Contains(key.UserID) {
+			continue
+		}
+		added.Add(key.UserID)
+		uInfo := &cmn.AuthUser{}
+		if err := m.db.Get(usersCollection, key.UserID, uInfo); err != nil {
+			continue
+		}
+		m.mergeRoles(uInfo)
+		if uInfo.IsAdmin() {
+			users = append(users, &cmn.AuthToken{UserID: key.UserID, IsAdmin: true})
+			continue
+		}
+		m.fixClusterIDs(uInfo.Clusters)
+		users = append(users, &cmn.AuthToken{
+			UserID:   key.UserID,
+			Clusters: uInfo.Clusters,
+			Buckets:  uInfo.Buckets,
 		})
 	}
+	return users, nil
+}
+
+func (m *userManager) checkCreds(userID, pwd string) (*cmn.AuthUser, error) {
+	uInfo := &cmn.AuthUser{}
+	if err := m.db.Get(usersCollection, userID, uInfo); err != nil {
+		glog.Error(err)
+		return nil, errInvalidCredentials
+	}
+	if !isSamePassword(pwd, uInfo.Password) {
+		return nil, errInvalidCredentials
+	}
+	return uInfo, nil
+}
+
+// update ACLs with roles's ones
+func (m *userManager) mergeRoles(uInfo *cmn.AuthUser) {
+	for _, role := range uInfo.Roles {
+		rInfo := &cmn.AuthRole{}
+		err := m.db.Get(rolesCollection, role, rInfo)
+		if err != nil {
+			continue
+		}
+		uInfo.Clusters = cmn.MergeClusterACLs(uInfo.Clusters, rInfo.Clusters)
+		uInfo.Buckets = cmn.MergeBckACLs(uInfo.Buckets, rInfo.Buckets)
+	}
+}
+
+func signToken(claims jwt.MapClaims) (string, error) {
+	t := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
 	tokenString, err := t.SignedString([]byte(conf.Auth.Secret))
 	if err != nil {
 		return "", fmt.Errorf("failed to generate token: %v", err)
 	}
-	return tokenString, err
+	return tokenString, nil
 }
 
 // Delete existing token, a.k.a log out
 // If the token was removed successfully then it sends the proxy a new valid token list
 func (m *userManager) revokeToken(token string) error {
+	// S3 access key: remove the stored key as well
+	if info, err := cmn.DecryptToken(token, conf.Auth.Secret); err == nil && info.S3 && info.KeyID != "" {
+		if err := m.revokeS3Key(info.KeyID); err == nil {
+			return nil
+		}
+	}
 	err := m.db.Set(revokedCollection, token, "!")
 	if err != nil {
 		return err
@@ -203,6 +203,9 @@ func (aisCLI *AISCLI) setupCommands() {
 	app.Commands = append(app.Commands, waitCmds...)
 	app.Commands = append(app.Commands, objectSpecificCmds...)
 	app.Commands = append(app.Commands, etlCmds...)
+	app.Commands = append(app.Commands, syncCmds...)
+	app.Commands = append(app.Commands, diffCmds...)
+	app.Commands = append(app.Commands, duCmds...)
 	sort.Sort(cli.CommandsByName(app.Commands))
 
 	setupCommandHelp(app.Commands)
@@ -30,6 +30,7 @@ const (
 	commandJoin      = "join"
 	commandList      = "ls"
 	commandPrefetch  = cmn.ActPrefetch
+	commandPresign   = "presign"
 	commandPromote   = "promote"
 	commandPut       = "put"
 	commandRemove    = "rm"
@@ -39,6 +40,9 @@ const (
 	commandShow      = "show"
 	commandStart     = cmn.ActXactStart
 	commandStop      = cmn.ActXactStop
+	commandSync      = "sync"
+	commandDiff      = "diff"
+	commandDU        = "du"
 	commandWait      = "wait"
 	commandSearch    = "search"
 	commandETL       = cmn.ETL
@@ -46,6 +50,7 @@ const (
 	// Subcommands - preferably nouns
 	subcmdDsort     = cmn.DSortNameLowercase
 	subcmdSmap      = cmn.GetWhatSmap
+	subcmdDomains   = "domains"
 	subcmdDisk      = cmn.GetWhatDiskStats
 	subcmdConfig    = cmn.GetWhatConfig
 	subcmdRebalance = cmn.ActRebalance
@@ -67,6 +72,8 @@ const (
 	subcmdLogs      = "logs"
 	subcmdStop      = "stop"
 	subcmdLRU       = cmn.ActLRU
+	subcmdWriteBack = cmn.GetWhatWriteBack
+	subcmdRetention = "retention"
 
 	// Show subcommands
 	subcmdShowBucket    = subcmdBucket
@@ -81,6 +88,8 @@ const (
 	subcmdShowRemoteAIS = subcmdRemoteAIS
 	subcmdShowCluster   = subcmdCluster
 	subcmdShowMpath     = subcmdMountpath
+	subcmdShowWriteBack = subcmdWriteBack
+	subcmdShowRetention = subcmdRetention
 
 	// Create subcommands
 	subcmdCreateBucket = subcmdBucket
@@ -111,9 +120,10 @@ const (
 	subcmdStopCluster  = subcmdCluster
 
 	// Set subcommand
-	subcmdSetConfig  = subcmdConfig
-	subcmdSetProps   = subcmdProps
-	subcmdSetPrimary = subcmdPrimary
+	subcmdSetConfig    = subcmdConfig
+	subcmdSetProps     = subcmdProps
+	subcmdSetPrimary   = subcmdPrimary
+	subcmdSetRetention = subcmdRetention
 
 	// Attach/Detach subcommand
 	subcmdAttachRemoteAIS = subcmdRemoteAIS
@@ -140,6 +150,7 @@ const (
 	subcmdAuthUser    = "user"
 	subcmdAuthRole    = "role"
 	subcmdAuthCluster = "cluster"
+	subcmdAuthS3Key   = "s3key"
 
 	// Warm up subcommands
 	subcmdPreload = "preload"
@@ -176,7 +187,11 @@ const (
 	putPromoteObjectArgument = "FILE|DIRECTORY BUCKET_NAME/[OBJECT_NAME]"
 	concatObjectArgument     = "FILE|DIRECTORY [FILE|DIRECTORY...] BUCKET_NAME/OBJECT_NAME"
 	objectArgument           = "BUCKET_NAME/OBJECT_NAME"
+	setRetentionArgument     = objectArgument + " RETAIN_UNTIL"
 	optionalObjectsArgument  = "BUCKET_NAME/[OBJECT_NAME]..."
+	syncArgument             = "DIRECTORY BUCKET_NAME/[PREFIX]|BUCKET_NAME/[PREFIX] DIRECTORY"
+	diffArgument             = "SRC_BUCKET_NAME [DST_BUCKET_NAME]"
+	bucketPrefixArgument     = "BUCKET_NAME/[PREFIX]"
 
 	// Daemons
 	daemonIDArgument         = "DAEMON_ID"
@@ -208,6 +223,8 @@ const (
 	showAuthRoleArgument      = "[ROLE]"
 	addAuthRoleArgument       = "ROLE [CLUSTER_ID PERMISSION ...]"
 	deleteRoleArgument        = "ROLE"
+	showAuthS3KeyArgument     = "[USER_NAME]"
+	deleteAuthS3KeyArgument   = "KEY_ID"
 
 	// Search
 	searchArgument = "KEYWORD [KEYWORD...]"
@@ -314,8 +331,24 @@ var (
 	lengthFlag    = cli.StringFlag{Name: "length", Usage: "object read length, can contain prefix 'b', 'KiB', 'MB'"}
 	isCachedFlag  = cli.BoolFlag{Name: "is-cached", Usage: "check if an object is cached"}
 	cachedFlag    = cli.BoolFlag{Name: "cached", Usage: "list only cached objects"}
-	checksumFlag  = cli.BoolFlag{Name: "checksum", Usage: "validate checksum"}
-	recursiveFlag = cli.BoolFlag{Name: "recursive,r", Usage: "recursive operation"}
+	bypassGovFlag = cli.BoolFlag{
+		Name:  "bypass-governance",
+		Usage: "override governance-mode object retention (requires admin permissions)",
+	}
+	checksumFlag     = cli.BoolFlag{Name: "checksum", Usage: "validate checksum"}
+	recursiveFlag    = cli.BoolFlag{Name: "recursive,r", Usage: "recursive operation"}
+	syncSizeOnlyFlag = cli.BoolFlag{Name: "size-only", Usage: "compare sizes only (instead of sizes and checksums)"}
+	syncDelFlag      = cli.BoolFlag{Name: "delete", Usage: "delete destination files (objects) that do not exist in the source"}
+	emitListFlag     = cli.StringFlag{
+		Name: "emit-list",
+		Usage: "only print comma-separated names of 'missing', 'extra' and/or 'different' objects " +
+			"(to use with '--list' of rm, evict and prefetch), e.g. 'missing,different'",
+	}
+
+	duDepthFlag      = cli.IntFlag{Name: "depth", Usage: "aggregate disk usage by prefixes (\"directories\") up to the given depth", Value: 1}
+	duTargetsFlag    = cli.BoolFlag{Name: "targets", Usage: "show per-target breakdown"}
+	duMountpathsFlag = cli.BoolFlag{Name: "mountpaths", Usage: "show per-target and per-mountpath breakdown"}
+
 	overwriteFlag = cli.BoolTFlag{Name: "overwrite,o", Usage: "overwrite destination if exists"}
 	keepOrigFlag  = cli.BoolTFlag{Name: "keep", Usage: "keep original file"}
 	targetFlag    = cli.StringFlag{Name: "target", Usage: "ais target ID"}
@@ -331,6 +364,21 @@ var (
 	// AuthN
 	tokenFileFlag = cli.StringFlag{Name: "file,f", Value: "", Usage: "save token to file"}
 	passwordFlag  = cli.StringFlag{Name: "password,p", Value: "", Usage: "user password"}
+	s3CredsFlag   = cli.BoolFlag{Name: "s3", Usage: "generate S3 access and secret keys instead of token"}
+
+	// Presign
+	presignMethodFlag  = cli.StringFlag{Name: "method", Usage: "HTTP method to presign: GET, HEAD, or PUT", Value: "GET"}
+	presignExpiresFlag = cli.DurationFlag{Name: "expires", Usage: "URL lifetime (at most 168h)", Value: time.Hour}
+	s3AccessKeyFlag    = cli.StringFlag{
+		Name:   "access-key",
+		Usage:  "S3 access key (see 'ais auth login --s3')",
+		EnvVar: "AWS_ACCESS_KEY_ID",
+	}
+	s3SecretKeyFlag = cli.StringFlag{
+		Name:   "secret-key",
+		Usage:  "S3 secret key (see 'ais auth login --s3')",
+		EnvVar: "AWS_SECRET_ACCESS_KEY",
+	}
 
 	// Copy Bucket
 	cpBckDryRunFlag = cli.BoolFlag{
@@ -101,6 +101,51 @@ func clusterDaemonStatus(c *cli.Context, smap *cluster.Smap, daemonID string, us
 	return fmt.Errorf("%s is not a valid DAEMON_ID nor DAEMON_TYPE", daemonID)
 }
 
+// Displays targets and their capacity aggregated by failure domain
+func clusterDomains(c *cli.Context, smap *cluster.Smap, useJSON bool) error {
+	return templates.DisplayOutput(domainStats(smap, target), c.App.Writer, templates.DomainsTmpl, useJSON)
+}
+
+// domainStats aggregates targets by zone and rack; the result is sorted by zone and rack
+func domainStats(smap *cluster.Smap, daeMap map[string]*stats.DaemonStatus) []templates.DomainStats {
+	var (
+		rows  = make([]templates.DomainStats, 0, 4)
+		index = make(map[string]int, 4)
+	)
+	for _, tsi := range smap.Tmap {
+		domain := tsi.Domain()
+		idx, ok := index[domain]
+		if !ok {
+			idx = len(rows)
+			index[domain] = idx
+			rows = append(rows, templates.DomainStats{Zone: tsi.Zone, Rack: tsi.Rack})
+		}
+		row := &rows[idx]
+		row.Targets++
+		if tsi.Flags.IsAnySet(cluster.SnodeMaintenanceMask) {
+			row.Maintenance++
+		}
+		if status, ok := daeMap[tsi.ID()]; ok && status != nil {
+			for _, mpcap := range status.Capacity {
+				row.Used += mpcap.Used
+				row.Avail += mpcap.Avail
+			}
+		}
+	}
+	for i := range rows {
+		if total := rows[i].Used + rows[i].Avail; total > 0 {
+			rows[i].UsedPct = float64(rows[i].Used) * 100 / float64(total)
+		}
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Zone != rows[j].Zone {
+			return rows[i].Zone < rows[j].Zone
+		}
+		return rows[i].Rack < rows[j].Rack
+	})
+	return rows
+}
+
 // Displays the disk stats of a target
 func daemonDiskStats(c *cli.Context, daemonID string, useJSON, hideHeader bool) error {
 	if _, ok := proxy[daemonID]; ok {
@@ -0,0 +1,142 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles comparing buckets.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/urfave/cli"
+)
+
+const (
+	diffMissing   = "missing"
+	diffExtra     = "extra"
+	diffDifferent = "different"
+)
+
+var (
+	diffCmdsFlags = map[string][]cli.Flag{
+		commandDiff: {
+			prefixFlag,
+			emitListFlag,
+		},
+	}
+
+	diffCmds = []cli.Command{
+		{
+			Name: commandDiff,
+			Usage: "compare two buckets (or a remote bucket's cached objects with its backend) " +
+				"by object names, sizes and checksums",
+			ArgsUsage:    diffArgument,
+			Flags:        diffCmdsFlags[commandDiff],
+			Action:       diffHandler,
+			BashComplete: manyBucketsCompletions([]cli.BashCompleteFunc{}, 0, 2),
+		},
+	}
+)
+
+// With a single (remote) bucket, compares its in-cluster objects with the backend.
+func diffHandler(c *cli.Context) (err error) {
+	var (
+		src, dst cmn.Bck
+		msg      = &cmn.BucketDiffMsg{Prefix: parseStrFlag(c, prefixFlag)}
+		diff     *cmn.BucketDiff
+	)
+	switch c.NArg() {
+	case 0:
+		return missingArgumentsError(c, "source bucket name")
+	case 1:
+		msg.Backend = true
+	case 2:
+	default:
+		return incorrectUsageMsg(c, "too many arguments")
+	}
+	if src, err = parseBckURI(c, c.Args().Get(0)); err != nil {
+		return
+	}
+	if src, _, err = validateBucket(c, src, "", false); err != nil {
+		return
+	}
+	if msg.Backend {
+		dst = src
+	} else {
+		if dst, err = parseBckURI(c, c.Args().Get(1)); err != nil {
+			return
+		}
+		if dst, _, err = validateBucket(c, dst, "", false); err != nil {
+			return
+		}
+		if src.Equal(dst) {
+			return incorrectUsageMsg(c, "cannot compare bucket %q with itself", src)
+		}
+	}
+
+	if flagIsSet(c, emitListFlag) {
+		if diff, err = api.DiffBuckets(defaultAPIParams, src, dst, msg); err != nil {
+			return
+		}
+		list, err := diffEmitList(diff, parseStrFlag(c, emitListFlag))
+		if err != nil {
+			return incorrectUsageMsg(c, "%v", err)
+		}
+		fmt.Fprintln(c.App.Writer, list)
+		return nil
+	}
+
+	err = cmn.WaitForFunc(func() (err error) {
+		diff, err = api.DiffBuckets(defaultAPIParams, src, dst, msg)
+		return
+	}, longCommandTime)
+	if err != nil {
+		return
+	}
+	dstName := fmt.Sprintf("%q", dst)
+	if msg.Backend {
+		dstName = "its backend"
+	}
+	if diff.InSync() {
+		fmt.Fprintf(c.App.Writer, "bucket %q and %s are in sync (%d objects)\n", src, dstName, diff.Same)
+		return nil
+	}
+	for _, name := range diff.Missing {
+		fmt.Fprintf(c.App.Writer, "- %s\n", name)
+	}
+	for _, name := range diff.Extra {
+		fmt.Fprintf(c.App.Writer, "+ %s\n", name)
+	}
+	for _, name := range diff.Different {
+		fmt.Fprintf(c.App.Writer, "~ %s\n", name)
+	}
+	by := "sizes"
+	if diff.Cksum {
+		by = "sizes and checksums"
+	}
+	fmt.Fprintf(c.App.Writer, "bucket %q vs %s (by %s): %d missing (-), %d extra (+), %d different (~), %d same\n",
+		src, dstName, by, len(diff.Missing), len(diff.Extra), len(diff.Different), diff.Same)
+	return nil
+}
+
+// returns comma-separated names of the objects in the requested categories
+func diffEmitList(diff *cmn.BucketDiff, kinds string) (string, error) {
+	var names []string
+	for _, kind := range makeList(kinds) {
+		switch kind {
+		case diffMissing:
+			names = append(names, diff.Missing...)
+		case diffExtra:
+			names = append(names, diff.Extra...)
+		case diffDifferent:
+			names = append(names, diff.Different...)
+		default:
+			return "", fmt.Errorf("invalid %q value %q (expecting one of: %s, %s, %s)",
+				emitListFlag.Name, kind, diffMissing, diffExtra, diffDifferent)
+		}
+	}
+	return strings.Join(names, ","), nil
+}
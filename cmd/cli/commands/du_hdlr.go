@@ -0,0 +1,121 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles disk usage reports.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"sort"
+
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmd/cli/templates"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/urfave/cli"
+)
+
+var (
+	duCmdsFlags = map[string][]cli.Flag{
+		commandDU: {
+			duDepthFlag,
+			duTargetsFlag,
+			duMountpathsFlag,
+			jsonFlag,
+		},
+	}
+
+	duCmds = []cli.Command{
+		{
+			Name:         commandDU,
+			Usage:        "show disk usage of the bucket's objects aggregated by prefix (\"directory\")",
+			ArgsUsage:    bucketPrefixArgument,
+			Flags:        duCmdsFlags[commandDU],
+			Action:       duHandler,
+			BashComplete: bucketCompletions(),
+		},
+	}
+)
+
+func duHandler(c *cli.Context) (err error) {
+	var (
+		bck    cmn.Bck
+		prefix string
+		depth  = parseIntFlag(c, duDepthFlag)
+	)
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "bucket name")
+	}
+	if c.NArg() > 1 {
+		return incorrectUsageMsg(c, "too many arguments")
+	}
+	if depth < 0 {
+		return incorrectUsageMsg(c, "invalid %q value %d (expecting non-negative number)", duDepthFlag.Name, depth)
+	}
+	if bck, prefix, err = parseBckObjectURI(c, c.Args().First()); err != nil {
+		return
+	}
+	if bck, _, err = validateBucket(c, bck, "", false); err != nil {
+		return
+	}
+	var summary cmn.BucketSummary
+	err = cmn.WaitForFunc(func() (err error) {
+		summary, err = api.GetBucketDiskUsage(defaultAPIParams, bck, prefix, depth)
+		return
+	}, longCommandTime)
+	if err != nil {
+		return
+	}
+
+	var (
+		byMpath  = flagIsSet(c, duMountpathsFlag)
+		byTarget = byMpath || flagIsSet(c, duTargetsFlag)
+		tmpl     = templates.DiskUsageTmpl
+		rows     = duRows(summary.Usage, byTarget, byMpath)
+	)
+	if byMpath {
+		tmpl = templates.DiskUsageMountpathsTmpl
+	} else if byTarget {
+		tmpl = templates.DiskUsageTargetsTmpl
+	}
+	for i := range rows {
+		rows[i].Prefix = bck.String() + "/" + rows[i].Prefix
+	}
+	return templates.DisplayOutput(rows, c.App.Writer, tmpl, flagIsSet(c, jsonFlag))
+}
+
+// duRows aggregates per-target, per-mountpath usage by prefix and, optionally,
+// by target and mountpath; the result is sorted by prefix, target and mountpath
+func duRows(usage []cmn.PrefixUsage, byTarget, byMpath bool) []cmn.PrefixUsage {
+	var (
+		rows  = make([]cmn.PrefixUsage, 0, len(usage))
+		index = make(map[cmn.PrefixUsage]int, len(usage))
+	)
+	for _, u := range usage {
+		key := cmn.PrefixUsage{Prefix: u.Prefix}
+		if byTarget {
+			key.Target = u.Target
+		}
+		if byMpath {
+			key.Mountpath = u.Mountpath
+		}
+		idx, ok := index[key]
+		if !ok {
+			idx = len(rows)
+			index[key] = idx
+			rows = append(rows, key)
+		}
+		rows[idx].ObjCount += u.ObjCount
+		rows[idx].Size += u.Size
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		a, b := rows[i], rows[j]
+		if a.Prefix != b.Prefix {
+			return a.Prefix < b.Prefix
+		}
+		if a.Target != b.Target {
+			return a.Target < b.Target
+		}
+		return a.Mountpath < b.Mountpath
+	})
+	return rows
+}
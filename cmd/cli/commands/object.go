@@ -827,7 +827,12 @@ func multiObjOp(c *cli.Context, command string) error {
 
 		switch command {
 		case commandRemove:
-			if err := api.DeleteObject(defaultAPIParams, bck, objectName); err != nil {
+			if flagIsSet(c, bypassGovFlag) {
+				err = api.DeleteLockedObject(defaultAPIParams, bck, objectName)
+			} else {
+				err = api.DeleteObject(defaultAPIParams, bck, objectName)
+			}
+			if err != nil {
 				return err
 			}
 			fmt.Fprintf(c.App.Writer, "%q deleted from %q bucket\n", objectName, bck)
@@ -10,6 +10,7 @@ import (
 	"path/filepath"
 	"strings"
 
+	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/urfave/cli"
 )
@@ -56,6 +57,12 @@ var (
 			checksumFlag,
 			forceFlag,
 		},
+		commandPresign: {
+			presignMethodFlag,
+			presignExpiresFlag,
+			s3AccessKeyFlag,
+			s3SecretKeyFlag,
+		},
 	}
 
 	objectSpecificCmds = []cli.Command{
@@ -106,6 +113,14 @@ var (
 			Action:       catHandler,
 			BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
 		},
+		{
+			Name:         commandPresign,
+			Usage:        "generate time-limited S3 URL to get or put the object without other credentials",
+			ArgsUsage:    objectArgument,
+			Flags:        objectSpecificCmdsFlags[commandPresign],
+			Action:       presignHandler,
+			BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
+		},
 	}
 )
 
@@ -311,3 +326,34 @@ func promoteHandler(c *cli.Context) (err error) {
 func catHandler(c *cli.Context) (err error) {
 	return getObject(c, fileStdIO, true /*silent*/)
 }
+
+func presignHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "object name in the form bucket/object")
+	}
+	if c.NArg() > 1 {
+		return incorrectUsageMsg(c, "too many arguments")
+	}
+	bck, objName, err := parseBckObjectURI(c, c.Args().First())
+	if err != nil {
+		return
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "object name is required")
+	}
+	if bck, _, err = validateBucket(c, bck, "", false); err != nil {
+		return
+	}
+	creds := &api.S3Creds{
+		AccessKey: parseStrFlag(c, s3AccessKeyFlag),
+		SecretKey: parseStrFlag(c, s3SecretKeyFlag),
+	}
+	method := strings.ToUpper(parseStrFlag(c, presignMethodFlag))
+	presigned, err := api.PresignObjectS3(defaultAPIParams, bck, objName, method, creds,
+		parseDurationFlag(c, presignExpiresFlag))
+	if err != nil {
+		return
+	}
+	fmt.Fprintln(c.App.Writer, presigned)
+	return
+}
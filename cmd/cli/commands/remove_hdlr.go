@@ -20,7 +20,7 @@ var (
 		subcmdRemoveBucket: {
 			ignoreErrorFlag,
 		},
-		subcmdRemoveObject: baseLstRngFlags,
+		subcmdRemoveObject: append(baseLstRngFlags, bypassGovFlag),
 		subcmdRemoveNode: {
 			maintenanceModeFlag,
 			noRebalanceFlag,
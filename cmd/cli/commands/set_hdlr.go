@@ -7,6 +7,9 @@ package commands
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cmn"
@@ -21,6 +24,9 @@ var (
 			forceFlag,
 		},
 		subcmdSetPrimary: {},
+		subcmdSetRetention: {
+			bypassGovFlag,
+		},
 	}
 
 	setCmds = []cli.Command{
@@ -52,6 +58,14 @@ var (
 					Action:       setPrimaryHandler,
 					BashComplete: daemonCompletions(completeProxies),
 				},
+				{
+					Name:         subcmdSetRetention,
+					Usage:        "extend object's retention (object lock); RETAIN_UNTIL is either RFC3339 date or duration from now, e.g. 30d",
+					ArgsUsage:    setRetentionArgument,
+					Flags:        setCmdsFlags[subcmdSetRetention],
+					Action:       setRetentionHandler,
+					BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
+				},
 			},
 		},
 	}
@@ -139,3 +153,44 @@ func setPrimaryHandler(c *cli.Context) (err error) {
 	}
 	return err
 }
+
+func setRetentionHandler(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, "object name in the form bucket/object", "retain until")
+	}
+	bck, objName, err := parseBckObjectURI(c, c.Args().First())
+	if err != nil {
+		return
+	}
+	if bck, _, err = validateBucket(c, bck, "", false); err != nil {
+		return
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "%q: missing object name", c.Args().First())
+	}
+	until, err := parseRetainUntil(c.Args().Get(1), time.Now())
+	if err != nil {
+		return incorrectUsageMsg(c, "%v", err)
+	}
+	if err = api.SetObjectRetention(defaultAPIParams, bck, objName, until, flagIsSet(c, bypassGovFlag)); err != nil {
+		return
+	}
+	fmt.Fprintf(c.App.Writer, "%s/%s retained until %s\n", bck, objName, until.Format(time.RFC3339))
+	return
+}
+
+// RFC3339 date or duration from `now` (with an additional "d" (days) unit)
+func parseRetainUntil(s string, now time.Time) (time.Time, error) {
+	if until, err := time.Parse(time.RFC3339, s); err == nil {
+		return until, nil
+	}
+	if strings.HasSuffix(s, "d") {
+		if days, err := strconv.ParseInt(strings.TrimSuffix(s, "d"), 10, 64); err == nil && days > 0 {
+			return now.Add(time.Duration(days) * 24 * time.Hour), nil
+		}
+	}
+	if d, err := time.ParseDuration(s); err == nil && d > 0 {
+		return now.Add(d), nil
+	}
+	return time.Time{}, fmt.Errorf("invalid retain-until %q (expecting RFC3339 date or duration, e.g. 30d)", s)
+}
@@ -37,6 +37,7 @@ var (
 		cmn.HeaderBucketAccessAttrs:           cmn.SupportedPermissions(),
 		cmn.HeaderObjCksumType:                cmn.SupportedChecksums(),
 		"md_write":                            cmn.SupportedWritePolicy,
+		"remote_write":                        cmn.SupportedRemoteWritePolicy,
 		"ec.compression":                      cmn.SupportedCompression,
 		"compression.checksum":                cmn.SupportedCompression,
 		"rebalance.compression":               cmn.SupportedCompression,
@@ -11,6 +11,7 @@ import (
 	"strings"
 	"sync"
 	"text/tabwriter"
+	"time"
 
 	"github.com/NVIDIA/aistore/api"
 	"github.com/NVIDIA/aistore/cluster"
@@ -77,6 +78,9 @@ var (
 		subcmdSmap: {
 			jsonFlag,
 		},
+		subcmdDomains: {
+			jsonFlag,
+		},
 		subcmdShowXaction: {
 			jsonFlag,
 			allXactionsFlag,
@@ -100,6 +104,13 @@ var (
 		subcmdShowMpath: {
 			jsonFlag,
 		},
+		subcmdShowWriteBack: {
+			jsonFlag,
+			noHeaderFlag,
+		},
+		subcmdShowRetention: {
+			noHeaderFlag,
+		},
 	}
 
 	showCmds = []cli.Command{
@@ -162,7 +173,7 @@ var (
 					Action:    showClusterHandler,
 					BashComplete: func(c *cli.Context) {
 						if c.NArg() == 0 {
-							fmt.Printf("%s\n%s\n%s\n", cmn.Proxy, cmn.Target, subcmdSmap)
+							fmt.Printf("%s\n%s\n%s\n%s\n", cmn.Proxy, cmn.Target, subcmdSmap, subcmdDomains)
 						}
 						daemonCompletions(completeAllDaemons)(c)
 					},
@@ -175,6 +186,12 @@ var (
 							Action:       showSmapHandler,
 							BashComplete: daemonCompletions(completeAllDaemons),
 						},
+						{
+							Name:   subcmdDomains,
+							Usage:  "show distribution of targets and their capacity across failure domains (zones and racks)",
+							Flags:  showCmdsFlags[subcmdDomains],
+							Action: showDomainsHandler,
+						},
 					},
 				},
 				{
@@ -225,6 +242,22 @@ var (
 					Action:       showMpathHandler,
 					BashComplete: daemonCompletions(completeTargets),
 				},
+				{
+					Name:         subcmdShowWriteBack,
+					Usage:        "show objects pending write-back to remote buckets",
+					ArgsUsage:    optionalBucketArgument,
+					Flags:        showCmdsFlags[subcmdShowWriteBack],
+					Action:       showWriteBackHandler,
+					BashComplete: bucketCompletions(),
+				},
+				{
+					Name:         subcmdShowRetention,
+					Usage:        "show object's retention (object lock)",
+					ArgsUsage:    objectArgument,
+					Flags:        showCmdsFlags[subcmdShowRetention],
+					Action:       showRetentionHandler,
+					BashComplete: bucketCompletions(bckCompletionsOpts{separator: true}),
+				},
 			},
 		},
 	}
@@ -432,6 +465,14 @@ func showSmapHandler(c *cli.Context) (err error) {
 	return clusterSmap(c, primarySmap, daemonID, flagIsSet(c, jsonFlag))
 }
 
+func showDomainsHandler(c *cli.Context) (err error) {
+	smap, err := fillMap()
+	if err != nil {
+		return
+	}
+	return clusterDomains(c, smap, flagIsSet(c, jsonFlag))
+}
+
 func showConfigHandler(c *cli.Context) (err error) {
 	if _, err = fillMap(); err != nil {
 		return
@@ -525,3 +566,81 @@ func showMpathHandler(c *cli.Context) (err error) {
 	useJSON := flagIsSet(c, jsonFlag)
 	return templates.DisplayOutput(mpls, c.App.Writer, templates.TargetMpathListTmpl, useJSON)
 }
+
+func showWriteBackHandler(c *cli.Context) (err error) {
+	var bck cmn.Bck
+	if c.NArg() > 0 {
+		if bck, err = parseBckURI(c, c.Args().First()); err != nil {
+			return
+		}
+	}
+	wbInfo, err := api.GetWriteBackPending(defaultAPIParams, bck)
+	if err != nil {
+		return
+	}
+	if flagIsSet(c, jsonFlag) {
+		return templates.DisplayOutput(wbInfo, c.App.Writer, "", true)
+	}
+	targetIDs := make([]string, 0, len(wbInfo))
+	for tid := range wbInfo {
+		targetIDs = append(targetIDs, tid)
+	}
+	sort.Strings(targetIDs)
+
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "TARGET\tBUCKET\tOBJECT\tSIZE\tQUEUED\tRETRIES\tERROR")
+	}
+	for _, tid := range targetIDs {
+		info := wbInfo[tid]
+		for _, e := range info.Entries {
+			fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%d\t%s\n", tid, e.Bck, e.ObjName,
+				cmn.B2S(e.Size, 2), cmn.FormatUnixNano(e.Queued, ""), e.Retries, e.Err)
+		}
+		if info.Total > len(info.Entries) {
+			fmt.Fprintf(tw, "%s\t...\t(%d more)\t\t\t\t\n", tid, info.Total-len(info.Entries))
+		}
+	}
+	tw.Flush()
+	return
+}
+
+func showRetentionHandler(c *cli.Context) (err error) {
+	if c.NArg() == 0 {
+		return missingArgumentsError(c, "object name in the form bucket/object")
+	}
+	bck, objName, err := parseBckObjectURI(c, c.Args().First())
+	if err != nil {
+		return
+	}
+	var bprops *cmn.BucketProps
+	if bck, bprops, err = validateBucket(c, bck, "", false); err != nil {
+		return
+	}
+	if objName == "" {
+		return incorrectUsageMsg(c, "%q: missing object name", c.Args().First())
+	}
+	objProps, err := api.HeadObject(defaultAPIParams, bck, objName)
+	if err != nil {
+		return handleObjHeadError(err, bck, objName)
+	}
+	var (
+		retainUntil = "-"
+		status      = "unlocked"
+	)
+	if objProps.RetainUntil != 0 {
+		retainUntil = cmn.FormatUnixNano(objProps.RetainUntil, time.RFC3339)
+		if bprops.ObjLock.Enabled() && time.Now().UnixNano() < objProps.RetainUntil {
+			status = "locked"
+		}
+	}
+	tw := &tabwriter.Writer{}
+	tw.Init(c.App.Writer, 0, 8, 2, ' ', 0)
+	if !flagIsSet(c, noHeaderFlag) {
+		fmt.Fprintln(tw, "OBJECT\tLOCK\tRETAIN UNTIL\tSTATUS")
+	}
+	fmt.Fprintf(tw, "%s/%s\t%s\t%s\t%s\n", bck, objName, bprops.ObjLock.String(), retainUntil, status)
+	tw.Flush()
+	return
+}
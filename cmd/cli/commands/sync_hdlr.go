@@ -0,0 +1,346 @@
+// Package commands provides the set of CLI commands used to communicate with the AIS cluster.
+// This file handles synchronization of local directories with buckets.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package commands
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/api"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/urfave/cli"
+)
+
+type (
+	// file or object that takes part in synchronization
+	syncEntry struct {
+		name  string // object name (for files: prefix + path relative to the directory)
+		path  string // local file path (files only)
+		size  int64  //
+		cksum string // objects: as listed; files: computed on demand (see syncer.equal)
+	}
+	syncer struct {
+		c         *cli.Context
+		bck       cmn.Bck
+		prefix    string
+		dir       string
+		cksumType string // empty when comparing sizes only
+	}
+)
+
+var (
+	syncCmdsFlags = map[string][]cli.Flag{
+		commandSync: {
+			syncDelFlag,
+			syncSizeOnlyFlag,
+			dryRunFlag,
+			concurrencyFlag,
+			progressBarFlag,
+			refreshFlag,
+			verboseFlag,
+		},
+	}
+
+	syncCmds = []cli.Command{
+		{
+			Name: commandSync,
+			Usage: "synchronize local directory with bucket (or vice versa) transferring only new and modified " +
+				"files (objects)",
+			ArgsUsage:    syncArgument,
+			Flags:        syncCmdsFlags[commandSync],
+			Action:       syncHandler,
+			BashComplete: putPromoteObjectCompletions,
+		},
+	}
+)
+
+// The direction is determined by the first argument: existing local directory
+// is synchronized into the bucket, otherwise the bucket into the directory.
+func syncHandler(c *cli.Context) (err error) {
+	if c.NArg() < 2 {
+		return missingArgumentsError(c, "source", "destination")
+	}
+	if c.NArg() > 2 {
+		return incorrectUsageMsg(c, "too many arguments")
+	}
+	var (
+		bck    cmn.Bck
+		p      *cmn.BucketProps
+		upload bool
+		dir    = c.Args().Get(0)
+		uri    = c.Args().Get(1)
+	)
+	if fi, err := os.Stat(cmn.ExpandPath(dir)); err == nil {
+		if !fi.IsDir() {
+			return incorrectUsageMsg(c, "%q is not a directory", dir)
+		}
+		upload = true
+	} else {
+		dir, uri = uri, dir
+	}
+	s := &syncer{c: c}
+	if s.dir, err = getPathFromFileName(dir); err != nil {
+		return
+	}
+	if bck, s.prefix, err = parseBckObjectURI(c, uri); err != nil {
+		return
+	}
+	if s.bck, p, err = validateBucket(c, bck, uri, false); err != nil {
+		return
+	}
+	if !flagIsSet(c, syncSizeOnlyFlag) {
+		if p.Cksum.Type == cmn.ChecksumNone {
+			return fmt.Errorf("cannot compare checksums: bucket %q has checksumming disabled (use `--%s` to compare sizes only)",
+				s.bck, syncSizeOnlyFlag.Name)
+		}
+		s.cksumType = p.Cksum.Type
+	}
+
+	printDryRunHeader(c)
+	files, err := s.listFiles()
+	if err != nil {
+		return
+	}
+	objs, err := s.listObjects()
+	if err != nil {
+		return
+	}
+	if upload {
+		return s.upload(files, objs)
+	}
+	return s.download(objs, files)
+}
+
+func (s *syncer) listFiles() (map[string]*syncEntry, error) {
+	if _, err := os.Stat(s.dir); os.IsNotExist(err) {
+		return map[string]*syncEntry{}, nil // (download into new directory)
+	}
+	files, err := generateFileList(s.dir, "", s.prefix, true /*recursive*/)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]*syncEntry, len(files))
+	for _, f := range files {
+		entries[f.name] = &syncEntry{name: f.name, path: f.path, size: f.size}
+	}
+	return entries, nil
+}
+
+func (s *syncer) listObjects() (map[string]*syncEntry, error) {
+	msg := &cmn.SelectMsg{
+		Prefix: s.prefix,
+		Props:  strings.Join([]string{cmn.GetPropsName, cmn.GetPropsSize, cmn.GetPropsChecksum}, ","),
+	}
+	objList, err := api.ListObjects(defaultAPIParams, s.bck, msg, 0)
+	if err != nil {
+		return nil, err
+	}
+	entries := make(map[string]*syncEntry, len(objList.Entries))
+	for _, e := range objList.Entries {
+		if e.IsDir() || !e.IsStatusOK() {
+			continue
+		}
+		entries[e.Name] = &syncEntry{name: e.Name, size: e.Size, cksum: e.Checksum}
+	}
+	return entries, nil
+}
+
+// Files and objects are the same if they have the same size and, unless
+// comparing sizes only (`--size-only`), the same checksum. (Objects have no
+// modification time that could be compared with the files' - their access
+// time is updated by GET.)
+func (s *syncer) equal(src, dst *syncEntry) (bool, error) {
+	if src.size != dst.size {
+		return false, nil
+	}
+	if s.cksumType == "" {
+		return true, nil
+	}
+	for _, e := range []*syncEntry{src, dst} {
+		if e.path == "" || e.cksum != "" {
+			continue
+		}
+		fh, err := os.Open(e.path)
+		if err != nil {
+			return false, err
+		}
+		_, cksum, err := cmn.CopyAndChecksum(ioutil.Discard, fh, nil, s.cksumType)
+		cmn.Close(fh)
+		if err != nil {
+			return false, err
+		}
+		e.cksum = cksum.Value()
+	}
+	return src.cksum != "" && src.cksum == dst.cksum, nil
+}
+
+// returns source entries that are either missing or different at the
+// destination, and destination entries that do not exist in the source -
+// both sorted by name
+func syncDiff(src, dst map[string]*syncEntry, equal func(src, dst *syncEntry) (bool, error)) (
+	transfer, extra []*syncEntry, err error) {
+	for name, se := range src {
+		de, ok := dst[name]
+		if ok {
+			var same bool
+			if same, err = equal(se, de); err != nil {
+				return
+			}
+			if same {
+				continue
+			}
+		}
+		transfer = append(transfer, se)
+	}
+	for name, de := range dst {
+		if _, ok := src[name]; !ok {
+			extra = append(extra, de)
+		}
+	}
+	sort.Slice(transfer, func(i, j int) bool { return transfer[i].name < transfer[j].name })
+	sort.Slice(extra, func(i, j int) bool { return extra[i].name < extra[j].name })
+	return
+}
+
+func (s *syncer) upload(files, objs map[string]*syncEntry) error {
+	transfer, extra, err := syncDiff(files, objs, s.equal)
+	if err != nil {
+		return err
+	}
+	if !flagIsSet(s.c, syncDelFlag) {
+		extra = nil
+	}
+	if len(transfer) == 0 && len(extra) == 0 {
+		fmt.Fprintf(s.c.App.Writer, "%q and bucket %q are in sync\n", s.dir, s.bck)
+		return nil
+	}
+	if flagIsSet(s.c, dryRunFlag) {
+		for _, e := range transfer {
+			fmt.Fprintf(s.c.App.Writer, "PUT %q => \"%s/%s\"\n", e.path, s.bck, e.name)
+		}
+		for _, e := range extra {
+			fmt.Fprintf(s.c.App.Writer, "DELETE \"%s/%s\"\n", s.bck, e.name)
+		}
+		return nil
+	}
+	if len(transfer) > 0 {
+		params := uploadParams{
+			bck:       s.bck,
+			files:     make([]fileToObj, 0, len(transfer)),
+			workerCnt: parseIntFlag(s.c, concurrencyFlag),
+			refresh:   calcPutRefresh(s.c),
+		}
+		for _, e := range transfer {
+			params.files = append(params.files, fileToObj{path: e.path, name: e.name, size: e.size})
+			params.totalSize += e.size
+		}
+		if err := uploadFiles(s.c, params); err != nil {
+			return err
+		}
+	}
+	return s.forEach(extra, "deleted", func(e *syncEntry) error {
+		return api.DeleteObject(defaultAPIParams, s.bck, e.name)
+	})
+}
+
+func (s *syncer) download(objs, files map[string]*syncEntry) error {
+	transfer, extra, err := syncDiff(objs, files, s.equal)
+	if err != nil {
+		return err
+	}
+	if !flagIsSet(s.c, syncDelFlag) {
+		extra = nil
+	}
+	for _, e := range transfer {
+		if e.path, err = s.localPath(e.name); err != nil {
+			return err
+		}
+	}
+	if len(transfer) == 0 && len(extra) == 0 {
+		fmt.Fprintf(s.c.App.Writer, "bucket %q and %q are in sync\n", s.bck, s.dir)
+		return nil
+	}
+	if flagIsSet(s.c, dryRunFlag) {
+		for _, e := range transfer {
+			fmt.Fprintf(s.c.App.Writer, "GET \"%s/%s\" => %q\n", s.bck, e.name, e.path)
+		}
+		for _, e := range extra {
+			fmt.Fprintf(s.c.App.Writer, "DELETE %q\n", e.path)
+		}
+		return nil
+	}
+	err = s.forEach(transfer, "downloaded", func(e *syncEntry) error {
+		return s.getObject(e)
+	})
+	if err != nil {
+		return err
+	}
+	return s.forEach(extra, "deleted", func(e *syncEntry) error {
+		return os.Remove(e.path)
+	})
+}
+
+// object name => local path, making sure the latter stays within the directory
+func (s *syncer) localPath(objName string) (string, error) {
+	rel := strings.TrimPrefix(objName, s.prefix)
+	path := filepath.Join(s.dir, rel)
+	if rel == "" || !strings.HasPrefix(path, s.dir+string(filepath.Separator)) {
+		return "", fmt.Errorf("object %q cannot be stored under %q", objName, s.dir)
+	}
+	return path, nil
+}
+
+// GET into a temporary file that then replaces the destination
+func (s *syncer) getObject(e *syncEntry) error {
+	tmpPath := e.path + ".sync.tmp"
+	fh, err := cmn.CreateFile(tmpPath)
+	if err != nil {
+		return err
+	}
+	_, err = api.GetObject(defaultAPIParams, s.bck, e.name, api.GetObjectInput{Writer: fh})
+	cmn.Close(fh)
+	if err == nil {
+		err = os.Rename(tmpPath, e.path)
+	}
+	if err != nil {
+		os.Remove(tmpPath)
+	}
+	return err
+}
+
+func (s *syncer) forEach(entries []*syncEntry, done string, action func(e *syncEntry) error) error {
+	if len(entries) == 0 {
+		return nil
+	}
+	var (
+		errCount atomic.Int32
+		verbose  = flagIsSet(s.c, verboseFlag)
+		wg       = cmn.NewLimitedWaitGroup(parseIntFlag(s.c, concurrencyFlag))
+	)
+	for _, e := range entries {
+		wg.Add(1)
+		go func(e *syncEntry) {
+			defer wg.Done()
+			if err := action(e); err != nil {
+				fmt.Fprintf(s.c.App.Writer, "Failed to sync %q: %v\n", e.name, err)
+				errCount.Inc()
+			} else if verbose {
+				fmt.Fprintf(s.c.App.Writer, "%s %s\n", e.name, done)
+			}
+		}(e)
+	}
+	wg.Wait()
+	if failed := errCount.Load(); failed != 0 {
+		return fmt.Errorf("failed to sync %d (out of %d) files (objects)", failed, len(entries))
+	}
+	fmt.Fprintf(s.c.App.Writer, "%d files (objects) %s\n", len(entries), done)
+	return nil
+}
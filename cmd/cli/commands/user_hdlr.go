@@ -33,7 +33,7 @@ const (
 
 var (
 	authFlags = map[string][]cli.Flag{
-		flagsAuthUserLogin: {tokenFileFlag, passwordFlag},
+		flagsAuthUserLogin: {tokenFileFlag, passwordFlag, s3CredsFlag},
 		subcmdAuthUser:     {passwordFlag},
 		flagsAuthRoleAdd:   {descriptionFlag},
 	}
@@ -95,6 +95,12 @@ var (
 							Action:       wrapAuthN(deleteRoleHandler),
 							BashComplete: oneRoleCompletions,
 						},
+						{
+							Name:      subcmdAuthS3Key,
+							Usage:     "revoke S3 access key",
+							ArgsUsage: deleteAuthS3KeyArgument,
+							Action:    wrapAuthN(deleteS3KeyHandler),
+						},
 					},
 				},
 				{
@@ -137,6 +143,13 @@ var (
 							Usage:  "show user list",
 							Action: wrapAuthN(showUserHandler),
 						},
+						{
+							Name:         subcmdAuthS3Key,
+							Usage:        "show issued S3 access keys",
+							ArgsUsage:    showAuthS3KeyArgument,
+							Action:       wrapAuthN(showS3KeyHandler),
+							BashComplete: oneUserCompletions,
+						},
 					},
 				},
 				{
@@ -238,6 +251,14 @@ func loginUserHandler(c *cli.Context) (err error) {
 	const tokenSaveFailFmt = "successfully logged in, but failed to save token: %v"
 	name := cliAuthnUserName(c)
 	password := cliAuthnUserPassword(c, false)
+	if flagIsSet(c, s3CredsFlag) {
+		creds, err := api.LoginUserS3(authParams, name, password)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(c.App.Writer, "Access key:\n%s\nSecret key:\n%s\n", creds.AccessKey, creds.SecretKey)
+		return nil
+	}
 	token, err := api.LoginUser(authParams, name, password)
 	if err != nil {
 		return err
@@ -374,6 +395,23 @@ func showUserHandler(c *cli.Context) (err error) {
 	return templates.DisplayOutput(list, c.App.Writer, templates.AuthNUserTmpl)
 }
 
+func showS3KeyHandler(c *cli.Context) (err error) {
+	list, err := api.GetS3KeysAuthN(authParams, c.Args().Get(0))
+	if err != nil {
+		return err
+	}
+
+	return templates.DisplayOutput(list, c.App.Writer, templates.AuthNS3KeyTmpl)
+}
+
+func deleteS3KeyHandler(c *cli.Context) (err error) {
+	keyID := c.Args().Get(0)
+	if keyID == "" {
+		return missingArgumentsError(c, "key ID")
+	}
+	return api.RevokeS3KeyAuthN(authParams, keyID)
+}
+
 // TODO: it is a basic parser for permissions.
 // Need better one that supports parsing specific permissions, not only
 // these three predefined ones. After the accurate pareser is implemeneted
@@ -376,8 +376,20 @@ func parseSource(rawURL string) (source dlSource, err error) {
 	case "https", "http":
 		break
 	default:
-		err = fmt.Errorf("invalid scheme: %s", scheme)
-		return
+		// 3rd party backend registered via `cmn.RegisterProvider` - same as Azure,
+		// there is no generic way to translate the URI into an HTTP link
+		provider, errN := cmn.NormalizeProvider(scheme)
+		if errN != nil {
+			err = fmt.Errorf("invalid scheme: %s", scheme)
+			return
+		}
+		return dlSource{
+			link: "",
+			backend: dlSourceBackend{
+				bck:    cmn.Bck{Name: host, Provider: provider},
+				prefix: strings.TrimPrefix(fullPath, "/"),
+			},
+		}, nil
 	}
 
 	normalizedURL := url.URL{
@@ -1046,6 +1058,9 @@ func bckPropList(props *cmn.BucketProps, verbose bool) (propList []prop, err err
 			{"mirror", props.Mirror.String()},
 			{"ec", props.EC.String()},
 			{"lru", props.LRU.String()},
+			{"lifecycle", props.Lifecycle.String()},
+			{"object_lock", props.ObjLock.String()},
+			{"rate_limit", props.RateLimit.String()},
 			{"versioning", props.Versioning.String()},
 		}
 		if props.Provider == cmn.ProviderHTTP {
@@ -1057,8 +1072,12 @@ func bckPropList(props *cmn.BucketProps, verbose bool) (propList []prop, err err
 	} else {
 		err = cmn.IterFields(props, func(uniqueTag string, field cmn.IterField) (err error, b bool) {
 			value := fmt.Sprintf("%v", field.Value())
-			if uniqueTag == cmn.HeaderBucketAccessAttrs {
+			switch uniqueTag {
+			case cmn.HeaderBucketAccessAttrs:
 				value = props.Access.Describe()
+			case "lifecycle.rules":
+				// the same (JSON) format that is used to set the rules
+				value, _ = jsoniter.MarshalToString(props.Lifecycle.Rules)
 			}
 			propList = append(propList, prop{
 				Name:  uniqueTag,
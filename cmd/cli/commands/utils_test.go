@@ -7,8 +7,13 @@ package commands
 import (
 	"reflect"
 	"testing"
+	"time"
 
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmd/cli/templates"
 	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/stats"
 	"github.com/urfave/cli"
 )
 
@@ -381,3 +386,146 @@ func TestParseBckObjectURI(t *testing.T) {
 		}
 	}
 }
+
+func TestParseRetainUntil(t *testing.T) {
+	now := time.Date(2020, 12, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		input    string
+		expected time.Time
+		valid    bool
+	}{
+		{"2021-01-01T00:00:00Z", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC), true},
+		{"30d", now.Add(30 * 24 * time.Hour), true},
+		{"1h30m", now.Add(90 * time.Minute), true},
+		{"0d", time.Time{}, false},
+		{"-1h", time.Time{}, false},
+		{"tomorrow", time.Time{}, false},
+	}
+	for _, test := range tests {
+		until, err := parseRetainUntil(test.input, now)
+		if (err == nil) != test.valid {
+			t.Errorf("parseRetainUntil(%q): expected valid=%t, got err=%v", test.input, test.valid, err)
+			continue
+		}
+		if test.valid && !until.Equal(test.expected) {
+			t.Errorf("parseRetainUntil(%q): expected %v, got %v", test.input, test.expected, until)
+		}
+	}
+}
+
+func TestSyncDiff(t *testing.T) {
+	var (
+		src = map[string]*syncEntry{
+			"same":     {name: "same", size: 1, cksum: "a"},
+			"modified": {name: "modified", size: 1, cksum: "a"},
+			"resized":  {name: "resized", size: 2, cksum: "a"},
+			"missing":  {name: "missing", size: 1, cksum: "a"},
+			"no-cksum": {name: "no-cksum", size: 1, cksum: "a"},
+		}
+		dst = map[string]*syncEntry{
+			"same":     {name: "same", size: 1, cksum: "a"},
+			"modified": {name: "modified", size: 1, cksum: "b"},
+			"resized":  {name: "resized", size: 1, cksum: "a"},
+			"no-cksum": {name: "no-cksum", size: 1},
+			"extra":    {name: "extra", size: 1},
+		}
+		s = &syncer{cksumType: cmn.ChecksumXXHash}
+	)
+	transfer, extra, err := syncDiff(src, dst, s.equal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	names := func(entries []*syncEntry) (names []string) {
+		for _, e := range entries {
+			names = append(names, e.name)
+		}
+		return
+	}
+	if expected := []string{"missing", "modified", "no-cksum", "resized"}; !reflect.DeepEqual(names(transfer), expected) {
+		t.Errorf("expected to transfer %v, got %v", expected, names(transfer))
+	}
+	if expected := []string{"extra"}; !reflect.DeepEqual(names(extra), expected) {
+		t.Errorf("expected extra %v, got %v", expected, names(extra))
+	}
+
+	// sizes only: checksums do not matter
+	s.cksumType = ""
+	transfer, _, err = syncDiff(src, dst, s.equal)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if expected := []string{"missing", "resized"}; !reflect.DeepEqual(names(transfer), expected) {
+		t.Errorf("expected to transfer %v, got %v", expected, names(transfer))
+	}
+}
+
+func TestDiffEmitList(t *testing.T) {
+	diff := &cmn.BucketDiff{Missing: []string{"m1", "m2"}, Extra: []string{"e"}, Different: []string{"d"}}
+	tests := []struct {
+		kinds    string
+		expected string
+		valid    bool
+	}{
+		{kinds: "missing", expected: "m1,m2", valid: true},
+		{kinds: "missing, different", expected: "m1,m2,d", valid: true},
+		{kinds: "extra", expected: "e", valid: true},
+		{kinds: "missing,unknown", valid: false},
+	}
+	for _, test := range tests {
+		list, err := diffEmitList(diff, test.kinds)
+		if (err == nil) != test.valid {
+			t.Errorf("%q: expected valid=%t, got err=%v", test.kinds, test.valid, err)
+		} else if test.valid && list != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.kinds, test.expected, list)
+		}
+	}
+}
+
+func TestDuRows(t *testing.T) {
+	usage := []cmn.PrefixUsage{
+		{Prefix: "b/", Target: "t1", Mountpath: "/mp1", ObjCount: 1, Size: 10},
+		{Prefix: "a/", Target: "t2", Mountpath: "/mp1", ObjCount: 2, Size: 20},
+		{Prefix: "a/", Target: "t1", Mountpath: "/mp2", ObjCount: 3, Size: 30},
+		{Prefix: "a/", Target: "t1", Mountpath: "/mp1", ObjCount: 4, Size: 40},
+	}
+	rows := duRows(usage, false, false)
+	expected := []cmn.PrefixUsage{{Prefix: "a/", ObjCount: 9, Size: 90}, {Prefix: "b/", ObjCount: 1, Size: 10}}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+	rows = duRows(usage, true, false)
+	expected = []cmn.PrefixUsage{
+		{Prefix: "a/", Target: "t1", ObjCount: 7, Size: 70},
+		{Prefix: "a/", Target: "t2", ObjCount: 2, Size: 20},
+		{Prefix: "b/", Target: "t1", ObjCount: 1, Size: 10},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+	if rows = duRows(usage, true, true); len(rows) != len(usage) || rows[0].Mountpath != "/mp1" || rows[3].Prefix != "b/" {
+		t.Errorf("unexpected per-mountpath rows: %v", rows)
+	}
+}
+
+func TestDomainStats(t *testing.T) {
+	smap := &cluster.Smap{Tmap: cluster.NodeMap{
+		"t1": {DaemonID: "t1", Zone: "z2", Rack: "r1"},
+		"t2": {DaemonID: "t2", Zone: "z1", Rack: "r2"},
+		"t3": {DaemonID: "t3", Zone: "z1", Rack: "r2", Flags: cluster.SnodeMaintenance},
+		"t4": {DaemonID: "t4", Zone: "z1", Rack: "r1"},
+	}}
+	daeMap := map[string]*stats.DaemonStatus{
+		"t1": {Capacity: fs.MPCap{"/mp1": {Used: 10, Avail: 30}}},
+		"t2": {Capacity: fs.MPCap{"/mp1": {Used: 10, Avail: 10}, "/mp2": {Used: 20, Avail: 20}}},
+		"t3": {Capacity: fs.MPCap{"/mp1": {Used: 20, Avail: 20}}},
+	}
+	rows := domainStats(smap, daeMap)
+	expected := []templates.DomainStats{
+		{Zone: "z1", Rack: "r1", Targets: 1},
+		{Zone: "z1", Rack: "r2", Targets: 2, Maintenance: 1, Used: 50, Avail: 50, UsedPct: 50},
+		{Zone: "z2", Rack: "r1", Targets: 1, Used: 10, Avail: 30, UsedPct: 25},
+	}
+	if !reflect.DeepEqual(rows, expected) {
+		t.Errorf("expected %v, got %v", expected, rows)
+	}
+}
@@ -54,6 +54,14 @@ const (
 		"Targets:\t{{len .Smap.Tmap}}\n Primary Proxy:\t{{.Smap.Primary.ID}}\n Smap Version:\t{{.Smap.Version}}\n " +
 		"Deployment:\t{{ ( Deployments .Status) }}\n"
 
+	// Failure domains (see DomainStats)
+	DomainsTmpl = "ZONE\t RACK\t TARGETS\t MAINTENANCE\t USED\t AVAIL\t USED %\n" +
+		"{{range $k, $v := . }}" +
+		"{{if $v.Zone}}{{$v.Zone}}{{else}}-{{end}}\t {{if $v.Rack}}{{$v.Rack}}{{else}}-{{end}}\t " +
+		"{{$v.Targets}}\t {{$v.Maintenance}}\t " +
+		"{{FormatBytesUnsigned $v.Used 2}}\t {{FormatBytesUnsigned $v.Avail 2}}\t {{FormatFloat $v.UsedPct}}%\n" +
+		"{{end}}"
+
 	// Disk Stats
 	DiskStatsHeader = "TARGET\t DISK\t READ\t WRITE\t UTIL %\n"
 
@@ -124,6 +132,13 @@ const (
 		"{{$v.Bck}}\t {{$v.ObjCount}}\t {{FormatBytesUnsigned $v.Size 2}}\t {{FormatFloat $v.UsedPct}}%\n" +
 		"{{end}}"
 
+	// For `ais du` (see cmn.PrefixUsage)
+	DiskUsageTmpl           = "PREFIX\t OBJECTS\t SIZE\n" + "{{range $k, $v := . }}" + "{{$v.Prefix}}\t " + diskUsageBody
+	DiskUsageTargetsTmpl    = "PREFIX\t TARGET\t OBJECTS\t SIZE\n" + "{{range $k, $v := . }}" + "{{$v.Prefix}}\t {{$v.Target}}\t " + diskUsageBody
+	DiskUsageMountpathsTmpl = "PREFIX\t TARGET\t MOUNTPATH\t OBJECTS\t SIZE\n" + "{{range $k, $v := . }}" +
+		"{{$v.Prefix}}\t {{$v.Target}}\t {{$v.Mountpath}}\t " + diskUsageBody
+	diskUsageBody = "{{$v.ObjCount}}\t {{FormatBytesUnsigned $v.Size 2}}\n{{end}}"
+
 	// For `object put` mass uploader. A caller adds to the template
 	// total count and size. That is why the template ends with \t
 	ExtensionTmpl = "Files to upload:\nEXTENSION\t COUNT\t SIZE\n" +
@@ -161,6 +176,11 @@ const (
 		"{{ $user.ID }}\t{{ JoinList $user.Roles }}\n" +
 		"{{end}}"
 
+	AuthNS3KeyTmpl = "KEY ID\tUSER\tISSUED\n" +
+		"{{ range $key := . }}" +
+		"{{ $key.ID }}\t{{ $key.UserID }}\t{{ FormatTime $key.Issued }}\n" +
+		"{{end}}"
+
 	// Command `search`
 	SearchTmpl = "{{ JoinListNL . }}\n"
 
@@ -198,6 +218,7 @@ var (
 		"type":       "{{$obj.Type}}",
 		"atime":      "{{$obj.Atime}}",
 		"version":    "{{$obj.Version}}",
+		"versions":   "{{$obj.Versions}}",
 		"target_url": "{{$obj.TargetURL}}",
 		"status":     "{{FormatObjStatus $obj}}",
 		"copies":     "{{$obj.Copies}}",
@@ -262,6 +283,17 @@ type (
 		ExtendedURLs bool
 	}
 
+	// Targets and their capacity aggregated by failure domain (zone and rack)
+	DomainStats struct {
+		Zone        string  `json:"zone"`
+		Rack        string  `json:"rack"`
+		Targets     int     `json:"targets"`
+		Maintenance int     `json:"maintenance"` // targets under maintenance or being decommissioned
+		Used        uint64  `json:"used,string"`
+		Avail       uint64  `json:"avail,string"`
+		UsedPct     float64 `json:"used_pct"`
+	}
+
 	DaemonStatusTemplateHelper struct {
 		Pmap map[string]*stats.DaemonStatus `json:"pmap"`
 		Tmap map[string]*stats.DaemonStatus `json:"tmap"`
@@ -7,6 +7,7 @@ package cmn
 import (
 	"fmt"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/NVIDIA/aistore/cmn/debug"
@@ -17,6 +18,7 @@ const (
 	SelectCached    = 1 << iota // list only cached (Cloud buckets only)
 	SelectMisplaced             // Include misplaced
 	SelectDeleted               // Include marked for deletion
+	SelectVersions              // Include retained prior versions (ais buckets only) - see GetPropsVersions
 )
 
 // ActionMsg is a JSON-formatted control structures for the REST API
@@ -33,6 +35,9 @@ type (
 		Overwrite bool   `json:"overwrite"`
 		KeepOrig  bool   `json:"keep_original"`
 	}
+	ActValRetention struct {
+		RetainUntil int64 `json:"retain_until,string"` // unix nano
+	}
 	ActValDecommision struct {
 		DaemonID      string `json:"sid"`
 		SkipRebalance bool   `json:"skip_rebalance"`
@@ -51,20 +56,38 @@ type (
 		ContinuationToken string `json:"continuation_token"` // `BucketList.ContinuationToken`
 		Flags             uint64 `json:"flags,string"`       // advanced filtering (SelectMsg extended flags)
 		UseCache          bool   `json:"use_cache"`          // use proxy cache to speed up listing objects
+		Delimiter         string `json:"delimiter"`          // return common prefixes ("directories") instead of their objects
 	}
 
 	BucketSummary struct {
 		Bck
-		ObjCount       uint64  `json:"count,string"`
-		Size           uint64  `json:"size,string"`
-		TotalDisksSize uint64  `json:"disks_size,string"`
-		UsedPct        float64 `json:"used_pct"`
+		ObjCount       uint64        `json:"count,string"`
+		Size           uint64        `json:"size,string"`
+		TotalDisksSize uint64        `json:"disks_size,string"`
+		UsedPct        float64       `json:"used_pct"`
+		Usage          []PrefixUsage `json:"usage,omitempty"` // see BucketSummaryMsg.DiskUsage
+	}
+	// PrefixUsage is the disk usage by objects under a given prefix ("directory")
+	// on a given target's mountpath. Sizes include mirrored copies.
+	PrefixUsage struct {
+		Prefix    string `json:"prefix"`
+		Target    string `json:"target"`
+		Mountpath string `json:"mountpath"`
+		ObjCount  uint64 `json:"count,string"`
+		Size      uint64 `json:"size,string"`
 	}
 	// BucketSummaryMsg represents options that can be set when asking for bucket summary.
 	BucketSummaryMsg struct {
 		UUID   string `json:"uuid"`
 		Fast   bool   `json:"fast"`
 		Cached bool   `json:"cached"`
+
+		// DiskUsage: walk the (in-cluster) objects with names starting with Prefix and
+		// report their disk usage aggregated by prefixes up to Depth levels below
+		// the Prefix (see UsagePrefix) - per target and per mountpath.
+		DiskUsage bool   `json:"disk_usage"`
+		Prefix    string `json:"prefix"`
+		Depth     int    `json:"depth"`
 	}
 	BucketsSummaries []BucketSummary
 
@@ -131,12 +154,24 @@ type (
 		// LRU is the embedded struct of the same name
 		LRU LRUConf `json:"lru"`
 
+		// Lifecycle rules: policy-based deletion and eviction (see LifecycleConf)
+		Lifecycle LifecycleConf `json:"lifecycle"`
+
+		// Object lock (WORM): retention of objects (see ObjLockConf)
+		ObjLock ObjLockConf `json:"object_lock"`
+
+		// Rate limits of the bucket's user traffic (see RateLimitConf)
+		RateLimit RateLimits `json:"rate_limit"`
+
 		// Mirror defines local-mirroring policy for the bucket
 		Mirror MirrorConf `json:"mirror"`
 
 		// Metadata write policy
 		MDWrite MDWritePolicy `json:"md_write"`
 
+		// Remote bucket: write-through (default) or write-back (asynchronous) PUT
+		RemoteWrite RemoteWritePolicy `json:"remote_write"`
+
 		// EC defines erasure coding setting for the bucket
 		EC ECConf `json:"ec"`
 
@@ -188,16 +223,20 @@ type (
 	// The struct may have extra fields that do not exist in BucketProps.
 	// Add tag 'copy:"skip"' to ignore those fields when copying values.
 	BucketPropsToUpdate struct {
-		BackendBck *BckToUpdate         `json:"backend_bck"`
-		Versioning *VersionConfToUpdate `json:"versioning"`
-		Cksum      *CksumConfToUpdate   `json:"checksum"`
-		LRU        *LRUConfToUpdate     `json:"lru"`
-		Mirror     *MirrorConfToUpdate  `json:"mirror"`
-		EC         *ECConfToUpdate      `json:"ec"`
-		Access     *AccessAttrs         `json:"access,string"`
-		MDWrite    *MDWritePolicy       `json:"md_write"`
-		Extra      *ExtraToUpdate       `json:"extra"`
-		Force      bool                 `json:"force" copy:"skip" list:"omit"`
+		BackendBck  *BckToUpdate           `json:"backend_bck"`
+		Versioning  *VersionConfToUpdate   `json:"versioning"`
+		Cksum       *CksumConfToUpdate     `json:"checksum"`
+		LRU         *LRUConfToUpdate       `json:"lru"`
+		Lifecycle   *LifecycleConfToUpdate `json:"lifecycle"`
+		ObjLock     *ObjLockConfToUpdate   `json:"object_lock"`
+		RateLimit   *RateLimitsToUpdate    `json:"rate_limit"`
+		Mirror      *MirrorConfToUpdate    `json:"mirror"`
+		EC          *ECConfToUpdate        `json:"ec"`
+		Access      *AccessAttrs           `json:"access,string"`
+		MDWrite     *MDWritePolicy         `json:"md_write"`
+		RemoteWrite *RemoteWritePolicy     `json:"remote_write"`
+		Extra       *ExtraToUpdate         `json:"extra"`
+		Force       bool                   `json:"force" copy:"skip" list:"omit"`
 	}
 
 	BckToUpdate struct {
@@ -220,12 +259,32 @@ type (
 		ParitySlices int              `list:"omit"`
 		IsECCopy     bool             `list:"omit"`
 		Present      bool             `json:"present"`
+		UserMD       SimpleKVs        `json:"user_md,omitempty" list:"omit"`
+		Tags         SimpleKVs        `json:"tags,omitempty" list:"omit"`
+		RetainUntil  int64            `json:"retain_until"` // see ObjLockConf
 	}
 
 	ObjectCksumProps struct {
 		Type  string `json:"type"`
 		Value string `json:"value"`
 	}
+
+	// WriteBackEntry describes an object that is committed locally and awaits
+	// asynchronous upload to its remote bucket (see RemoteWritePolicy).
+	WriteBackEntry struct {
+		Bck     Bck    `json:"bck"`
+		ObjName string `json:"name"`
+		Size    int64  `json:"size,string"`
+		Queued  int64  `json:"queued,string"` // unix nano
+		Retries int    `json:"retries"`
+		Err     string `json:"error,omitempty"` // last upload error, if any
+	}
+	// WriteBackInfo is a target's response to `GetWhatWriteBack` query:
+	// the total number of pending objects and (up to a limit) the oldest entries.
+	WriteBackInfo struct {
+		Total   int               `json:"total"`
+		Entries []*WriteBackEntry `json:"entries"`
+	}
 )
 
 // GetPropsDefault is a list of default (most relevant) `GetProps*` options.
@@ -237,7 +296,7 @@ var GetPropsDefault = []string{
 // GetPropsAll is a list of all `GetProps*` options.
 // NOTE: do **NOT** forget to update this array when a prop is added/removed.
 var GetPropsAll = append(GetPropsDefault,
-	GetPropsVersion, GetPropsCached, GetTargetURL, GetPropsStatus, GetPropsCopies, GetPropsEC,
+	GetPropsVersion, GetPropsCached, GetTargetURL, GetPropsStatus, GetPropsCopies, GetPropsEC, GetPropsVersions,
 )
 
 ///////////////
@@ -303,6 +362,22 @@ func (bs *BucketSummary) Aggregate(bckSummary BucketSummary) {
 	bs.Size += bckSummary.Size
 	bs.TotalDisksSize += bckSummary.TotalDisksSize
 	bs.UsedPct = float64(bs.Size) * 100 / float64(bs.TotalDisksSize)
+	bs.Usage = append(bs.Usage, bckSummary.Usage...)
+}
+
+// UsagePrefix returns the prefix ("directory") the object is accounted under
+// when the disk usage is aggregated `depth` levels below the `prefix`, e.g.:
+// ("a/b/c/d", "a/", 2) => "a/b/c/"; ("a/b", "a/", 2) => "a/".
+func UsagePrefix(objName, prefix string, depth int) string {
+	end := len(prefix)
+	for i := 0; i < depth; i++ {
+		idx := strings.IndexByte(objName[end:], '/')
+		if idx < 0 {
+			break
+		}
+		end += idx + 1
+	}
+	return objName[:end]
 }
 
 //////////////////////
@@ -349,6 +424,9 @@ func (c *VersionConf) String() string {
 	} else {
 		text += "no"
 	}
+	if c.Retain > 0 {
+		text += " | Retain: " + strconv.Itoa(c.Retain)
+	}
 
 	return text
 }
@@ -451,6 +529,7 @@ func DefaultBckProps(cs ...*Config) *BucketProps {
 		Versioning: c.Versioning,
 		Access:     AccessAll,
 		EC:         c.EC,
+		RateLimit:  c.RateLimit.Bucket,
 	}
 }
 
@@ -495,7 +574,10 @@ func (bp *BucketProps) Validate(targetCnt int) error {
 	var (
 		softErr        error
 		validationArgs = &ValidationArgs{Provider: bp.Provider, TargetCnt: targetCnt}
-		validators     = []PropsValidator{&bp.Cksum, &bp.LRU, &bp.Mirror, &bp.EC, &bp.Extra, bp.MDWrite}
+		validators     = []PropsValidator{
+			&bp.Cksum, &bp.LRU, &bp.Lifecycle, &bp.ObjLock, &bp.RateLimit, &bp.Mirror, &bp.EC, &bp.Extra,
+			bp.MDWrite, bp.RemoteWrite,
+		}
 	)
 	for _, validator := range validators {
 		if err := validator.ValidateAsProps(validationArgs); err != nil {
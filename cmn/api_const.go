@@ -51,11 +51,14 @@ const (
 	ActQueryObjects   = "queryobj"
 	ActInvalListCache = "invallistobjcache"
 	ActSummaryBck     = "summarybck"
+	ActDiffBck        = "diffbck" // compare two buckets (see BucketDiffMsg)
 	ActRenameObject   = "renameobj"
 	ActPromote        = "promote"
+	ActSetRetention   = "setretention" // extend object's retention (see ObjLockConf)
 	ActEvictObjects   = "evictobj"
 	ActDelete         = "delete"
 	ActPrefetch       = "prefetch"
+	ActGetBatch       = "getbatch" // read multiple objects in a single (streamed) response
 	ActDownload       = "download"
 	ActRegTarget      = "regtarget"
 	ActRegProxy       = "regproxy"
@@ -66,6 +69,7 @@ const (
 	ActPutCopies      = "putcopies"
 	ActMakeNCopies    = "makencopies"
 	ActLoadLomCache   = "loadlomcache"
+	ActLifecycle      = "lifecycle"
 	ActECGet          = "ecget"    // erasure decode objects
 	ActECPut          = "ecput"    // erasure encode objects
 	ActECRespond      = "ecresp"   // respond to other targets' EC requests
@@ -132,6 +136,8 @@ const (
 	HeaderObjCksumVal  = "checksum.value" // Checksum Value
 	HeaderObjAtime     = "atime"          // Object access time
 	HeaderObjCustomMD  = "custom_md"      // Object custom metadata
+	HeaderObjUserMD    = "user_md"        // User-defined object metadata (repeated key=value)
+	HeaderObjTags      = "tags"           // Object tags (repeated key=value)
 	HeaderObjSize      = "size"           // Object size (bytes)
 	HeaderObjVersion   = "version"        // Object version/generation - ais or Cloud
 	HeaderObjECMeta    = "ec_meta"        // Info about EC object/slice/replica
@@ -146,7 +152,9 @@ const (
 	HeaderNodeURL = "node.url"
 
 	// custom
-	HeaderAppendHandle = "append.handle"
+	HeaderAppendHandle     = "append.handle"
+	HeaderBypassGovernance = "bypass.governance" // true: override governance-mode retention (see ObjLockConf)
+	HeaderTrace            = "trace"             // true|false: trace the request (or not) regardless of TraceConf.SampleRatio
 
 	// intra-cluster: streams
 	HeaderSessID   = "session.id"
@@ -166,6 +174,7 @@ const (
 	URLParamWhat            = "what"         // "smap" | "bmd" | "config" | "stats" | "xaction" ...
 	URLParamProps           = "props"        // e.g. "checksum, size"|"atime, size"|"cached"|"bucket, size"| ...
 	URLParamCheckExists     = "check_cached" // true: check if object exists
+	URLParamVersion         = "version"      // prior version of the object (see VersionConf.Retain)
 	URLParamHealthReadiness = "readiness"    // true: check if node can accept HTTP(S) requests
 
 	URLParamProvider  = "provider" // backend provider
@@ -182,6 +191,8 @@ const (
 	URLParamPrepare          = "prp" // true: request belongs to the "prepare" phase of the primary proxy election
 	URLParamNonElectable     = "nel" // true: proxy is non-electable for the primary role
 	URLParamUnixTime         = "utm" // Unix time: number of nanoseconds elapsed since 01/01/70 UTC
+	URLParamTraceParent      = "tpr" // trace context of the redirecting proxy (see HeaderTraceParent)
+	URLParamUserID           = "uid" // AuthN user (as per the token validated by the redirecting proxy)
 	URLParamIsGFNRequest     = "gfn" // true if the request is a Get-From-Neighbor
 	URLParamSilent           = "sln" // true: destination should not log errors (HEAD request)
 	URLParamRebStatus        = "rbs" // true: get detailed rebalancing status
@@ -189,6 +200,8 @@ const (
 	URLParamTaskAction       = "tac" // "start", "status", "result"
 	URLParamClusterInfo      = "cii" // true: Health to return ais.clusterInfo
 	URLParamRecvType         = "rtp" // to tell real PUT from migration PUT
+	URLParamSigHost          = "sgh" // host of the presigned S3 request redirected by proxy
+	URLParamBatchPeer        = "bpr" // true: batch GET of the locally-owned objects on behalf of the designated target
 
 	URLParamAppendType   = "appendty"
 	URLParamAppendHandle = "handle"
@@ -238,6 +251,7 @@ const (
 	GetWhatStatus       = "status"    // JTX status by uuid
 	GetWhatICBundle     = "ic_bundle"
 	GetWhatTargetIPs    = "target_ips"
+	GetWhatWriteBack    = "writeback" // objects pending remote write (see RemoteWritePolicy)
 )
 
 // SelectMsg.TimeFormat enum
@@ -258,6 +272,7 @@ const (
 	GetPropsStatus   = "status"
 	GetPropsCopies   = "copies"
 	GetPropsEC       = "ec"
+	GetPropsVersions = "versions" // retained prior versions (see also SelectVersions)
 )
 
 // BucketEntry.Status
@@ -272,6 +287,7 @@ const (
 	EntryStatusBits = 5                          // N bits
 	EntryStatusMask = (1 << EntryStatusBits) - 1 // mask for N low bits
 	EntryIsCached   = 1 << (EntryStatusBits + 1) // StatusMaskBits + 1
+	EntryIsDir      = 1 << (EntryStatusBits + 2) // common prefix of objects (see SelectMsg.Delimiter)
 )
 
 // List objects default page size
@@ -300,11 +316,13 @@ const (
 	Rebalance = "rebalance"
 	Xactions  = "xactions"
 	S3        = "s3"
+	AZ        = "az"       // Azure Blob Storage compatibility
 	Txn       = "txn"      // 2PC
 	Notifs    = "notifs"   // intra-cluster notifications
 	Users     = "users"    // AuthN
 	Clusters  = "clusters" // AuthN
 	Roles     = "roles"    // AuthN
+	S3Keys    = "s3keys"   // AuthN
 	Query     = "query"
 	IC        = "ic" // information center
 
@@ -387,7 +405,20 @@ const (
 	WriteDefault = MDWritePolicy("") // equivalent to immediate writing (WriteImmediate)
 )
 
+// remote bucket data write policy
+type RemoteWritePolicy string
+
+func (p RemoteWritePolicy) IsWriteBack() bool { return p == WriteBack }
+
+const (
+	WriteThrough = RemoteWritePolicy("write_through") // PUT completes once the object is written remotely (default)
+	WriteBack    = RemoteWritePolicy("write_back")    // PUT completes once the object is stored locally, remote write is async
+
+	WriteRemoteDefault = RemoteWritePolicy("") // equivalent to write-through (WriteThrough)
+)
+
 var (
-	SupportedWritePolicy = []string{string(WriteImmediate), string(WriteDelayed), string(WriteNever)}
-	SupportedCompression = []string{CompressNever, CompressAlways}
+	SupportedWritePolicy       = []string{string(WriteImmediate), string(WriteDelayed), string(WriteNever)}
+	SupportedRemoteWritePolicy = []string{string(WriteThrough), string(WriteBack)}
+	SupportedCompression       = []string{CompressNever, CompressAlways}
 )
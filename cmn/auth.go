@@ -5,6 +5,9 @@
 package cmn
 
 import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"time"
@@ -58,6 +61,15 @@ type (
 		Clusters []*AuthCluster `json:"clusters"`
 		Buckets  []*AuthBucket  `json:"buckets,omitempty"`
 		IsAdmin  bool           `json:"admin"`
+		S3       bool           `json:"s3,omitempty"`  // S3 access key: valid only as a part of AWS signature
+		KeyID    string         `json:"kid,omitempty"` // S3 access key ID (to list and revoke keys)
+	}
+	// S3 access key issued by AuthN
+	AuthS3Key struct {
+		ID        string    `json:"id"`
+		UserID    string    `json:"user_id"`
+		Issued    time.Time `json:"issued"`
+		AccessKey string    `json:"access_key,omitempty"`
 	}
 	AuthClusterList struct {
 		Clusters map[string]*AuthCluster `json:"clusters,omitempty"`
@@ -204,3 +216,11 @@ func DecryptToken(tokenStr, secret string) (*AuthToken, error) {
 	}
 	return tInfo, nil
 }
+
+// S3 secret key is not stored anywhere: it is derived from the access key
+// (which is a token issued by AuthN) and the secret shared by AuthN and AIS.
+func S3SecretKey(accessKey, secret string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(accessKey))
+	return hex.EncodeToString(mac.Sum(nil))
+}
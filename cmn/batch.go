@@ -0,0 +1,272 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"archive/tar"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"time"
+)
+
+// Batch GET (ActGetBatch) reads multiple objects of a given bucket and streams
+// them back in a single response - one entry per requested object, in the
+// order of arrival (which is not necessarily the order of the request).
+//
+// Supported formats:
+// * BatchFormatTar - TAR archive; an object that cannot be read results in
+//   an empty entry carrying the error in its `BatchPAXError` PAX record;
+// * BatchFormatLP  - length-prefixed stream of entries, each formatted as:
+//   | name length (uint16) | name | status (uint8) | length (uint64) | payload |
+//   where payload is the object's content (batchStatusOK) or the error
+//   message (batchStatusErr). All integers are big-endian.
+
+const (
+	BatchFormatTar = "tar"
+	BatchFormatLP  = "lp"
+
+	BatchPAXError = "AIS.error" // PAX record that marks a missing (failed) entry
+
+	batchStatusOK  = 0
+	batchStatusErr = 1
+)
+
+type (
+	// GetBatchMsg selects the objects to read: either a list of names or
+	// a range template (e.g. "shard-{0000..9999}.tar") - but not both.
+	GetBatchMsg struct {
+		ListMsg
+		RangeMsg
+		Format string `json:"format,omitempty"` // BatchFormatTar (default) or BatchFormatLP
+	}
+
+	BatchEntryHdr struct {
+		Name string
+		Size int64
+		Err  string // non-empty: failed to read the object (size is zero)
+	}
+
+	BatchWriter interface {
+		// WriteEntry writes the header and exactly `hdr.Size` bytes read from `r`
+		// (r is ignored when hdr.Err is set).
+		WriteEntry(hdr *BatchEntryHdr, r io.Reader) error
+		Close() error
+	}
+	BatchReader interface {
+		// Next advances to the next entry and returns its header; the returned
+		// reader is valid until the next call. Returns io.EOF at the end.
+		Next() (*BatchEntryHdr, io.Reader, error)
+	}
+
+	tarBatchWriter struct {
+		tw *tar.Writer
+	}
+	tarBatchReader struct {
+		tr *tar.Reader
+	}
+	lpBatchWriter struct {
+		w   io.Writer
+		buf [8]byte
+	}
+	lpBatchReader struct {
+		r   io.Reader
+		buf [8]byte
+		lr  io.LimitedReader
+	}
+)
+
+var SupportedBatchFormats = []string{BatchFormatTar, BatchFormatLP}
+
+/////////////////
+// GetBatchMsg //
+/////////////////
+
+func (msg *GetBatchMsg) Validate() error {
+	switch {
+	case len(msg.ObjNames) == 0 && msg.Template == "":
+		return errors.New("batch GET: expecting either object names or range template")
+	case len(msg.ObjNames) > 0 && msg.Template != "":
+		return errors.New("batch GET: object names and range template are mutually exclusive")
+	}
+	switch msg.Format {
+	case "":
+		msg.Format = BatchFormatTar
+	case BatchFormatTar, BatchFormatLP:
+	default:
+		return fmt.Errorf("batch GET: invalid format %q (expected one of %v)", msg.Format, SupportedBatchFormats)
+	}
+	return nil
+}
+
+// Names returns the names of the objects to read.
+// NOTE: prefix-only templates (that would require listing the bucket) are not supported.
+func (msg *GetBatchMsg) Names() ([]string, error) {
+	if msg.Template == "" {
+		return msg.ObjNames, nil
+	}
+	pt, err := ParseBashTemplate(msg.Template)
+	if err != nil {
+		if pt, err = ParseAtTemplate(msg.Template); err != nil {
+			return nil, fmt.Errorf("batch GET: invalid range template %q: %v", msg.Template, err)
+		}
+	}
+	if len(pt.Ranges) == 0 {
+		return nil, fmt.Errorf("batch GET: range template %q does not contain ranges", msg.Template)
+	}
+	return pt.ToSlice(), nil
+}
+
+func BatchContentType(format string) string {
+	if format == BatchFormatLP {
+		return ContentBinary
+	}
+	return ContentTar
+}
+
+func NewBatchWriter(w io.Writer, format string) BatchWriter {
+	if format == BatchFormatLP {
+		return &lpBatchWriter{w: w}
+	}
+	return &tarBatchWriter{tw: tar.NewWriter(w)}
+}
+
+func NewBatchReader(r io.Reader, format string) BatchReader {
+	if format == BatchFormatLP {
+		return &lpBatchReader{r: r}
+	}
+	return &tarBatchReader{tr: tar.NewReader(r)}
+}
+
+/////////
+// TAR //
+/////////
+
+func (bw *tarBatchWriter) WriteEntry(hdr *BatchEntryHdr, r io.Reader) error {
+	th := &tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     hdr.Name,
+		Size:     hdr.Size,
+		Mode:     int64(PermRWR),
+		ModTime:  time.Now(),
+	}
+	if hdr.Err != "" {
+		th.Size = 0
+		th.PAXRecords = map[string]string{BatchPAXError: hdr.Err}
+		th.Format = tar.FormatPAX
+	}
+	if err := bw.tw.WriteHeader(th); err != nil {
+		return err
+	}
+	if th.Size == 0 {
+		return nil
+	}
+	_, err := io.CopyN(bw.tw, r, th.Size)
+	return err
+}
+
+func (bw *tarBatchWriter) Close() error { return bw.tw.Close() }
+
+func (br *tarBatchReader) Next() (*BatchEntryHdr, io.Reader, error) {
+	th, err := br.tr.Next()
+	if err != nil {
+		return nil, nil, err
+	}
+	hdr := &BatchEntryHdr{Name: th.Name, Size: th.Size, Err: th.PAXRecords[BatchPAXError]}
+	return hdr, br.tr, nil
+}
+
+//////////////////////
+// length-prefixed  //
+//////////////////////
+
+func (bw *lpBatchWriter) WriteEntry(hdr *BatchEntryHdr, r io.Reader) (err error) {
+	if len(hdr.Name) > math.MaxUint16 {
+		return fmt.Errorf("batch GET: object name is too long (%d)", len(hdr.Name))
+	}
+	var (
+		status = byte(batchStatusOK)
+		size   = hdr.Size
+	)
+	if hdr.Err != "" {
+		status, size = batchStatusErr, int64(len(hdr.Err))
+	}
+	binary.BigEndian.PutUint16(bw.buf[:2], uint16(len(hdr.Name)))
+	if _, err = bw.w.Write(bw.buf[:2]); err != nil {
+		return
+	}
+	if _, err = io.WriteString(bw.w, hdr.Name); err != nil {
+		return
+	}
+	bw.buf[0] = status
+	if _, err = bw.w.Write(bw.buf[:1]); err != nil {
+		return
+	}
+	binary.BigEndian.PutUint64(bw.buf[:], uint64(size))
+	if _, err = bw.w.Write(bw.buf[:]); err != nil {
+		return
+	}
+	if status == batchStatusErr {
+		_, err = io.WriteString(bw.w, hdr.Err)
+		return
+	}
+	_, err = io.CopyN(bw.w, r, size)
+	return
+}
+
+func (*lpBatchWriter) Close() error { return nil }
+
+func (br *lpBatchReader) Next() (*BatchEntryHdr, io.Reader, error) {
+	// skip the remainder of the previous entry, if any
+	if br.lr.N > 0 {
+		if _, err := io.Copy(ioutil.Discard, &br.lr); err != nil {
+			return nil, nil, err
+		}
+	}
+	if _, err := io.ReadFull(br.r, br.buf[:2]); err != nil {
+		return nil, nil, err // io.EOF at the entry boundary is the end of the stream
+	}
+	name := make([]byte, binary.BigEndian.Uint16(br.buf[:2]))
+	if _, err := io.ReadFull(br.r, name); err != nil {
+		return nil, nil, unexpectedEOF(err)
+	}
+	if _, err := io.ReadFull(br.r, br.buf[:1]); err != nil {
+		return nil, nil, unexpectedEOF(err)
+	}
+	status := br.buf[0]
+	if _, err := io.ReadFull(br.r, br.buf[:]); err != nil {
+		return nil, nil, unexpectedEOF(err)
+	}
+	var (
+		size = int64(binary.BigEndian.Uint64(br.buf[:]))
+		hdr  = &BatchEntryHdr{Name: string(name)}
+	)
+	switch status {
+	case batchStatusOK:
+		hdr.Size = size
+		br.lr = io.LimitedReader{R: br.r, N: size}
+		return hdr, &br.lr, nil
+	case batchStatusErr:
+		msg := make([]byte, size)
+		if _, err := io.ReadFull(br.r, msg); err != nil {
+			return nil, nil, unexpectedEOF(err)
+		}
+		hdr.Err = string(msg)
+		br.lr = io.LimitedReader{}
+		return hdr, &br.lr, nil
+	default:
+		return nil, nil, fmt.Errorf("batch GET: invalid entry %q status %d", hdr.Name, status)
+	}
+}
+
+func unexpectedEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
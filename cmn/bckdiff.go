@@ -0,0 +1,102 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"sort"
+)
+
+// Bucket diff: compares objects in two buckets (or a remote bucket's in-cluster
+// content with its backend) by name, size and, when applicable, checksum.
+//
+// Each target lists its own (locally stored) objects of both buckets; remote
+// buckets are listed once, by a single target designated for the task. The
+// proxy then merges the per-target listings (see BucketDiffLists) and computes
+// the resulting BucketDiff.
+//
+// Checksums are compared only when both buckets are listed in-cluster and are
+// configured with the same checksum type - otherwise the comparison is by size.
+//
+// NOTE: the proxy holds both (merged) listings in memory; hence, the number of
+// objects in each listing is limited by BucketDiffMaxObjects - larger buckets
+// can be compared piece by piece (see BucketDiffMsg.Prefix).
+
+// BucketDiffMaxObjects is the maximum number of objects listed in each of the
+// compared buckets.
+const BucketDiffMaxObjects = 1000000
+
+var ErrBucketDiffTooLarge = fmt.Errorf("too many objects to compare (max %d per bucket), use prefix "+
+	"to compare the buckets piece by piece", BucketDiffMaxObjects)
+
+type (
+	// BucketDiffMsg represents options that can be set when comparing buckets (see ActDiffBck).
+	BucketDiffMsg struct {
+		UUID    string `json:"uuid"`
+		Dst     Bck    `json:"dst"`     // bucket to compare with (ignored when `Backend` is set)
+		Prefix  string `json:"prefix"`  // compare only objects with names starting with prefix
+		Backend bool   `json:"backend"` // compare in-cluster objects of a remote bucket with its backend
+	}
+
+	// BucketDiffLists is a (single target's) portion of the source and destination listings.
+	BucketDiffLists struct {
+		Src []*BucketEntry `json:"src"`
+		Dst []*BucketEntry `json:"dst"`
+	}
+
+	// BucketDiff is the result of comparing two buckets.
+	BucketDiff struct {
+		Missing   []string `json:"missing"`     // in the source but not in the destination
+		Extra     []string `json:"extra"`       // in the destination but not in the source
+		Different []string `json:"different"`   // in both, with different size or checksum
+		Same      int64    `json:"same,string"` // number of identical objects
+		Cksum     bool     `json:"cksum"`       // true if checksums were compared
+	}
+)
+
+func (l *BucketDiffLists) Merge(other *BucketDiffLists) error {
+	l.Src = append(l.Src, other.Src...)
+	l.Dst = append(l.Dst, other.Dst...)
+	if len(l.Src) > BucketDiffMaxObjects || len(l.Dst) > BucketDiffMaxObjects {
+		return ErrBucketDiffTooLarge
+	}
+	return nil
+}
+
+// DiffBuckets compares source and destination entries; all resulting
+// lists of names are sorted.
+func DiffBuckets(src, dst []*BucketEntry, cksum bool) *BucketDiff {
+	var (
+		diff   = &BucketDiff{Cksum: cksum}
+		dstMap = make(map[string]*BucketEntry, len(dst))
+	)
+	for _, e := range dst {
+		dstMap[e.Name] = e
+	}
+	for _, s := range src {
+		d, ok := dstMap[s.Name]
+		if !ok {
+			diff.Missing = append(diff.Missing, s.Name)
+			continue
+		}
+		delete(dstMap, s.Name)
+		if s.Size != d.Size || (cksum && s.Checksum != d.Checksum) {
+			diff.Different = append(diff.Different, s.Name)
+		} else {
+			diff.Same++
+		}
+	}
+	for name := range dstMap {
+		diff.Extra = append(diff.Extra, name)
+	}
+	sort.Strings(diff.Missing)
+	sort.Strings(diff.Extra)
+	sort.Strings(diff.Different)
+	return diff
+}
+
+func (d *BucketDiff) InSync() bool {
+	return len(d.Missing) == 0 && len(d.Extra) == 0 && len(d.Different) == 0
+}
@@ -23,7 +23,7 @@ const (
 	ProviderGoogle = "gcp"
 	ProviderHDFS   = "hdfs"
 	ProviderHTTP   = "ht"
-	allProviders   = "ais, aws (s3://), gcp (gs://), azure (az://), hdfs://, ht://"
+	ProviderPOSIX  = "posix"
 
 	NsUUIDPrefix = '@' // BEWARE: used by on-disk layout
 	NsNamePrefix = '#' // BEWARE: used by on-disk layout
@@ -87,15 +87,6 @@ var (
 	// NsAnyRemote represents any remote cluster. As such, NsGlobalRemote applies
 	// exclusively to AIS (provider) given that other Backend providers are remote by definition.
 	NsAnyRemote = Ns{UUID: string(NsUUIDPrefix)}
-
-	Providers = NewStringSet(
-		ProviderAIS,
-		ProviderGoogle,
-		ProviderAmazon,
-		ProviderAzure,
-		ProviderHDFS,
-		ProviderHTTP,
-	)
 )
 
 // Parses [@uuid][#namespace]. It does a little bit more than just parsing
@@ -114,17 +105,13 @@ func ParseNsUname(s string) (n Ns) {
 	return
 }
 
-// Replace provider aliases with real provider names
+// Replace provider aliases (URI schemes) with real provider names
 func NormalizeProvider(provider string) (string, error) {
-	switch provider {
-	case "":
+	if provider == "" {
 		return "", nil
-	case GSScheme:
-		return ProviderGoogle, nil
-	case S3Scheme:
-		return ProviderAmazon, nil
-	case AZScheme:
-		return ProviderAzure, nil
+	}
+	if name, ok := providerScheme[provider]; ok {
+		return name, nil
 	}
 	if err := ValidateProvider(provider); err != nil {
 		return "", err
@@ -373,8 +360,9 @@ func (b Bck) IsRemoteAIS() bool { return b.Provider == ProviderAIS && b.Ns.IsRem
 func (b Bck) IsHDFS() bool      { return b.Provider == ProviderHDFS }
 func (b Bck) IsHTTP() bool      { return b.Provider == ProviderHTTP }
 
+// NOTE: any provider other than ais (including registered 3rd party ones) is remote
 func (b Bck) IsRemote() bool {
-	return b.IsCloud() || b.IsRemoteAIS() || b.IsHDFS() || b.IsHTTP() || b.HasBackendBck()
+	return b.IsRemoteAIS() || b.HasBackendBck() || (b.Provider != ProviderAIS && Providers.Contains(b.Provider))
 }
 
 func (b Bck) IsCloud() bool {
@@ -382,16 +370,16 @@ func (b Bck) IsCloud() bool {
 		debug.Assert(bck.IsCloud()) // Currently, backend bucket is always cloud.
 		return bck.IsCloud()
 	}
-	return b.Provider == ProviderAmazon || b.Provider == ProviderAzure || b.Provider == ProviderGoogle
+	return isCloudProvider(b.Provider)
 }
 
 func (b Bck) HasProvider() bool { return ValidateProvider(b.Provider) == nil }
 
 func ValidateProvider(provider string) error {
-	if _, ok := Providers[provider]; ok {
+	if Providers.Contains(provider) {
 		return nil
 	}
-	return fmt.Errorf("invalid backend provider %q: must be one of [%s]", provider, allProviders)
+	return fmt.Errorf("invalid backend provider %q: must be one of [%s]", provider, registeredProviders())
 }
 
 func (query QueryBcks) String() string     { return Bck(query).String() }
@@ -14,6 +14,7 @@ package cmn
 // 0-2: objects status, all statuses are mutually exclusive, so it can hold up
 //      to 8 different statuses. Now only OK=0, Moved=1, Deleted=2 are supported
 // 3:   CheckExists (for cloud bucket it shows if the object in local cache)
+// 4:   IsDir (the entry is a common prefix of objects, not an object)
 type BucketEntry struct {
 	Name      string `json:"name" msg:"n"`                            // name of the object - NOTE: Does not include the bucket name.
 	Size      int64  `json:"size,string,omitempty" msg:"s,omitempty"` // size in bytes
@@ -23,6 +24,7 @@ type BucketEntry struct {
 	TargetURL string `json:"target_url,omitempty" msg:"t,omitempty"`  // URL of target which has the entry
 	Copies    int16  `json:"copies,omitempty" msg:"c,omitempty"`      // ## copies (non-replicated = 1)
 	Flags     uint16 `json:"flags,omitempty" msg:"f,omitempty"`       // object flags, like CheckExists, IsMoved etc
+	Versions  string `json:"versions,omitempty" msg:"vs,omitempty"`   // retained prior versions, newest first (comma-separated)
 }
 
 func (be *BucketEntry) CheckExists() bool {
@@ -33,6 +35,10 @@ func (be *BucketEntry) SetExists() {
 	be.Flags |= EntryIsCached
 }
 
+func (be *BucketEntry) IsDir() bool {
+	return be.Flags&EntryIsDir != 0
+}
+
 func (be *BucketEntry) IsStatusOK() bool {
 	return be.Flags&EntryStatusMask == 0
 }
@@ -9,7 +9,6 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"net"
 	"net/url"
 	"path/filepath"
 	"strconv"
@@ -29,6 +28,12 @@ const (
 	KeepaliveAverageType   = "average"
 )
 
+// trace exporters (see TraceConf)
+const (
+	TraceExporterOTLP = "otlp" // OTLP/HTTP (JSON encoding) to a collector
+	TraceExporterFile = "file" // JSON lines appended to a local file
+)
+
 const (
 	ThrottleMin = time.Millisecond
 	ThrottleAvg = time.Millisecond * 10
@@ -126,6 +131,8 @@ type (
 		Downloader  DownloaderConf  `json:"downloader"`
 		DSort       DSortConf       `json:"distributed_sort"`
 		Compression CompressionConf `json:"compression"`
+		Trace       TraceConf       `json:"trace"`
+		RateLimit   RateLimitConf   `json:"rate_limit"`
 		MDWrite     MDWritePolicy   `json:"md_write"`
 	}
 
@@ -150,6 +157,8 @@ type (
 		Downloader  *DownloaderConfToUpdate  `json:"downloader"`
 		DSort       *DSortConfToUpdate       `json:"distributed_sort"`
 		Compression *CompressionConfToUpdate `json:"compression"`
+		Trace       *TraceConfToUpdate       `json:"trace"`
+		RateLimit   *RateLimitConfToUpdate   `json:"rate_limit"`
 		MDWrite     *MDWritePolicy           `json:"md_write"`
 
 		// Logging
@@ -182,6 +191,12 @@ type (
 		UseDatanodeHostname bool     `json:"use_datanode_hostname"`
 	}
 
+	// Root directory of a (shared) filesystem, e.g. an NFS mount; each sub-directory of the
+	// root is a bucket - see ais/backend/posix.go
+	BackendConfPOSIX struct {
+		Root string `json:"root"`
+	}
+
 	MirrorConf struct {
 		Copies      int64 `json:"copies"`       // num local copies
 		UtilThresh  int64 `json:"util_thresh"`  // considered equivalent when below threshold
@@ -406,11 +421,16 @@ type (
 
 		// Validate object version upon warm GET.
 		ValidateWarmGet bool `json:"validate_warm_get"`
+
+		// Number of prior versions to retain upon overwrite (ais buckets only);
+		// zero - no history.
+		Retain int `json:"retain"`
 	}
 
 	VersionConfToUpdate struct {
 		Enabled         *bool `json:"enabled"`
 		ValidateWarmGet *bool `json:"validate_warm_get"`
+		Retain          *int  `json:"retain"`
 	}
 
 	TestfspathConf struct {
@@ -560,6 +580,23 @@ type (
 		BlockMaxSize *int  `json:"block_size"`
 		Checksum     *bool `json:"checksum"`
 	}
+
+	// distributed tracing of user requests (see tracing package)
+	TraceConf struct {
+		Enabled     bool    `json:"enabled"`
+		SampleRatio float64 `json:"sample_ratio"` // [0, 1] - fraction of the requests to trace (see also HeaderTrace)
+		Exporter    string  `json:"exporter"`     // TraceExporterOTLP | TraceExporterFile
+		Endpoint    string  `json:"endpoint"`     // OTLP/HTTP collector, e.g. "http://localhost:4318/v1/traces"
+		Path        string  `json:"path"`         // file to append the spans to
+	}
+
+	TraceConfToUpdate struct {
+		Enabled     *bool    `json:"enabled"`
+		SampleRatio *float64 `json:"sample_ratio"`
+		Exporter    *string  `json:"exporter"`
+		Endpoint    *string  `json:"endpoint"`
+		Path        *string  `json:"path"`
+	}
 )
 
 // GCO stands for global config owner which is responsible for updating
@@ -682,6 +719,7 @@ var (
 	_ Validator = (*FSPathsConf)(nil)
 	_ Validator = (*TestfspathConf)(nil)
 	_ Validator = (*CompressionConf)(nil)
+	_ Validator = (*TraceConf)(nil)
 
 	_ PropsValidator = (*CksumConf)(nil)
 	_ PropsValidator = (*LRUConf)(nil)
@@ -742,73 +780,33 @@ func (c *BackendConf) MarshalJSON() (data []byte, err error) {
 
 func (c *BackendConf) Validate(_ *Config) (err error) {
 	for provider := range c.Conf {
-		b := MustMarshal(c.Conf[provider])
-		switch provider {
-		case ProviderAIS:
-			var aisConf BackendConfAIS
-			if err := jsoniter.Unmarshal(b, &aisConf); err != nil {
-				return fmt.Errorf("invalid cloud specification: %v", err)
-			}
-			for alias, urls := range aisConf {
-				if len(urls) == 0 {
-					return fmt.Errorf("no URL(s) to connect to remote AIS cluster %q", alias)
-				}
-				break
-			}
-			c.Conf[provider] = aisConf
-		case ProviderHDFS:
-			var hdfsConf BackendConfHDFS
-			if err := jsoniter.Unmarshal(b, &hdfsConf); err != nil {
-				return fmt.Errorf("invalid cloud specification: %v", err)
-			}
-			if len(hdfsConf.Addresses) == 0 {
-				return fmt.Errorf("no addresses provided to HDFS NameNode")
-			}
-
-			// Check connectivity and filter out non-reachable addresses.
-			reachableAddrs := hdfsConf.Addresses[:0]
-			for _, address := range hdfsConf.Addresses {
-				conn, err := net.DialTimeout("tcp", address, 5*time.Second)
-				if err != nil {
-					glog.Warningf(
-						"Failed to dial %q HDFS address, check connectivity to the HDFS cluster, err: %v",
-						address, err,
-					)
-					continue
-				}
-				conn.Close()
-				reachableAddrs = append(reachableAddrs, address)
-			}
-			hdfsConf.Addresses = reachableAddrs
-
-			// Re-check if there is any address reachable.
-			if len(hdfsConf.Addresses) == 0 {
-				return fmt.Errorf("no address provided to HDFS NameNode is reachable")
-			}
-
-			c.Conf[provider] = hdfsConf
-			c.setProvider(provider)
-		case "":
+		if provider == "" {
 			continue
-		default:
+		}
+		info, ok := GetProviderInfo(provider)
+		if !ok {
+			return fmt.Errorf("invalid backend config: %v", ValidateProvider(provider))
+		}
+		if info.ParseConf != nil {
+			conf, err := info.ParseConf(MustMarshal(c.Conf[provider]))
+			if err != nil {
+				return err
+			}
+			c.Conf[provider] = conf
+		}
+		if !info.AlwaysOn {
 			c.setProvider(provider)
 		}
 	}
 	return nil
 }
 
+// NOTE: all 3rd party backends are currently global (no namespaces)
 func (c *BackendConf) setProvider(provider string) {
-	var ns Ns
-	switch provider {
-	case ProviderAmazon, ProviderAzure, ProviderGoogle, ProviderHDFS:
-		ns = NsGlobal
-	default:
-		AssertMsg(false, "unknown backend provider "+provider)
-	}
 	if c.Providers == nil {
 		c.Providers = map[string]Ns{}
 	}
-	c.Providers[provider] = ns
+	c.Providers[provider] = NsGlobal
 }
 
 func (c *BackendConf) ProviderConf(provider string, newConf ...interface{}) (conf interface{}, ok bool) {
@@ -881,6 +879,12 @@ func (c *VersionConf) Validate(_ *Config) error {
 	if !c.Enabled && c.ValidateWarmGet {
 		return errors.New("versioning.validate_warm_get requires versioning to be enabled")
 	}
+	if c.Retain < 0 {
+		return fmt.Errorf("invalid versioning.retain: %d (expected non-negative value)", c.Retain)
+	}
+	if !c.Enabled && c.Retain > 0 {
+		return errors.New("versioning.retain requires versioning to be enabled")
+	}
 	return nil
 }
 
@@ -914,6 +918,15 @@ func (c MDWritePolicy) Validate(_ *Config) (err error) {
 
 func (c MDWritePolicy) ValidateAsProps(_ *ValidationArgs) (err error) { return c.Validate(nil) }
 
+func (c RemoteWritePolicy) Validate(_ *Config) (err error) {
+	if c == WriteRemoteDefault || c == WriteThrough || c == WriteBack {
+		return
+	}
+	return fmt.Errorf("invalid remote_write policy %q (expecting one of %v)", c, SupportedRemoteWritePolicy)
+}
+
+func (c RemoteWritePolicy) ValidateAsProps(_ *ValidationArgs) (err error) { return c.Validate(nil) }
+
 func (c *ECConf) Validate(_ *Config) error {
 	if c.ObjSizeLimit < 0 {
 		return fmt.Errorf("invalid ec.obj_size_limit: %d (expected >=0)", c.ObjSizeLimit)
@@ -1217,6 +1230,29 @@ func (c *CompressionConf) Validate(_ *Config) (err error) {
 	return nil
 }
 
+func (c *TraceConf) Validate(_ *Config) (err error) {
+	if c.SampleRatio < 0 || c.SampleRatio > 1 {
+		return fmt.Errorf("invalid trace.sample_ratio %f (expected value in range [0, 1])", c.SampleRatio)
+	}
+	if !c.Enabled {
+		return nil
+	}
+	switch c.Exporter {
+	case TraceExporterOTLP:
+		if c.Endpoint == "" {
+			return fmt.Errorf("trace.endpoint must be specified for %q exporter", c.Exporter)
+		}
+	case TraceExporterFile:
+		if c.Path == "" {
+			return fmt.Errorf("trace.path must be specified for %q exporter", c.Exporter)
+		}
+	default:
+		return fmt.Errorf("invalid trace.exporter %q (expected one of: %q, %q)",
+			c.Exporter, TraceExporterOTLP, TraceExporterFile)
+	}
+	return nil
+}
+
 func KeepaliveRetryDuration(cs ...*Config) time.Duration {
 	var c *Config
 	if len(cs) != 0 {
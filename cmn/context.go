@@ -17,7 +17,9 @@ type (
 )
 
 const (
-	CtxReadWrapper contextID = "readWrapper" // context key for ReadWrapperFunc
-	CtxSetSize     contextID = "setSize"     // context key for SetSizeFunc
-	CtxOriginalURL contextID = "origURL"     // context key for OriginalURL for HTTP cloud
+	CtxReadWrapper      contextID = "readWrapper"      // context key for ReadWrapperFunc
+	CtxSetSize          contextID = "setSize"          // context key for SetSizeFunc
+	CtxOriginalURL      contextID = "origURL"          // context key for OriginalURL for HTTP cloud
+	CtxBypassGovernance contextID = "bypassGovernance" // context key (bool): see HeaderBypassGovernance
+	CtxUserID           contextID = "userID"           // context key (string): authenticated user of the request
 )
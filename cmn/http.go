@@ -37,6 +37,16 @@ const (
 	HeaderLocation              = "Location"
 	HeaderETag                  = "ETag" // Ref: https://developer.mozilla.org/en-US/docs/Web/HTTP/Headers/ETag
 	HeaderError                 = "Header-Error"
+	HeaderRetryAfter            = "Retry-After" // Ref: https://tools.ietf.org/html/rfc7231#section-7.1.3
+
+	// conditional requests (preconditions) - Ref: https://tools.ietf.org/html/rfc7232#section-3
+	HeaderIfMatch           = "If-Match"
+	HeaderIfNoneMatch       = "If-None-Match"
+	HeaderIfModifiedSince   = "If-Modified-Since"
+	HeaderIfUnmodifiedSince = "If-Unmodified-Since"
+
+	// distributed tracing - Ref: https://www.w3.org/TR/trace-context/#traceparent-header
+	HeaderTraceParent = "traceparent"
 )
 
 // Ref: https://www.iana.org/assignments/media-types/media-types.xhtml
@@ -45,6 +55,9 @@ const (
 	ContentMsgPack = "application/msgpack"
 	ContentXML     = "application/xml"
 	ContentBinary  = "application/octet-stream"
+	ContentTar     = "application/x-tar"
+
+	ContentMultiRange = "multipart/byteranges" // Ref: https://tools.ietf.org/html/rfc7233#section-4.1
 )
 
 type (
@@ -488,6 +501,38 @@ func ParseMultiRange(s string, size int64) (ranges []HTTPRange, err error) {
 	return ranges, nil
 }
 
+// RangesSize returns the total size of the ranges (in bytes).
+func RangesSize(ranges []HTTPRange) (size int64) {
+	for _, r := range ranges {
+		size += r.Length
+	}
+	return
+}
+
+// MultiRangeHdr returns `Range` header for (possibly) multiple byte ranges.
+func MultiRangeHdr(ranges []HTTPRange) http.Header {
+	if len(ranges) == 0 {
+		return nil
+	}
+	specs := make([]string, 0, len(ranges))
+	for _, r := range ranges {
+		specs = append(specs, fmt.Sprintf("%d-%d", r.Start, r.Start+r.Length-1))
+	}
+	return http.Header{HeaderRange: []string{HeaderRangeValPrefix + strings.Join(specs, ",")}}
+}
+
+// ParseContentRange parses `Content-Range` header value, e.g. "bytes 0-99/1000".
+func ParseContentRange(s string) (r HTTPRange, size int64, err error) {
+	var start, end int64
+	if _, err = fmt.Sscanf(s, HeaderContentRangeValPrefix+"%d-%d/%d", &start, &end, &size); err != nil {
+		return r, 0, fmt.Errorf("invalid %s %q: %v", HeaderContentRange, s, err)
+	}
+	if start < 0 || end < start || end >= size {
+		return r, 0, fmt.Errorf("invalid %s %q", HeaderContentRange, s)
+	}
+	return HTTPRange{Start: start, Length: end - start + 1}, size, nil
+}
+
 func RangeHdr(start, length int64) (hdr http.Header) {
 	if start == 0 && length == 0 {
 		return hdr
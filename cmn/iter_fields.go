@@ -9,6 +9,8 @@ import (
 	"reflect"
 	"strconv"
 	"strings"
+
+	jsoniter "github.com/json-iterator/go"
 )
 
 const (
@@ -274,6 +276,11 @@ func (f *field) SetValue(src interface{}, force ...bool) error {
 				return err
 			}
 			dst.SetFloat(n)
+		case reflect.Slice:
+			// e.g. `lifecycle.rules`: the value is expected to be JSON-encoded
+			if err := jsoniter.UnmarshalFromString(s, dst.Addr().Interface()); err != nil {
+				return fmt.Errorf("invalid value for %q: %v", f.name, err)
+			}
 		case reflect.Ptr:
 			dst.Set(reflect.New(dst.Type().Elem())) // set pointer to default value
 			dst = dst.Elem()                        // dereference pointer
@@ -0,0 +1,174 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Bucket lifecycle: policy-based (as opposed to LRU's capacity-driven) removal of objects.
+//
+// Each rule selects objects by name prefix and applies one of the two actions
+// to the objects that are older than the specified number of days:
+// * LifecycleDelete - delete the object (in remote buckets: both the cached
+//   copy and the remote object); the age is the time since the object was
+//   last written (modified) - for objects cached from remote buckets, their
+//   last-modified time in the remote bucket. Migration (rebalance, resilver)
+//   does not change the age;
+// * LifecycleEvict - evict the cached copy of a remote object; the age is the
+//   time since the object was last accessed (atime). Not applicable to ais
+//   buckets (without backend bucket).
+//
+// The rules are evaluated by the periodic (and on-demand) `ActLifecycle` xaction
+// that visits the objects stored in the cluster - remote objects that are not
+// cached (present) in the cluster are not subject to lifecycle rules.
+// When both actions apply, delete takes precedence.
+
+const (
+	LifecycleDelete = "delete"
+	LifecycleEvict  = "evict"
+
+	lifecycleDay = 24 * time.Hour
+)
+
+type (
+	LifecycleConf struct {
+		Rules []LifecycleRule `json:"rules"`
+	}
+	LifecycleConfToUpdate struct {
+		Rules *[]LifecycleRule `json:"rules"`
+	}
+	LifecycleRule struct {
+		ID      string `json:"id"`
+		Prefix  string `json:"prefix"` // empty prefix selects all objects in the bucket
+		Action  string `json:"action"` // LifecycleDelete or LifecycleEvict
+		Days    int64  `json:"days"`   // minimum age (in days) of the objects to act upon
+		Enabled bool   `json:"enabled"`
+	}
+)
+
+var SupportedLifecycleActions = []string{LifecycleDelete, LifecycleEvict}
+
+// interface guard
+var _ PropsValidator = (*LifecycleConf)(nil)
+
+///////////////////
+// LifecycleRule //
+///////////////////
+
+func (r *LifecycleRule) Age() time.Duration { return time.Duration(r.Days) * lifecycleDay }
+
+func (r *LifecycleRule) Validate() error {
+	if r.ID == "" {
+		return fmt.Errorf("invalid lifecycle rule: empty ID")
+	}
+	if r.Action != LifecycleDelete && r.Action != LifecycleEvict {
+		return fmt.Errorf("invalid lifecycle rule %q: action %q (expected one of: %s)",
+			r.ID, r.Action, strings.Join(SupportedLifecycleActions, ", "))
+	}
+	if r.Days <= 0 {
+		return fmt.Errorf("invalid lifecycle rule %q: days %d (expected >0)", r.ID, r.Days)
+	}
+	return nil
+}
+
+func (r *LifecycleRule) String() string {
+	s := fmt.Sprintf("%s: %s %q after %dd", r.ID, r.Action, r.Prefix+"*", r.Days)
+	if !r.Enabled {
+		s += " (disabled)"
+	}
+	return s
+}
+
+///////////////////
+// LifecycleConf //
+///////////////////
+
+// Enabled returns true if the configuration contains at least one enabled rule.
+func (c *LifecycleConf) Enabled() bool {
+	for i := range c.Rules {
+		if c.Rules[i].Enabled {
+			return true
+		}
+	}
+	return false
+}
+
+// HasAction returns true if there's an enabled rule with the given action.
+func (c *LifecycleConf) HasAction(action string) bool {
+	for i := range c.Rules {
+		if c.Rules[i].Enabled && c.Rules[i].Action == action {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *LifecycleConf) Validate() error {
+	ids := make(StringSet, len(c.Rules))
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+		// (same ID can be shared by delete and evict rules - cf. S3 rule with both expiration and transition)
+		key := rule.ID + "/" + rule.Action
+		if ids.Contains(key) {
+			return fmt.Errorf("invalid lifecycle configuration: duplicate %s rule ID %q", rule.Action, rule.ID)
+		}
+		ids.Add(key)
+	}
+	return nil
+}
+
+func (c *LifecycleConf) ValidateAsProps(args *ValidationArgs) error {
+	if err := c.Validate(); err != nil {
+		return err
+	}
+	if args.Provider == ProviderHTTP && c.HasAction(LifecycleDelete) {
+		return fmt.Errorf("lifecycle action %q is not supported for %q buckets", LifecycleDelete, ProviderHTTP)
+	}
+	return nil
+}
+
+// Eval returns the action that applies to the object (or empty string if none).
+// `remote` must be false for ais buckets without backend (in which case
+// evict rules are ignored).
+func (c *LifecycleConf) Eval(objName string, atime, mtime, now time.Time, remote bool) string {
+	var evict bool
+	for i := range c.Rules {
+		rule := &c.Rules[i]
+		if !rule.Enabled || !strings.HasPrefix(objName, rule.Prefix) {
+			continue
+		}
+		switch rule.Action {
+		case LifecycleDelete:
+			if now.Sub(mtime) > rule.Age() {
+				return LifecycleDelete
+			}
+		case LifecycleEvict:
+			evict = evict || (remote && now.Sub(atime) > rule.Age())
+		}
+	}
+	if evict {
+		return LifecycleEvict
+	}
+	return ""
+}
+
+func (c *LifecycleConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	rules := make([]string, 0, len(c.Rules))
+	for i := range c.Rules {
+		if c.Rules[i].Enabled {
+			rules = append(rules, c.Rules[i].String())
+		}
+	}
+	return strings.Join(rules, " | ")
+}
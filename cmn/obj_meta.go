@@ -4,6 +4,13 @@
  */
 package cmn
 
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
 type (
 	ObjHeaderMetaProvider interface {
 		Size(special ...bool) int64
@@ -31,3 +38,75 @@ func NewHdrMetaCustomVersion(provider ObjHeaderMetaProvider, version string) *Hd
 		version:  version,
 	}
 }
+
+// User-defined object metadata and tags are stored along with other object's
+// metadata, and therefore their total size is limited.
+const (
+	MaxObjUserMDSize = 2 * KiB // user metadata and tags, combined
+	MaxObjTagsCnt    = 10
+	MaxObjTagKeyLen  = 128
+	MaxObjTagValLen  = 256
+)
+
+// ValidateObjUserMD validates user-defined metadata and tags of an object.
+func ValidateObjUserMD(userMD, tags SimpleKVs) error {
+	var size int
+	for k, v := range userMD {
+		if err := validateObjMDEntry(k, v); err != nil {
+			return err
+		}
+		size += len(k) + len(v)
+	}
+	if len(tags) > MaxObjTagsCnt {
+		return fmt.Errorf("number of object tags (%d) exceeds the limit (%d)", len(tags), MaxObjTagsCnt)
+	}
+	for k, v := range tags {
+		if err := validateObjMDEntry(k, v); err != nil {
+			return err
+		}
+		if len(k) > MaxObjTagKeyLen || len(v) > MaxObjTagValLen {
+			return fmt.Errorf("object tag %q is too long (max key length %d, max value length %d)",
+				k, MaxObjTagKeyLen, MaxObjTagValLen)
+		}
+		size += len(k) + len(v)
+	}
+	if size > MaxObjUserMDSize {
+		return fmt.Errorf("size of object metadata and tags (%d) exceeds the limit (%d)", size, MaxObjUserMDSize)
+	}
+	return nil
+}
+
+func validateObjMDEntry(k, v string) error {
+	if k == "" {
+		return errors.New("object metadata key cannot be empty")
+	}
+	if strings.ContainsAny(k, "=\x00\x01") || strings.ContainsAny(v, "\x00\x01") {
+		return fmt.Errorf("object metadata %q contains invalid characters", k)
+	}
+	return nil
+}
+
+// KVsToHdr adds key-value pairs to the header as repeated `key=value` entries
+// (see, e.g., HeaderObjUserMD and HeaderObjTags).
+func KVsToHdr(hdr http.Header, name string, kvs SimpleKVs) {
+	for k, v := range kvs {
+		hdr.Add(name, k+"="+v)
+	}
+}
+
+// KVsFromHdr is the reverse of KVsToHdr.
+func KVsFromHdr(hdr http.Header, name string) (SimpleKVs, error) {
+	entries := hdr[http.CanonicalHeaderKey(name)]
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	kvs := make(SimpleKVs, len(entries))
+	for _, entry := range entries {
+		kv := strings.SplitN(entry, "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid %s entry %q (expecting key=value)", name, entry)
+		}
+		kvs[kv[0]] = kv[1]
+	}
+	return kvs, nil
+}
@@ -0,0 +1,128 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Object lock (WORM): objects that cannot be deleted, overwritten, renamed, or
+// evicted until the end of their respective retention periods.
+//
+// The retention period of an object starts when the object gets written (PUT)
+// and lasts `ObjLockConf.Days`; it can be further extended on a per-object
+// basis (see ActSetRetention). The bucket's lock mode determines whether the
+// retention can be overridden:
+// * ObjLockGovernance - by users with admin permissions (AccessAdmin) that
+//   pass HeaderBypassGovernance with the request;
+// * ObjLockCompliance - not at all; in addition, compliance mode, once
+//   enabled, cannot be disabled, and its default retention cannot be shortened.
+
+const (
+	ObjLockGovernance = "governance"
+	ObjLockCompliance = "compliance"
+)
+
+type (
+	ObjLockConf struct {
+		Mode string `json:"mode"` // empty (disabled), ObjLockGovernance, or ObjLockCompliance
+		Days int64  `json:"days"` // default retention of new objects (zero - per-object retention only)
+	}
+	ObjLockConfToUpdate struct {
+		Mode *string `json:"mode"`
+		Days *int64  `json:"days"`
+	}
+
+	ErrObjLocked struct {
+		name  string
+		until time.Time
+	}
+)
+
+var SupportedObjLockModes = []string{ObjLockGovernance, ObjLockCompliance}
+
+// interface guard
+var _ PropsValidator = (*ObjLockConf)(nil)
+
+func (c *ObjLockConf) Enabled() bool            { return c.Mode != "" }
+func (c *ObjLockConf) Retention() time.Duration { return time.Duration(c.Days) * lifecycleDay }
+
+func (c *ObjLockConf) Validate() error {
+	if c.Mode != "" && !StringInSlice(c.Mode, SupportedObjLockModes) {
+		return fmt.Errorf("invalid object lock mode %q (expected one of: %s)",
+			c.Mode, strings.Join(SupportedObjLockModes, ", "))
+	}
+	if c.Days < 0 {
+		return fmt.Errorf("invalid object lock retention: days %d (expected >=0)", c.Days)
+	}
+	return nil
+}
+
+func (c *ObjLockConf) ValidateAsProps(*ValidationArgs) error { return c.Validate() }
+
+// ValidateUpdate makes sure that the compliance mode does not get relaxed.
+func (c *ObjLockConf) ValidateUpdate(nc *ObjLockConf) error {
+	if c.Mode != ObjLockCompliance {
+		return nil
+	}
+	if nc.Mode != ObjLockCompliance {
+		return fmt.Errorf("object lock: %s mode cannot be changed", ObjLockCompliance)
+	}
+	if nc.Days < c.Days {
+		return fmt.Errorf("object lock: %s retention cannot be shortened (%dd => %dd)",
+			ObjLockCompliance, c.Days, nc.Days)
+	}
+	return nil
+}
+
+// CheckRetention returns ErrObjLocked if the named object that is retained until
+// `until` cannot be deleted (overwritten, etc.) at the time `now`.
+func (c *ObjLockConf) CheckRetention(name string, until, now time.Time, bypass bool) error {
+	if !c.Enabled() || !now.Before(until) {
+		return nil
+	}
+	if bypass && c.Mode == ObjLockGovernance {
+		return nil
+	}
+	return &ErrObjLocked{name: name, until: until}
+}
+
+// CheckDestroy returns an error if the bucket cannot be destroyed (or evicted)
+// in its entirety - which would release all its objects regardless of retention.
+func (c *ObjLockConf) CheckDestroy(bck string, bypass bool) error {
+	switch c.Mode {
+	case ObjLockCompliance:
+		return fmt.Errorf("bucket %s is locked in %s mode", bck, ObjLockCompliance)
+	case ObjLockGovernance:
+		if !bypass {
+			return fmt.Errorf("bucket %s is locked in %s mode (the lock can be bypassed with %q header)",
+				bck, ObjLockGovernance, HeaderBypassGovernance)
+		}
+	}
+	return nil
+}
+
+func (c *ObjLockConf) String() string {
+	if !c.Enabled() {
+		return "Disabled"
+	}
+	return fmt.Sprintf("%s, retention %dd", c.Mode, c.Days)
+}
+
+//////////////////
+// ErrObjLocked //
+//////////////////
+
+func (e *ErrObjLocked) Error() string {
+	return fmt.Sprintf("%s is locked: retained until %s", e.name, e.until.UTC().Format(time.RFC3339))
+}
+
+func IsErrObjLocked(err error) bool {
+	var e *ErrObjLocked
+	return errors.As(err, &e)
+}
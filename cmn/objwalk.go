@@ -7,6 +7,7 @@ package cmn
 import (
 	"sort"
 	"strings"
+	"unicode/utf8"
 )
 
 func SortBckEntries(bckEntries []*BucketEntry) {
@@ -139,6 +140,45 @@ func MergeObjLists(lists []*BucketList, maxSize uint) (objs *BucketList) {
 	return bckList
 }
 
+// AggregateDirs replaces all entries that contain `delimiter` after `prefix`
+// with a single entry per common prefix ("directory"), e.g. with prefix "a/"
+// and delimiter "/" objects "a/b/c" and "a/b/d/e" become "a/b/" (see EntryIsDir).
+// The entries must be sorted.
+// If `skipDirs` is set (i.e., the continuation token is an object name) and the page
+// ends with a directory, the token is moved past the directory's content, so
+// that the next page does not return the same directory again.
+func (bl *BucketList) AggregateDirs(prefix, delimiter string, skipDirs bool) {
+	if delimiter == "" || len(bl.Entries) == 0 {
+		return
+	}
+	var (
+		lastDir string
+		entries = make([]*BucketEntry, 0, len(bl.Entries))
+	)
+	for _, e := range bl.Entries {
+		if !strings.HasPrefix(e.Name, prefix) {
+			entries = append(entries, e)
+			continue
+		}
+		idx := strings.Index(e.Name[len(prefix):], delimiter)
+		if idx < 0 {
+			entries = append(entries, e)
+			continue
+		}
+		dir := e.Name[:len(prefix)+idx+len(delimiter)]
+		if dir == lastDir {
+			continue
+		}
+		lastDir = dir
+		entries = append(entries, &BucketEntry{Name: dir, Flags: EntryIsDir})
+	}
+	bl.Entries = entries
+	if skipDirs && bl.ContinuationToken != "" && entries[len(entries)-1].IsDir() {
+		// every object name that starts with `lastDir` is less than the token
+		bl.ContinuationToken = lastDir + string(utf8.MaxRune)
+	}
+}
+
 // Returns true if given `token` includes given object name.
 // `token` includes an object name iff the object name would
 // be included in response having given continuation token.
@@ -0,0 +1,109 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Conditional requests (HTTP preconditions) as per RFC 7232.
+//
+// An object is identified by a set of entity tags - any of its checksum value,
+// version, and (S3) ETag - so that `If-Match` can be used to compare-and-swap on
+// either. Modification time is compared with one-second granularity (HTTP-date).
+// Invalid (unparsable) dates are ignored as per the RFC.
+
+type (
+	Preconds struct {
+		IfMatch           []string // unquoted entity tags; "*" matches any existing object
+		IfNoneMatch       []string // ditto
+		IfModifiedSince   time.Time
+		IfUnmodifiedSince time.Time
+	}
+	// the object to evaluate preconditions against
+	PrecondObj struct {
+		ETags  []string // empty tags are ignored
+		Mtime  time.Time
+		Exists bool
+	}
+)
+
+// ParsePreconds returns nil when the request has no preconditions.
+func ParsePreconds(hdr http.Header) *Preconds {
+	p := &Preconds{
+		IfMatch:     parseETags(hdr.Get(HeaderIfMatch)),
+		IfNoneMatch: parseETags(hdr.Get(HeaderIfNoneMatch)),
+	}
+	if v := hdr.Get(HeaderIfModifiedSince); v != "" {
+		p.IfModifiedSince, _ = http.ParseTime(v)
+	}
+	if v := hdr.Get(HeaderIfUnmodifiedSince); v != "" {
+		p.IfUnmodifiedSince, _ = http.ParseTime(v)
+	}
+	if len(p.IfMatch) == 0 && len(p.IfNoneMatch) == 0 && p.IfModifiedSince.IsZero() && p.IfUnmodifiedSince.IsZero() {
+		return nil
+	}
+	return p
+}
+
+func parseETags(s string) (tags []string) {
+	if s == "" {
+		return
+	}
+	for _, tag := range strings.Split(s, ",") {
+		tag = strings.TrimSpace(tag)
+		tag = strings.TrimPrefix(tag, "W/") // (weak comparison)
+		if tag = strings.Trim(tag, "\""); tag != "" {
+			tags = append(tags, tag)
+		}
+	}
+	return
+}
+
+// NeedMtime returns true if the evaluation requires object's modification time.
+func (p *Preconds) NeedMtime() bool {
+	return !p.IfModifiedSince.IsZero() || !p.IfUnmodifiedSince.IsZero()
+}
+
+// Eval evaluates the preconditions in the order specified by RFC 7232 (section 6)
+// and returns either zero (proceed with the request), or http.StatusNotModified
+// (GET and HEAD only - `read` is true), or http.StatusPreconditionFailed.
+func (p *Preconds) Eval(obj *PrecondObj, read bool) int {
+	mtime := obj.Mtime.Truncate(time.Second)
+	if len(p.IfMatch) > 0 {
+		if !obj.Exists || !obj.matches(p.IfMatch) {
+			return http.StatusPreconditionFailed
+		}
+	} else if !p.IfUnmodifiedSince.IsZero() && obj.Exists && mtime.After(p.IfUnmodifiedSince) {
+		return http.StatusPreconditionFailed
+	}
+	if len(p.IfNoneMatch) > 0 {
+		if obj.Exists && obj.matches(p.IfNoneMatch) {
+			if read {
+				return http.StatusNotModified
+			}
+			return http.StatusPreconditionFailed
+		}
+	} else if read && !p.IfModifiedSince.IsZero() && obj.Exists && !mtime.After(p.IfModifiedSince) {
+		return http.StatusNotModified
+	}
+	return 0
+}
+
+func (obj *PrecondObj) matches(tags []string) bool {
+	for _, tag := range tags {
+		if tag == "*" {
+			return true
+		}
+		for _, etag := range obj.ETags {
+			if etag != "" && etag == tag {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	jsoniter "github.com/json-iterator/go"
+)
+
+// Backend provider registry
+//
+// Every backend provider known to AIS (including the built-in ones) is described
+// by ProviderInfo and registered via RegisterProvider. The registry is consulted
+// when validating and normalizing provider names (including URI schemes, e.g.
+// `s3://` => `aws`), when validating per-provider config sections, and when
+// telling Cloud providers from the rest.
+//
+// NOTE: registration is only allowed at init time (i.e., from `init()` functions)
+// - the registry is read without locking.
+
+type (
+	ProviderInfo struct {
+		// Provider name, as stored in BMD and used in `provider://bucket` URIs.
+		Name string
+		// URI schemes that are accepted as aliases of the provider name (e.g., "s3" for "aws").
+		Schemes []string
+		// Cloud providers own their buckets: a bucket is added to the BMD upon first access.
+		Cloud bool
+		// The provider is always enabled and does not require a config section (ais, ht).
+		AlwaysOn bool
+		// ParseConf validates the provider's section of the `backend.conf` config
+		// and returns its parsed (typed) representation. Optional: if not defined,
+		// the section is kept as is.
+		ParseConf func(raw []byte) (conf interface{}, err error)
+	}
+)
+
+var (
+	Providers = NewStringSet() // registered provider names
+
+	providers      = make(map[string]*ProviderInfo, 8)
+	providerNames  = make([]string, 0, 8) // in the order of registration
+	providerScheme = make(map[string]string, 8)
+)
+
+func init() {
+	RegisterProvider(ProviderInfo{Name: ProviderAIS, AlwaysOn: true, ParseConf: parseConfAIS})
+	RegisterProvider(ProviderInfo{Name: ProviderAmazon, Schemes: []string{S3Scheme}, Cloud: true})
+	RegisterProvider(ProviderInfo{Name: ProviderGoogle, Schemes: []string{GSScheme}, Cloud: true})
+	RegisterProvider(ProviderInfo{Name: ProviderAzure, Schemes: []string{AZScheme}, Cloud: true})
+	RegisterProvider(ProviderInfo{Name: ProviderHDFS, ParseConf: parseConfHDFS})
+	RegisterProvider(ProviderInfo{Name: ProviderHTTP, AlwaysOn: true})
+	RegisterProvider(ProviderInfo{Name: ProviderPOSIX, Cloud: true, ParseConf: parseConfPOSIX})
+}
+
+// RegisterProvider adds a new backend provider. Must be called from `init()`.
+func RegisterProvider(info ProviderInfo) {
+	AssertMsg(info.Name != "" && !strings.Contains(info.Name, BckProviderSeparator),
+		"invalid backend provider name "+info.Name)
+	_, exists := providers[info.Name]
+	_, isScheme := providerScheme[info.Name]
+	AssertMsg(!exists && !isScheme, "duplicate backend provider "+info.Name)
+	for _, scheme := range info.Schemes {
+		_, exists := providerScheme[scheme]
+		AssertMsg(!exists && !Providers.Contains(scheme), "duplicate backend provider scheme "+scheme)
+		providerScheme[scheme] = info.Name
+	}
+	providers[info.Name] = &info
+	providerNames = append(providerNames, info.Name)
+	Providers.Add(info.Name)
+}
+
+// GetProviderInfo returns registered provider's info; the name must be normalized.
+func GetProviderInfo(provider string) (info *ProviderInfo, ok bool) {
+	info, ok = providers[provider]
+	return
+}
+
+// e.g.: "ais, aws (s3://), gcp (gs://), azure (az://), hdfs, ht, posix"
+func registeredProviders() string {
+	var sb strings.Builder
+	for i, name := range providerNames {
+		if i > 0 {
+			sb.WriteString(", ")
+		}
+		sb.WriteString(name)
+		for _, scheme := range providers[name].Schemes {
+			sb.WriteString(" (" + scheme + BckProviderSeparator + ")")
+		}
+	}
+	return sb.String()
+}
+
+func isCloudProvider(provider string) bool {
+	info, ok := providers[provider]
+	return ok && info.Cloud
+}
+
+//
+// built-in providers' config parsing
+//
+
+func parseConfAIS(raw []byte) (interface{}, error) {
+	var aisConf BackendConfAIS
+	if err := jsoniter.Unmarshal(raw, &aisConf); err != nil {
+		return nil, fmt.Errorf("invalid cloud specification: %v", err)
+	}
+	for alias, urls := range aisConf {
+		if len(urls) == 0 {
+			return nil, fmt.Errorf("no URL(s) to connect to remote AIS cluster %q", alias)
+		}
+		break
+	}
+	return aisConf, nil
+}
+
+func parseConfHDFS(raw []byte) (interface{}, error) {
+	var hdfsConf BackendConfHDFS
+	if err := jsoniter.Unmarshal(raw, &hdfsConf); err != nil {
+		return nil, fmt.Errorf("invalid cloud specification: %v", err)
+	}
+	if len(hdfsConf.Addresses) == 0 {
+		return nil, fmt.Errorf("no addresses provided to HDFS NameNode")
+	}
+
+	// Check connectivity and filter out non-reachable addresses.
+	reachableAddrs := hdfsConf.Addresses[:0]
+	for _, address := range hdfsConf.Addresses {
+		conn, err := net.DialTimeout("tcp", address, 5*time.Second)
+		if err != nil {
+			glog.Warningf(
+				"Failed to dial %q HDFS address, check connectivity to the HDFS cluster, err: %v",
+				address, err,
+			)
+			continue
+		}
+		conn.Close()
+		reachableAddrs = append(reachableAddrs, address)
+	}
+	hdfsConf.Addresses = reachableAddrs
+
+	// Re-check if there is any address reachable.
+	if len(hdfsConf.Addresses) == 0 {
+		return nil, fmt.Errorf("no address provided to HDFS NameNode is reachable")
+	}
+	return hdfsConf, nil
+}
+
+func parseConfPOSIX(raw []byte) (interface{}, error) {
+	var posixConf BackendConfPOSIX
+	if err := jsoniter.Unmarshal(raw, &posixConf); err != nil {
+		return nil, fmt.Errorf("invalid cloud specification: %v", err)
+	}
+	if posixConf.Root == "" {
+		return nil, fmt.Errorf("no root directory provided for %q backend", ProviderPOSIX)
+	}
+	if !filepath.IsAbs(posixConf.Root) {
+		return nil, fmt.Errorf("%q backend root directory %q must be an absolute path", ProviderPOSIX, posixConf.Root)
+	}
+	posixConf.Root = filepath.Clean(posixConf.Root)
+	return posixConf, nil
+}
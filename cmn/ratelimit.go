@@ -0,0 +1,194 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn/mono"
+)
+
+// Rate limiting (QoS) of user traffic: requests per second and bandwidth
+// (bytes per second) - per bucket and per authenticated user.
+//
+// The limits are cluster-wide. Each bucket inherits the default
+// `RateLimitConf.Bucket` at creation time and can override it via bucket
+// props; each AuthN user is limited by `RateLimitConf.User`. Zero means
+// unlimited. Requests that exceed their limits fail with 429 (Too Many Requests).
+
+type (
+	RateLimits struct {
+		RPS       int64 `json:"rps"`       // requests per second
+		Bandwidth int64 `json:"bandwidth"` // bytes per second
+	}
+	RateLimitsToUpdate struct {
+		RPS       *int64 `json:"rps"`
+		Bandwidth *int64 `json:"bandwidth"`
+	}
+
+	RateLimitConf struct {
+		Enabled bool       `json:"enabled"`
+		Bucket  RateLimits `json:"bucket"` // default limits of new buckets (see BucketProps.RateLimit)
+		User    RateLimits `json:"user"`   // limits of each authenticated user
+	}
+	RateLimitConfToUpdate struct {
+		Enabled *bool               `json:"enabled"`
+		Bucket  *RateLimitsToUpdate `json:"bucket"`
+		User    *RateLimitsToUpdate `json:"user"`
+	}
+
+	// RateLimiter is a token bucket that holds up to one second worth of tokens.
+	// The tokens are either acquired (TryAcquire) prior to performing an
+	// operation, or reserved (Reserve) - in which case the limiter may go into
+	// debt, and the caller is expected to wait until the debt is paid off.
+	RateLimiter struct {
+		mu     sync.Mutex
+		rate   float64 // tokens per second
+		tokens float64 // negative when in debt
+		last   int64   // mono time of the last refill
+	}
+)
+
+// interface guard
+var (
+	_ Validator      = (*RateLimitConf)(nil)
+	_ PropsValidator = (*RateLimits)(nil)
+)
+
+////////////////
+// RateLimits //
+////////////////
+
+func (c *RateLimits) IsZero() bool { return c.RPS == 0 && c.Bandwidth == 0 }
+
+func (c *RateLimits) validate(prefix string) error {
+	if c.RPS < 0 {
+		return fmt.Errorf("invalid %srps %d (expected >=0)", prefix, c.RPS)
+	}
+	if c.Bandwidth < 0 {
+		return fmt.Errorf("invalid %sbandwidth %d (expected >=0)", prefix, c.Bandwidth)
+	}
+	return nil
+}
+
+func (c *RateLimits) ValidateAsProps(*ValidationArgs) error { return c.validate("rate_limit.") }
+
+func (c *RateLimits) String() string {
+	if c.IsZero() {
+		return "Unlimited"
+	}
+	rps, bw := "unlimited", "unlimited"
+	if c.RPS > 0 {
+		rps = fmt.Sprintf("%d/s", c.RPS)
+	}
+	if c.Bandwidth > 0 {
+		bw = B2S(c.Bandwidth, 2) + "/s"
+	}
+	return fmt.Sprintf("Requests: %s | Bandwidth: %s", rps, bw)
+}
+
+///////////////////
+// RateLimitConf //
+///////////////////
+
+func (c *RateLimitConf) Validate(_ *Config) error {
+	if err := c.Bucket.validate("rate_limit.bucket."); err != nil {
+		return err
+	}
+	return c.User.validate("rate_limit.user.")
+}
+
+/////////////////
+// RateLimiter //
+/////////////////
+
+func NewRateLimiter(rate float64) *RateLimiter {
+	rl := &RateLimiter{rate: rate, last: mono.NanoTime()}
+	rl.tokens = rl.burst()
+	return rl
+}
+
+func (rl *RateLimiter) burst() float64 {
+	if rl.rate < 1 {
+		return 1
+	}
+	return rl.rate
+}
+
+// SetRate changes the rate (e.g., when the limit or the number of nodes changes).
+func (rl *RateLimiter) SetRate(rate float64) {
+	rl.mu.Lock()
+	if rl.rate != rate {
+		rl.refill(mono.NanoTime())
+		rl.rate = rate
+		if burst := rl.burst(); rl.tokens > burst {
+			rl.tokens = burst
+		}
+	}
+	rl.mu.Unlock()
+}
+
+func (rl *RateLimiter) refill(now int64) {
+	elapsed := time.Duration(now - rl.last)
+	rl.last = now
+	if elapsed <= 0 {
+		return
+	}
+	rl.tokens += elapsed.Seconds() * rl.rate
+	if burst := rl.burst(); rl.tokens > burst {
+		rl.tokens = burst
+	}
+}
+
+// TryAcquire takes `n` tokens if available and returns false otherwise.
+func (rl *RateLimiter) TryAcquire(n int64) (ok bool) {
+	rl.mu.Lock()
+	rl.refill(mono.NanoTime())
+	if ok = rl.tokens >= float64(n); ok {
+		rl.tokens -= float64(n)
+	}
+	rl.mu.Unlock()
+	return
+}
+
+// Reserve unconditionally takes `n` tokens, possibly going into debt, and
+// returns the time the caller must wait for the reserved tokens to refill.
+func (rl *RateLimiter) Reserve(n int64) (d time.Duration) {
+	rl.mu.Lock()
+	rl.refill(mono.NanoTime())
+	rl.tokens -= float64(n)
+	d = rl.delay(0)
+	rl.mu.Unlock()
+	return
+}
+
+// Delay returns the time until `n` tokens are available (zero if available now).
+func (rl *RateLimiter) Delay(n int64) (d time.Duration) {
+	rl.mu.Lock()
+	rl.refill(mono.NanoTime())
+	d = rl.delay(n)
+	rl.mu.Unlock()
+	return
+}
+
+func (rl *RateLimiter) delay(n int64) time.Duration {
+	missing := float64(n) - rl.tokens
+	if missing <= 0 {
+		return 0
+	}
+	return time.Duration(missing / rl.rate * float64(time.Second))
+}
+
+// Full returns true if the limiter has all its tokens, i.e. it has been idle
+// for a while and can be discarded (and later recreated) with no effect.
+func (rl *RateLimiter) Full() (full bool) {
+	rl.mu.Lock()
+	rl.refill(mono.NanoTime())
+	full = rl.tokens >= rl.burst()
+	rl.mu.Unlock()
+	return
+}
@@ -0,0 +1,368 @@
+// Package cmn provides common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package cmn
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// AWS Signature Version 4, see:
+// https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-authenticating-requests.html
+
+const (
+	SigV4Algorithm       = "AWS4-HMAC-SHA256"
+	SigV4UnsignedPayload = "UNSIGNED-PAYLOAD"
+	SigV4StreamingPrefix = "STREAMING-" // chunked upload, each chunk is signed separately
+	SigV4TimeFormat      = "20060102T150405Z"
+	SigV4MaxExpires      = 7 * 24 * time.Hour // the maximum lifetime of a presigned URL
+	SigV4DefaultRegion   = "us-east-1"
+
+	sigV4DateFormat = "20060102"
+	sigV4Service    = "s3"
+	sigV4Terminator = "aws4_request"
+	sigV4MaxSkew    = 15 * time.Minute // the maximum difference between request time and server time
+
+	// headers
+	HeaderAmzDate          = "X-Amz-Date"
+	HeaderAmzContentSHA256 = "X-Amz-Content-Sha256"
+
+	// presigned URL query parameters
+	URLParamAmzAlgorithm     = "X-Amz-Algorithm"
+	URLParamAmzCredential    = "X-Amz-Credential"
+	URLParamAmzDate          = "X-Amz-Date"
+	URLParamAmzExpires       = "X-Amz-Expires"
+	URLParamAmzSignedHeaders = "X-Amz-SignedHeaders"
+	URLParamAmzSignature     = "X-Amz-Signature"
+)
+
+type (
+	// sigV4PayloadReader computes SHA256 of the payload while it is being read,
+	// and fails the final read (EOF) if the checksum does not match the signed one.
+	sigV4PayloadReader struct {
+		io.ReadCloser
+		h        hash.Hash
+		expected string
+	}
+
+	// SigV4 contains the signature and signing parameters extracted either
+	// from the `Authorization` header or from the query of a presigned URL.
+	SigV4 struct {
+		AccessKey     string
+		Date          string // YYYYMMDD - as in the credential scope
+		Region        string
+		Service       string
+		SignedHeaders []string
+		Signature     string
+		Time          time.Time     // request time (X-Amz-Date)
+		Expires       time.Duration // presigned URL only
+		Presigned     bool
+	}
+)
+
+var (
+	ErrSigV4NotSigned = errors.New("request is not signed with AWS signature V4")
+	ErrSigV4Mismatch  = errors.New("AWS signature V4 does not match")
+	ErrSigV4Expired   = errors.New("AWS signature V4 expired")
+
+	ErrSigV4PayloadMismatch = errors.New("SHA256 of the payload does not match " + HeaderAmzContentSHA256)
+)
+
+// ParseSigV4 extracts signature V4 from a request. Returns `ErrSigV4NotSigned`
+// if the request contains neither signed header nor signed query.
+func ParseSigV4(r *http.Request) (*SigV4, error) {
+	if auth := r.Header.Get(HeaderAuthorization); strings.HasPrefix(auth, SigV4Algorithm+" ") {
+		return parseSigV4Header(r, auth[len(SigV4Algorithm)+1:])
+	}
+	q := r.URL.Query()
+	if q.Get(URLParamAmzAlgorithm) == SigV4Algorithm {
+		return parseSigV4Query(q)
+	}
+	return nil, ErrSigV4NotSigned
+}
+
+// Parses the value of the header (the algorithm prefix is already removed), e.g.:
+// "Credential=AKID/20130524/us-east-1/s3/aws4_request, SignedHeaders=host;range;x-amz-date, Signature=fe5f80f77d..."
+func parseSigV4Header(r *http.Request, auth string) (sig *SigV4, err error) {
+	var cred, signed string
+	sig = &SigV4{}
+	for _, part := range strings.Split(auth, ",") {
+		kv := strings.SplitN(strings.TrimSpace(part), "=", 2)
+		if len(kv) != 2 {
+			return nil, fmt.Errorf("invalid AWS signature V4 header: %q", auth)
+		}
+		switch kv[0] {
+		case "Credential":
+			cred = kv[1]
+		case "SignedHeaders":
+			signed = kv[1]
+		case "Signature":
+			sig.Signature = kv[1]
+		}
+	}
+	if err = sig.parseCredential(cred); err != nil {
+		return nil, err
+	}
+	if signed == "" || sig.Signature == "" {
+		return nil, fmt.Errorf("invalid AWS signature V4 header: %q", auth)
+	}
+	sig.SignedHeaders = strings.Split(signed, ";")
+	amzDate := r.Header.Get(HeaderAmzDate)
+	if amzDate == "" {
+		return nil, fmt.Errorf("AWS signature V4: missing %q header", HeaderAmzDate)
+	}
+	if sig.Time, err = time.Parse(SigV4TimeFormat, amzDate); err != nil {
+		return nil, fmt.Errorf("AWS signature V4: invalid %q header: %v", HeaderAmzDate, err)
+	}
+	return sig, nil
+}
+
+func parseSigV4Query(q url.Values) (sig *SigV4, err error) {
+	sig = &SigV4{Presigned: true, Signature: q.Get(URLParamAmzSignature)}
+	if err = sig.parseCredential(q.Get(URLParamAmzCredential)); err != nil {
+		return nil, err
+	}
+	signed := q.Get(URLParamAmzSignedHeaders)
+	if signed == "" || sig.Signature == "" {
+		return nil, errors.New("invalid AWS signature V4 query: missing signed headers or signature")
+	}
+	sig.SignedHeaders = strings.Split(signed, ";")
+	if sig.Time, err = time.Parse(SigV4TimeFormat, q.Get(URLParamAmzDate)); err != nil {
+		return nil, fmt.Errorf("AWS signature V4: invalid %q: %v", URLParamAmzDate, err)
+	}
+	secs, err := strconv.ParseInt(q.Get(URLParamAmzExpires), 10, 64)
+	if err != nil || secs <= 0 || time.Duration(secs)*time.Second > SigV4MaxExpires {
+		return nil, fmt.Errorf("AWS signature V4: invalid %q: %q", URLParamAmzExpires, q.Get(URLParamAmzExpires))
+	}
+	sig.Expires = time.Duration(secs) * time.Second
+	return sig, nil
+}
+
+// AKID/20130524/us-east-1/s3/aws4_request
+func (sig *SigV4) parseCredential(cred string) error {
+	parts := strings.Split(cred, "/")
+	if len(parts) != 5 || parts[0] == "" || parts[4] != sigV4Terminator {
+		return fmt.Errorf("invalid AWS signature V4 credential: %q", cred)
+	}
+	sig.AccessKey, sig.Date, sig.Region, sig.Service = parts[0], parts[1], parts[2], parts[3]
+	return nil
+}
+
+func (sig *SigV4) scope() string {
+	return sig.Date + "/" + sig.Region + "/" + sig.Service + "/" + sigV4Terminator
+}
+
+// Verify validates request time (or presigned URL expiration) and recomputes
+// the signature using the provided secret key.
+func (sig *SigV4) Verify(r *http.Request, secretKey string) error {
+	return sig.VerifyAs(r, r.Host, r.URL.Query(), secretKey)
+}
+
+// VerifyAs is Verify for a request that was redirected: `host` and `query`
+// are those of the original (signed) request.
+func (sig *SigV4) VerifyAs(r *http.Request, host string, query url.Values, secretKey string) error {
+	now := time.Now()
+	if sig.Presigned {
+		if now.After(sig.Time.Add(sig.Expires)) {
+			return ErrSigV4Expired
+		}
+		if sig.Time.Sub(now) > sigV4MaxSkew {
+			return fmt.Errorf("AWS signature V4: request time %s is in the future", sig.Time)
+		}
+	} else {
+		if skew := now.Sub(sig.Time); skew > sigV4MaxSkew || -skew > sigV4MaxSkew {
+			return fmt.Errorf("AWS signature V4: request time %s is too skewed", sig.Time)
+		}
+	}
+	if sig.Date != sig.Time.UTC().Format(sigV4DateFormat) {
+		return fmt.Errorf("AWS signature V4: credential date %q does not match request time", sig.Date)
+	}
+
+	if !hmac.Equal([]byte(sig.compute(r, host, query, secretKey)), []byte(sig.Signature)) {
+		return ErrSigV4Mismatch
+	}
+	return nil
+}
+
+func (sig *SigV4) compute(r *http.Request, host string, query url.Values, secretKey string) string {
+	payloadHash := SigV4UnsignedPayload
+	if sig.Presigned {
+		signed := make(url.Values, len(query))
+		for k, vs := range query {
+			if k != URLParamAmzSignature {
+				signed[k] = vs
+			}
+		}
+		query = signed
+	} else if h := r.Header.Get(HeaderAmzContentSHA256); h != "" {
+		// NOTE: only the header is signed - the payload itself must be
+		// verified by whoever reads it (see NewSigV4PayloadReader)
+		payloadHash = h
+	}
+	canonical := sigV4CanonicalRequest(r.Method, r.URL.Path, query, r.Header, host, sig.SignedHeaders, payloadHash)
+	return sigV4Sign(secretKey, sig.Date, sig.Region, sig.Service, sigV4StringToSign(sig.Time, sig.scope(), canonical))
+}
+
+// NewSigV4PayloadReader wraps the body of a request signed with the
+// (signed) SHA256 of its payload - see HeaderAmzContentSHA256. The returned
+// reader fails with ErrSigV4PayloadMismatch upon reaching the end of a payload
+// that does not match. Unsigned (and chunk-signed) payloads are returned as is.
+func NewSigV4PayloadReader(body io.ReadCloser, hdr http.Header) io.ReadCloser {
+	expected := hdr.Get(HeaderAmzContentSHA256)
+	if body == nil || expected == "" || expected == SigV4UnsignedPayload ||
+		strings.HasPrefix(expected, SigV4StreamingPrefix) {
+		return body
+	}
+	return &sigV4PayloadReader{ReadCloser: body, h: sha256.New(), expected: strings.ToLower(expected)}
+}
+
+func (pr *sigV4PayloadReader) Read(b []byte) (n int, err error) {
+	n, err = pr.ReadCloser.Read(b)
+	pr.h.Write(b[:n])
+	if err == io.EOF && hex.EncodeToString(pr.h.Sum(nil)) != pr.expected {
+		err = ErrSigV4PayloadMismatch
+	}
+	return
+}
+
+// PresignURL returns `rawURL` with the query that contains signature V4
+// of the request `method rawURL` valid for `expires` since now.
+// The signature covers only the host header and the URL (unsigned payload).
+func PresignURL(method, rawURL, accessKey, secretKey, region string, expires time.Duration) (string, error) {
+	if expires < time.Second || expires > SigV4MaxExpires {
+		return "", fmt.Errorf("invalid expiration time %v (must be in [1s, %v] range)", expires, SigV4MaxExpires)
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	if region == "" {
+		region = SigV4DefaultRegion
+	}
+	var (
+		now = time.Now().UTC()
+		sig = &SigV4{
+			AccessKey:     accessKey,
+			Date:          now.Format(sigV4DateFormat),
+			Region:        region,
+			Service:       sigV4Service,
+			SignedHeaders: []string{"host"},
+			Time:          now,
+			Expires:       expires,
+			Presigned:     true,
+		}
+		query = u.Query()
+	)
+	query.Set(URLParamAmzAlgorithm, SigV4Algorithm)
+	query.Set(URLParamAmzCredential, sig.AccessKey+"/"+sig.scope())
+	query.Set(URLParamAmzDate, now.Format(SigV4TimeFormat))
+	query.Set(URLParamAmzExpires, strconv.FormatInt(int64(expires/time.Second), 10))
+	query.Set(URLParamAmzSignedHeaders, "host")
+	canonical := sigV4CanonicalRequest(method, u.Path, query, nil, u.Host, sig.SignedHeaders, SigV4UnsignedPayload)
+	query.Set(URLParamAmzSignature,
+		sigV4Sign(secretKey, sig.Date, sig.Region, sig.Service, sigV4StringToSign(now, sig.scope(), canonical)))
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// IsPresigned returns true if the query contains signature V4
+func IsPresigned(query url.Values) bool {
+	return query.Get(URLParamAmzAlgorithm) != ""
+}
+
+func sigV4CanonicalRequest(method, path string, query url.Values, hdr http.Header, host string,
+	signedHeaders []string, payloadHash string) string {
+	var sb strings.Builder
+	sb.WriteString(method)
+	sb.WriteByte('\n')
+	if path == "" {
+		path = "/"
+	}
+	sb.WriteString(sigV4URIEncode(path, false))
+	sb.WriteByte('\n')
+
+	// query: sorted by (encoded) key and value
+	params := make([]string, 0, len(query))
+	for k, vs := range query {
+		ek := sigV4URIEncode(k, true)
+		for _, v := range vs {
+			params = append(params, ek+"="+sigV4URIEncode(v, true))
+		}
+	}
+	sort.Strings(params)
+	sb.WriteString(strings.Join(params, "&"))
+	sb.WriteByte('\n')
+
+	// headers: in the order of the (sorted) list of signed headers
+	for _, name := range signedHeaders {
+		var value string
+		if name == "host" {
+			value = host
+		} else {
+			value = strings.Join(hdr.Values(name), ",")
+		}
+		sb.WriteString(name)
+		sb.WriteByte(':')
+		sb.WriteString(strings.Join(strings.Fields(value), " "))
+		sb.WriteByte('\n')
+	}
+	sb.WriteByte('\n')
+	sb.WriteString(strings.Join(signedHeaders, ";"))
+	sb.WriteByte('\n')
+	sb.WriteString(payloadHash)
+	return sb.String()
+}
+
+func sigV4StringToSign(t time.Time, scope, canonical string) string {
+	h := sha256.Sum256([]byte(canonical))
+	return SigV4Algorithm + "\n" + t.UTC().Format(SigV4TimeFormat) + "\n" + scope + "\n" + hex.EncodeToString(h[:])
+}
+
+func sigV4Sign(secretKey, date, region, service, stringToSign string) string {
+	key := sigV4HMAC([]byte("AWS4"+secretKey), date)
+	key = sigV4HMAC(key, region)
+	key = sigV4HMAC(key, service)
+	key = sigV4HMAC(key, sigV4Terminator)
+	return hex.EncodeToString(sigV4HMAC(key, stringToSign))
+}
+
+func sigV4HMAC(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// URI-encodes every byte except unreserved characters: 'A'-'Z', 'a'-'z', '0'-'9', '-', '.', '_', and '~'.
+// The forward slash is encoded only when `encodeSlash` is set (query keys and values).
+func sigV4URIEncode(s string, encodeSlash bool) string {
+	const hexDigits = "0123456789ABCDEF"
+	var sb strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9'),
+			c == '-', c == '.', c == '_', c == '~':
+			sb.WriteByte(c)
+		case c == '/' && !encodeSlash:
+			sb.WriteByte(c)
+		default:
+			sb.WriteByte('%')
+			sb.WriteByte(hexDigits[c>>4])
+			sb.WriteByte(hexDigits[c&0xf])
+		}
+	}
+	return sb.String()
+}
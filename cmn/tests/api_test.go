@@ -40,6 +40,18 @@ var _ = Describe("API", func() {
 					Access:   1024,
 				},
 			),
+			Entry("remote write policy",
+				cmn.BucketProps{
+					Provider: cmn.ProviderAmazon,
+				},
+				cmn.BucketPropsToUpdate{
+					RemoteWrite: api.RemoteWritePolicy(cmn.WriteBack),
+				},
+				cmn.BucketProps{
+					Provider:    cmn.ProviderAmazon,
+					RemoteWrite: cmn.WriteBack,
+				},
+			),
 			Entry("nested field",
 				cmn.BucketProps{},
 				cmn.BucketPropsToUpdate{
@@ -181,4 +193,16 @@ var _ = Describe("API", func() {
 			),
 		)
 	})
+
+	DescribeTable("UsagePrefix",
+		func(objName, prefix string, depth int, expected string) {
+			Expect(cmn.UsagePrefix(objName, prefix, depth)).To(Equal(expected))
+		},
+		Entry("zero depth", "a/b/c", "a/", 0, "a/"),
+		Entry("top level", "a/b/c", "", 1, "a/"),
+		Entry("object at the top", "obj", "", 1, ""),
+		Entry("nested", "a/b/c/d", "a/", 2, "a/b/c/"),
+		Entry("depth exceeds nesting", "a/b", "a/", 2, "a/"),
+		Entry("prefix is not a directory", "data/img/1.jpg", "dat", 1, "data/"),
+	)
 })
@@ -0,0 +1,85 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestBatchMsg(t *testing.T) {
+	testCases := []struct {
+		msg   cmn.GetBatchMsg
+		names []string
+		valid bool
+	}{
+		{cmn.GetBatchMsg{ListMsg: cmn.ListMsg{ObjNames: []string{"a", "b"}}}, []string{"a", "b"}, true},
+		{cmn.GetBatchMsg{RangeMsg: cmn.RangeMsg{Template: "obj-{1..3}"}}, []string{"obj-1", "obj-2", "obj-3"}, true},
+		{cmn.GetBatchMsg{RangeMsg: cmn.RangeMsg{Template: "obj-@2"}, Format: cmn.BatchFormatLP}, []string{"obj-0", "obj-1", "obj-2"}, true},
+		{cmn.GetBatchMsg{}, nil, false},
+		{cmn.GetBatchMsg{ListMsg: cmn.ListMsg{ObjNames: []string{"a"}}, RangeMsg: cmn.RangeMsg{Template: "obj-{1..3}"}}, nil, false},
+		{cmn.GetBatchMsg{ListMsg: cmn.ListMsg{ObjNames: []string{"a"}}, Format: "zip"}, nil, false},
+		{cmn.GetBatchMsg{RangeMsg: cmn.RangeMsg{Template: "prefix/"}}, nil, false},
+	}
+	for _, test := range testCases {
+		msg := test.msg
+		err := msg.Validate()
+		var names []string
+		if err == nil {
+			names, err = msg.Names()
+		}
+		if !test.valid {
+			tassert.Errorf(t, err != nil, "%+v: expected error", test.msg)
+			continue
+		}
+		tassert.CheckFatal(t, err)
+		tassert.Errorf(t, msg.Format != "", "%+v: expected default format", test.msg)
+		tassert.Errorf(t, strings.Join(names, ",") == strings.Join(test.names, ","),
+			"%+v: expected %v, got %v", test.msg, test.names, names)
+	}
+}
+
+func TestBatchStream(t *testing.T) {
+	entries := []struct {
+		hdr  cmn.BatchEntryHdr
+		data string
+	}{
+		{cmn.BatchEntryHdr{Name: "a/1", Size: 5}, "hello"},
+		{cmn.BatchEntryHdr{Name: "a/2", Err: "a/2 does not exist"}, ""},
+		{cmn.BatchEntryHdr{Name: "a/3", Size: 0}, ""},
+		{cmn.BatchEntryHdr{Name: "b/4", Size: 1000}, strings.Repeat("x", 1000)},
+	}
+	for _, format := range cmn.SupportedBatchFormats {
+		t.Run(format, func(t *testing.T) {
+			buf := &bytes.Buffer{}
+			bw := cmn.NewBatchWriter(buf, format)
+			for i := range entries {
+				tassert.CheckFatal(t, bw.WriteEntry(&entries[i].hdr, strings.NewReader(entries[i].data)))
+			}
+			tassert.CheckFatal(t, bw.Close())
+
+			br := cmn.NewBatchReader(buf, format)
+			for i, entry := range entries {
+				hdr, r, err := br.Next()
+				tassert.CheckFatal(t, err)
+				tassert.Errorf(t, *hdr == entry.hdr, "entry #%d: expected %+v, got %+v", i, entry.hdr, *hdr)
+				if i == 0 {
+					continue // skip reading the first one
+				}
+				b, err := ioutil.ReadAll(r)
+				tassert.CheckFatal(t, err)
+				tassert.Errorf(t, string(b) == entry.data, "entry #%d: data mismatch", i)
+			}
+			_, _, err := br.Next()
+			tassert.Errorf(t, err == io.EOF, "expected EOF, got %v", err)
+		})
+	}
+}
@@ -0,0 +1,44 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestDiffBuckets(t *testing.T) {
+	var (
+		src = []*cmn.BucketEntry{
+			{Name: "same", Size: 1, Checksum: "a"},
+			{Name: "resized", Size: 2, Checksum: "a"},
+			{Name: "modified", Size: 1, Checksum: "a"},
+			{Name: "missing-b", Size: 1},
+			{Name: "missing-a", Size: 1},
+		}
+		dst = []*cmn.BucketEntry{
+			{Name: "extra", Size: 1},
+			{Name: "modified", Size: 1, Checksum: "b"},
+			{Name: "resized", Size: 1, Checksum: "a"},
+			{Name: "same", Size: 1, Checksum: "a"},
+		}
+	)
+	diff := cmn.DiffBuckets(src, dst, true /*cksum*/)
+	tassert.Errorf(t, reflect.DeepEqual(diff.Missing, []string{"missing-a", "missing-b"}), "missing: %v", diff.Missing)
+	tassert.Errorf(t, reflect.DeepEqual(diff.Extra, []string{"extra"}), "extra: %v", diff.Extra)
+	tassert.Errorf(t, reflect.DeepEqual(diff.Different, []string{"modified", "resized"}), "different: %v", diff.Different)
+	tassert.Errorf(t, diff.Same == 1 && diff.Cksum, "same: %d, cksum: %t", diff.Same, diff.Cksum)
+
+	// by size only
+	diff = cmn.DiffBuckets(src, dst, false /*cksum*/)
+	tassert.Errorf(t, reflect.DeepEqual(diff.Different, []string{"resized"}), "different: %v", diff.Different)
+	tassert.Errorf(t, diff.Same == 2 && !diff.InSync(), "same: %d", diff.Same)
+
+	diff = cmn.DiffBuckets(dst, dst, true /*cksum*/)
+	tassert.Errorf(t, diff.InSync() && diff.Same == int64(len(dst)), "expected in sync: %+v", diff)
+}
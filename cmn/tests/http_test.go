@@ -85,3 +85,36 @@ func TestMatchRESTItems(t *testing.T) {
 		}
 	}
 }
+
+func TestMultiRange(t *testing.T) {
+	const size = 1000
+	ranges := []cmn.HTTPRange{{Start: 0, Length: 10}, {Start: 500, Length: 1}, {Start: 990, Length: 10}}
+	hdr := cmn.MultiRangeHdr(ranges)
+	if s := hdr.Get(cmn.HeaderRange); s != "bytes=0-9,500-500,990-999" {
+		t.Fatalf("unexpected %s header: %q", cmn.HeaderRange, s)
+	}
+	parsed, err := cmn.ParseMultiRange(hdr.Get(cmn.HeaderRange), size)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(parsed, ranges) {
+		t.Fatalf("ranges are not equal (got: %v, expected: %v)", parsed, ranges)
+	}
+	if n := cmn.RangesSize(ranges); n != 21 {
+		t.Fatalf("expected total size 21, got %d", n)
+	}
+	for _, r := range ranges {
+		got, gotSize, err := cmn.ParseContentRange(r.ContentRange(size))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != r || gotSize != size {
+			t.Fatalf("expected %v (size %d), got %v (size %d)", r, size, got, gotSize)
+		}
+	}
+	for _, s := range []string{"", "bytes 10-5/100", "bytes 0-100/100", "bytes */100", "items 0-1/2"} {
+		if _, _, err := cmn.ParseContentRange(s); err == nil {
+			t.Errorf("expected error parsing %q", s)
+		}
+	}
+}
@@ -77,6 +77,7 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           false,
 					"versioning.validate_warm_get": false,
+					"versioning.retain":            0,
 
 					"checksum.type":              cmn.ChecksumXXHash,
 					"checksum.validate_warm_get": false,
@@ -91,11 +92,20 @@ var _ = Describe("IterFields", func() {
 					"lru.dont_evict_time":   "",
 					"lru.capacity_upd_time": "",
 
+					"lifecycle.rules": []cmn.LifecycleRule(nil),
+
+					"object_lock.mode": "",
+					"object_lock.days": int64(0),
+
+					"rate_limit.rps":       int64(0),
+					"rate_limit.bandwidth": int64(0),
+
 					"extra.aws.cloud_region": "us-central",
 
-					"access":   cmn.AccessAttrs(0),
-					"md_write": cmn.MDWritePolicy(""),
-					"created":  int64(0),
+					"access":       cmn.AccessAttrs(0),
+					"md_write":     cmn.MDWritePolicy(""),
+					"remote_write": cmn.RemoteWritePolicy(""),
+					"created":      int64(0),
 				},
 			),
 			Entry("list BucketPropsToUpdate fields",
@@ -130,6 +140,7 @@ var _ = Describe("IterFields", func() {
 
 					"versioning.enabled":           (*bool)(nil),
 					"versioning.validate_warm_get": (*bool)(nil),
+					"versioning.retain":            (*int)(nil),
 
 					"checksum.type":              api.String(cmn.ChecksumXXHash),
 					"checksum.validate_warm_get": (*bool)(nil),
@@ -144,8 +155,17 @@ var _ = Describe("IterFields", func() {
 					"lru.capacity_upd_time": (*string)(nil),
 					"lru.out_of_space":      (*int64)(nil),
 
-					"access":   api.AccessAttrs(1024),
-					"md_write": api.MDWritePolicy("never"),
+					"lifecycle.rules": (*[]cmn.LifecycleRule)(nil),
+
+					"object_lock.mode": (*string)(nil),
+					"object_lock.days": (*int64)(nil),
+
+					"rate_limit.rps":       (*int64)(nil),
+					"rate_limit.bandwidth": (*int64)(nil),
+
+					"access":       api.AccessAttrs(1024),
+					"md_write":     api.MDWritePolicy("never"),
+					"remote_write": (*cmn.RemoteWritePolicy)(nil),
 
 					"extra.hdfs.ref_directory": (*string)(nil),
 				},
@@ -254,6 +274,8 @@ var _ = Describe("IterFields", func() {
 
 					"access":   "12", // type == uint64
 					"md_write": "never",
+
+					"lifecycle.rules": `[{"id":"tmp","prefix":"tmp/","action":"delete","days":1,"enabled":true}]`,
 				},
 				&cmn.BucketPropsToUpdate{
 					Versioning: &cmn.VersionConfToUpdate{
@@ -274,6 +296,11 @@ var _ = Describe("IterFields", func() {
 						Type:            api.String(cmn.ChecksumXXHash),
 						ValidateWarmGet: api.Bool(true),
 					},
+					Lifecycle: &cmn.LifecycleConfToUpdate{
+						Rules: &[]cmn.LifecycleRule{
+							{ID: "tmp", Prefix: "tmp/", Action: cmn.LifecycleDelete, Days: 1, Enabled: true},
+						},
+					},
 					Access:  api.AccessAttrs(12),
 					MDWrite: api.MDWritePolicy(cmn.WriteNever),
 				},
@@ -296,6 +323,9 @@ var _ = Describe("IterFields", func() {
 			Entry("field not found", &Foo{}, map[string]interface{}{
 				"foo.bar": 2,
 			}),
+			Entry("invalid JSON value", &cmn.BucketPropsToUpdate{}, map[string]interface{}{
+				"lifecycle.rules": "[{",
+			}),
 		)
 	})
 })
@@ -0,0 +1,75 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestLifecycleValidate(t *testing.T) {
+	testCases := []struct {
+		rules []cmn.LifecycleRule
+		valid bool
+	}{
+		{nil, true},
+		{[]cmn.LifecycleRule{{ID: "a", Action: cmn.LifecycleDelete, Days: 1}}, true},
+		{[]cmn.LifecycleRule{
+			{ID: "a", Action: cmn.LifecycleDelete, Days: 30},
+			{ID: "a", Action: cmn.LifecycleEvict, Days: 7},
+		}, true},
+		{[]cmn.LifecycleRule{{Action: cmn.LifecycleDelete, Days: 1}}, false},
+		{[]cmn.LifecycleRule{{ID: "a", Action: "archive", Days: 1}}, false},
+		{[]cmn.LifecycleRule{{ID: "a", Action: cmn.LifecycleEvict, Days: 0}}, false},
+		{[]cmn.LifecycleRule{
+			{ID: "a", Action: cmn.LifecycleDelete, Days: 1},
+			{ID: "a", Action: cmn.LifecycleDelete, Days: 2},
+		}, false},
+	}
+	for _, test := range testCases {
+		conf := cmn.LifecycleConf{Rules: test.rules}
+		err := conf.ValidateAsProps(&cmn.ValidationArgs{Provider: cmn.ProviderAmazon})
+		tassert.Errorf(t, (err == nil) == test.valid, "rules %+v: expected valid=%t, got %v", test.rules, test.valid, err)
+	}
+}
+
+func TestLifecycleEval(t *testing.T) {
+	var (
+		day  = 24 * time.Hour
+		now  = time.Now()
+		conf = cmn.LifecycleConf{Rules: []cmn.LifecycleRule{
+			{ID: "tmp", Prefix: "tmp/", Action: cmn.LifecycleDelete, Days: 1, Enabled: true},
+			{ID: "cache", Prefix: "", Action: cmn.LifecycleEvict, Days: 7, Enabled: true},
+			{ID: "logs", Prefix: "logs/", Action: cmn.LifecycleDelete, Days: 2, Enabled: false},
+		}}
+	)
+	testCases := []struct {
+		objName      string
+		atime, mtime time.Duration // ago
+		remote       bool
+		expected     string
+	}{
+		{"tmp/a", 0, 2 * day, false, cmn.LifecycleDelete},
+		{"tmp/a", 0, 2 * day, true, cmn.LifecycleDelete},
+		{"tmp/a", 8 * day, time.Hour, true, cmn.LifecycleEvict},
+		{"tmp/a", 8 * day, 2 * day, true, cmn.LifecycleDelete}, // delete takes precedence
+		{"data/a", 8 * day, 8 * day, true, cmn.LifecycleEvict},
+		{"data/a", 8 * day, 8 * day, false, ""}, // nothing to evict in ais buckets
+		{"data/a", 6 * day, 8 * day, true, ""},
+		{"logs/a", 0, 10 * day, false, ""}, // disabled rule
+	}
+	for _, test := range testCases {
+		action := conf.Eval(test.objName, now.Add(-test.atime), now.Add(-test.mtime), now, test.remote)
+		tassert.Errorf(t, action == test.expected, "%s (atime -%v, mtime -%v, remote %t): expected %q, got %q",
+			test.objName, test.atime, test.mtime, test.remote, test.expected, action)
+	}
+	tassert.Errorf(t, conf.Enabled() && conf.HasAction(cmn.LifecycleEvict), "expected enabled evict rule")
+
+	conf.Rules[0].Enabled, conf.Rules[1].Enabled = false, false
+	tassert.Errorf(t, !conf.Enabled() && !conf.HasAction(cmn.LifecycleDelete), "expected no enabled rules")
+}
@@ -0,0 +1,65 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestValidateObjUserMD(t *testing.T) {
+	tests := []struct {
+		userMD, tags cmn.SimpleKVs
+		valid        bool
+	}{
+		{nil, nil, true},
+		{cmn.SimpleKVs{"a": "b=c"}, cmn.SimpleKVs{"x": ""}, true},
+		{cmn.SimpleKVs{"": "b"}, nil, false},
+		{cmn.SimpleKVs{"a=b": "c"}, nil, false},
+		{cmn.SimpleKVs{"a": "b\x01c"}, nil, false},
+		{cmn.SimpleKVs{"a": strings.Repeat("x", cmn.MaxObjUserMDSize)}, nil, false},
+		{nil, cmn.SimpleKVs{"a": strings.Repeat("x", cmn.MaxObjTagValLen+1)}, false},
+		{nil, cmn.SimpleKVs{strings.Repeat("x", cmn.MaxObjTagKeyLen+1): "a"}, false},
+		{
+			cmn.SimpleKVs{"a": strings.Repeat("x", cmn.MaxObjUserMDSize/2)},
+			cmn.SimpleKVs{"b": strings.Repeat("x", cmn.MaxObjTagValLen)}, true,
+		},
+	}
+	manyTags := make(cmn.SimpleKVs, cmn.MaxObjTagsCnt+1)
+	for i := 0; i <= cmn.MaxObjTagsCnt; i++ {
+		manyTags[cmn.I2S(int64(i))] = ""
+	}
+	tests = append(tests, struct {
+		userMD, tags cmn.SimpleKVs
+		valid        bool
+	}{nil, manyTags, false})
+
+	for _, test := range tests {
+		err := cmn.ValidateObjUserMD(test.userMD, test.tags)
+		tassert.Errorf(t, (err == nil) == test.valid, "user md %v, tags %v: expected valid=%t, got %v",
+			test.userMD, test.tags, test.valid, err)
+	}
+}
+
+func TestKVsHdr(t *testing.T) {
+	kvs := cmn.SimpleKVs{"a": "1", "b": "x=y", "c": ""}
+	hdr := make(http.Header)
+	cmn.KVsToHdr(hdr, cmn.HeaderObjUserMD, kvs)
+	parsed, err := cmn.KVsFromHdr(hdr, cmn.HeaderObjUserMD)
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, len(parsed) == len(kvs), "expected %v, got %v", kvs, parsed)
+	for k, v := range kvs {
+		tassert.Errorf(t, parsed[k] == v, "expected %v, got %v", kvs, parsed)
+	}
+
+	hdr = make(http.Header)
+	hdr.Add(cmn.HeaderObjTags, "novalue")
+	_, err = cmn.KVsFromHdr(hdr, cmn.HeaderObjTags)
+	tassert.Errorf(t, err != nil, "expected error for invalid entry")
+}
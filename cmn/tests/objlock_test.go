@@ -0,0 +1,82 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestObjLockValidate(t *testing.T) {
+	testCases := []struct {
+		conf  cmn.ObjLockConf
+		valid bool
+	}{
+		{cmn.ObjLockConf{}, true},
+		{cmn.ObjLockConf{Mode: cmn.ObjLockGovernance, Days: 1}, true},
+		{cmn.ObjLockConf{Mode: cmn.ObjLockCompliance}, true},
+		{cmn.ObjLockConf{Mode: "legal-hold", Days: 1}, false},
+		{cmn.ObjLockConf{Mode: cmn.ObjLockGovernance, Days: -1}, false},
+	}
+	for _, test := range testCases {
+		err := test.conf.Validate()
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got %v", test.conf, test.valid, err)
+	}
+}
+
+func TestObjLockValidateUpdate(t *testing.T) {
+	var (
+		governance = cmn.ObjLockConf{Mode: cmn.ObjLockGovernance, Days: 10}
+		compliance = cmn.ObjLockConf{Mode: cmn.ObjLockCompliance, Days: 10}
+	)
+	testCases := []struct {
+		from, to cmn.ObjLockConf
+		valid    bool
+	}{
+		{governance, cmn.ObjLockConf{}, true},
+		{governance, cmn.ObjLockConf{Mode: cmn.ObjLockGovernance, Days: 1}, true},
+		{governance, compliance, true},
+		{compliance, cmn.ObjLockConf{Mode: cmn.ObjLockCompliance, Days: 20}, true},
+		{compliance, cmn.ObjLockConf{Mode: cmn.ObjLockCompliance, Days: 1}, false},
+		{compliance, governance, false},
+		{compliance, cmn.ObjLockConf{}, false},
+	}
+	for _, test := range testCases {
+		err := test.from.ValidateUpdate(&test.to)
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v => %+v: expected valid=%t, got %v",
+			test.from, test.to, test.valid, err)
+	}
+}
+
+func TestObjLockCheckRetention(t *testing.T) {
+	var (
+		now   = time.Now()
+		until = now.Add(time.Hour)
+	)
+	testCases := []struct {
+		mode   string
+		until  time.Time
+		bypass bool
+		locked bool
+	}{
+		{"", until, false, false},
+		{cmn.ObjLockGovernance, until, false, true},
+		{cmn.ObjLockGovernance, until, true, false},
+		{cmn.ObjLockGovernance, now, false, false},
+		{cmn.ObjLockGovernance, time.Time{}, false, false},
+		{cmn.ObjLockCompliance, until, false, true},
+		{cmn.ObjLockCompliance, until, true, true},
+		{cmn.ObjLockCompliance, now.Add(-time.Hour), false, false},
+	}
+	for _, test := range testCases {
+		conf := cmn.ObjLockConf{Mode: test.mode}
+		err := conf.CheckRetention("obj", test.until, now, test.bypass)
+		tassert.Errorf(t, cmn.IsErrObjLocked(err) == test.locked, "%+v: expected locked=%t, got %v",
+			test, test.locked, err)
+	}
+}
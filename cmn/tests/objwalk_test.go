@@ -0,0 +1,64 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func makeBckList(token string, names ...string) *cmn.BucketList {
+	bckList := &cmn.BucketList{ContinuationToken: token}
+	for _, name := range names {
+		bckList.Entries = append(bckList.Entries, &cmn.BucketEntry{Name: name})
+	}
+	return bckList
+}
+
+func checkBckList(t *testing.T, bckList *cmn.BucketList, expected ...string) {
+	names := make([]string, 0, len(bckList.Entries))
+	for _, e := range bckList.Entries {
+		name := e.Name
+		if e.IsDir() {
+			name += "(dir)"
+		}
+		names = append(names, name)
+	}
+	tassert.Fatalf(t, len(names) == len(expected), "expected %v, got %v", expected, names)
+	for i := range names {
+		tassert.Errorf(t, names[i] == expected[i], "expected %v, got %v", expected, names)
+	}
+}
+
+func TestAggregateDirs(t *testing.T) {
+	bckList := makeBckList("", "a", "b/c", "b/d/e", "b0", "c/d")
+	bckList.AggregateDirs("", "/", true)
+	checkBckList(t, bckList, "a", "b/(dir)", "b0", "c/(dir)")
+	tassert.Errorf(t, bckList.ContinuationToken == "", "unexpected token %q", bckList.ContinuationToken)
+
+	bckList = makeBckList("", "a/b", "a/c/d", "a/c/e", "a/f")
+	bckList.AggregateDirs("a/", "/", true)
+	checkBckList(t, bckList, "a/b", "a/c/(dir)", "a/f")
+
+	// no delimiter: nothing changes
+	bckList = makeBckList("", "a/b", "a/c/d")
+	bckList.AggregateDirs("", "", true)
+	checkBckList(t, bckList, "a/b", "a/c/d")
+
+	// the page ends with a directory: the token must skip its content
+	bckList = makeBckList("b/d/e", "a", "b/c", "b/d/e")
+	bckList.AggregateDirs("", "/", true)
+	checkBckList(t, bckList, "a", "b/(dir)")
+	tassert.Errorf(t, bckList.ContinuationToken > "b/zzz/zzz" && bckList.ContinuationToken < "b0",
+		"invalid token %q", bckList.ContinuationToken)
+
+	// opaque token (remote bucket) is never modified
+	bckList = makeBckList("opaque", "a", "b/c")
+	bckList.AggregateDirs("", "/", false)
+	checkBckList(t, bckList, "a", "b/(dir)")
+	tassert.Errorf(t, bckList.ContinuationToken == "opaque", "unexpected token %q", bckList.ContinuationToken)
+}
@@ -0,0 +1,83 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestPrecondsParse(t *testing.T) {
+	tassert.Errorf(t, cmn.ParsePreconds(http.Header{}) == nil, "expected no preconditions")
+	tassert.Errorf(t, cmn.ParsePreconds(http.Header{cmn.HeaderIfModifiedSince: {"yesterday"}}) == nil,
+		"expected invalid date to be ignored")
+
+	p := cmn.ParsePreconds(http.Header{
+		cmn.HeaderIfMatch:     {`"abc", W/"def" ,"2"`},
+		cmn.HeaderIfNoneMatch: {"*"},
+	})
+	tassert.Fatalf(t, p != nil, "expected preconditions")
+	tassert.Errorf(t, len(p.IfMatch) == 3 && p.IfMatch[0] == "abc" && p.IfMatch[1] == "def" && p.IfMatch[2] == "2",
+		"unexpected If-Match: %v", p.IfMatch)
+	tassert.Errorf(t, len(p.IfNoneMatch) == 1 && p.IfNoneMatch[0] == "*", "unexpected If-None-Match: %v", p.IfNoneMatch)
+	tassert.Errorf(t, !p.NeedMtime(), "expected no time-based preconditions")
+}
+
+func TestPrecondsEval(t *testing.T) {
+	var (
+		mtime  = time.Date(2020, 10, 10, 10, 10, 10, 500, time.UTC)
+		before = mtime.Add(-time.Hour).Format(http.TimeFormat)
+		after  = mtime.Add(time.Hour).Format(http.TimeFormat)
+		same   = mtime.Format(http.TimeFormat)
+		obj    = &cmn.PrecondObj{ETags: []string{"3", "", "cksum"}, Mtime: mtime, Exists: true}
+		none   = &cmn.PrecondObj{}
+	)
+	testCases := []struct {
+		hdr      http.Header
+		obj      *cmn.PrecondObj
+		read     bool
+		expected int
+	}{
+		// If-Match (compare-and-swap)
+		{http.Header{cmn.HeaderIfMatch: {`"cksum"`}}, obj, false, 0},
+		{http.Header{cmn.HeaderIfMatch: {"1, 3"}}, obj, false, 0},
+		{http.Header{cmn.HeaderIfMatch: {"2"}}, obj, false, http.StatusPreconditionFailed},
+		{http.Header{cmn.HeaderIfMatch: {"*"}}, obj, false, 0},
+		{http.Header{cmn.HeaderIfMatch: {"*"}}, none, false, http.StatusPreconditionFailed},
+		{http.Header{cmn.HeaderIfMatch: {`""`}}, obj, false, 0}, // (no tags - no precondition)
+
+		// If-None-Match
+		{http.Header{cmn.HeaderIfNoneMatch: {"*"}}, none, false, 0},
+		{http.Header{cmn.HeaderIfNoneMatch: {"*"}}, obj, false, http.StatusPreconditionFailed},
+		{http.Header{cmn.HeaderIfNoneMatch: {"cksum"}}, obj, true, http.StatusNotModified},
+		{http.Header{cmn.HeaderIfNoneMatch: {"other"}}, obj, true, 0},
+
+		// dates
+		{http.Header{cmn.HeaderIfModifiedSince: {before}}, obj, true, 0},
+		{http.Header{cmn.HeaderIfModifiedSince: {same}}, obj, true, http.StatusNotModified},
+		{http.Header{cmn.HeaderIfModifiedSince: {after}}, obj, true, http.StatusNotModified},
+		{http.Header{cmn.HeaderIfModifiedSince: {after}}, obj, false, 0}, // (ignored by PUT)
+		{http.Header{cmn.HeaderIfUnmodifiedSince: {before}}, obj, false, http.StatusPreconditionFailed},
+		{http.Header{cmn.HeaderIfUnmodifiedSince: {same}}, obj, true, 0},
+
+		// precedence
+		{http.Header{cmn.HeaderIfMatch: {"3"}, cmn.HeaderIfUnmodifiedSince: {before}}, obj, false, 0},
+		{http.Header{cmn.HeaderIfNoneMatch: {"other"}, cmn.HeaderIfModifiedSince: {after}}, obj, true, 0},
+		{http.Header{cmn.HeaderIfMatch: {"2"}, cmn.HeaderIfNoneMatch: {"cksum"}}, obj, true, http.StatusPreconditionFailed},
+	}
+	for _, test := range testCases {
+		p := cmn.ParsePreconds(test.hdr)
+		status := 0
+		if p != nil {
+			status = p.Eval(test.obj, test.read)
+		}
+		tassert.Errorf(t, status == test.expected, "%v (exists=%t, read=%t): expected %d, got %d",
+			test.hdr, test.obj.Exists, test.read, test.expected, status)
+	}
+}
@@ -0,0 +1,123 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+	jsoniter "github.com/json-iterator/go"
+)
+
+const (
+	testProvider       = "tst"
+	testProviderScheme = "tsts"
+)
+
+type testProviderConf struct {
+	Root string `json:"root"`
+}
+
+func init() {
+	cmn.RegisterProvider(cmn.ProviderInfo{
+		Name:    testProvider,
+		Schemes: []string{testProviderScheme},
+		ParseConf: func(raw []byte) (interface{}, error) {
+			var conf testProviderConf
+			if err := jsoniter.Unmarshal(raw, &conf); err != nil {
+				return nil, err
+			}
+			if conf.Root == "" {
+				return nil, errors.New("root is required")
+			}
+			return conf, nil
+		},
+	})
+}
+
+func TestNormalizeProvider(t *testing.T) {
+	testCases := []struct {
+		provider, expected string
+		valid              bool
+	}{
+		{"", "", true},
+		{cmn.ProviderAIS, cmn.ProviderAIS, true},
+		{cmn.S3Scheme, cmn.ProviderAmazon, true},
+		{cmn.GSScheme, cmn.ProviderGoogle, true},
+		{cmn.AZScheme, cmn.ProviderAzure, true},
+		{cmn.ProviderHDFS, cmn.ProviderHDFS, true},
+		{cmn.ProviderPOSIX, cmn.ProviderPOSIX, true},
+		{testProvider, testProvider, true},
+		{testProviderScheme, testProvider, true},
+		{"unknown", "", false},
+	}
+	for _, tc := range testCases {
+		provider, err := cmn.NormalizeProvider(tc.provider)
+		if !tc.valid {
+			tassert.Errorf(t, err != nil, "expected error for %q", tc.provider)
+			continue
+		}
+		tassert.CheckError(t, err)
+		tassert.Errorf(t, provider == tc.expected, "%q: expected %q, got %q", tc.provider, tc.expected, provider)
+	}
+
+	err := cmn.ValidateProvider("unknown")
+	tassert.Fatalf(t, err != nil, "expected error")
+	tassert.Errorf(t, strings.Contains(err.Error(), testProvider+" ("+testProviderScheme+"://)"),
+		"expected registered provider in %q", err)
+}
+
+func TestRegisteredProviderBck(t *testing.T) {
+	bck, _, err := cmn.ParseBckObjectURI(testProviderScheme + "://bucket")
+	tassert.CheckFatal(t, err)
+	tassert.Errorf(t, bck.Provider == testProvider, "expected %q, got %q", testProvider, bck.Provider)
+	tassert.Errorf(t, bck.IsRemote(), "expected %s to be remote", bck)
+	tassert.Errorf(t, !bck.IsCloud(), "expected %s not to be cloud", bck)
+	tassert.Errorf(t, cmn.Bck{Name: "b", Provider: cmn.ProviderAmazon}.IsCloud(), "expected aws to be cloud")
+	tassert.Errorf(t, !cmn.Bck{Name: "b", Provider: cmn.ProviderAIS}.IsRemote(), "expected ais not to be remote")
+}
+
+func TestBackendConfRegisteredProvider(t *testing.T) {
+	conf := cmn.BackendConf{Conf: map[string]interface{}{
+		cmn.ProviderAmazon: map[string]interface{}{},
+		testProvider:       map[string]interface{}{"root": "/mnt/data"},
+	}}
+	tassert.CheckFatal(t, conf.Validate(nil))
+	_, ok := conf.Providers[testProvider]
+	tassert.Errorf(t, ok, "expected %q to be enabled", testProvider)
+	_, ok = conf.Providers[cmn.ProviderAmazon]
+	tassert.Errorf(t, ok, "expected %q to be enabled", cmn.ProviderAmazon)
+	parsed, ok := conf.ProviderConf(testProvider)
+	tassert.Fatalf(t, ok, "expected %q config", testProvider)
+	tstConf, ok := parsed.(testProviderConf)
+	tassert.Errorf(t, ok && tstConf.Root == "/mnt/data", "unexpected %q config: %+v", testProvider, parsed)
+
+	conf = cmn.BackendConf{Conf: map[string]interface{}{testProvider: map[string]interface{}{}}}
+	tassert.Errorf(t, conf.Validate(nil) != nil, "expected invalid %q config", testProvider)
+
+	conf = cmn.BackendConf{Conf: map[string]interface{}{"unknown": map[string]interface{}{}}}
+	tassert.Errorf(t, conf.Validate(nil) != nil, "expected error for unknown provider")
+}
+
+func TestBackendConfPOSIX(t *testing.T) {
+	conf := cmn.BackendConf{Conf: map[string]interface{}{
+		cmn.ProviderPOSIX: map[string]interface{}{"root": "/mnt/nfs/datasets/"},
+	}}
+	tassert.CheckFatal(t, conf.Validate(nil))
+	_, ok := conf.Providers[cmn.ProviderPOSIX]
+	tassert.Errorf(t, ok, "expected %q to be enabled", cmn.ProviderPOSIX)
+	parsed, _ := conf.ProviderConf(cmn.ProviderPOSIX)
+	posixConf := parsed.(cmn.BackendConfPOSIX)
+	tassert.Errorf(t, posixConf.Root == "/mnt/nfs/datasets", "unexpected root %q", posixConf.Root)
+	tassert.Errorf(t, cmn.Bck{Name: "b", Provider: cmn.ProviderPOSIX}.IsCloud(), "expected posix to be cloud")
+
+	for _, root := range []string{"", "mnt/nfs"} {
+		conf = cmn.BackendConf{Conf: map[string]interface{}{cmn.ProviderPOSIX: map[string]interface{}{"root": root}}}
+		tassert.Errorf(t, conf.Validate(nil) != nil, "expected invalid root %q", root)
+	}
+}
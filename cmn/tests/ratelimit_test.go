@@ -0,0 +1,71 @@
+// Package test provides tests for common low-level types and utilities for all aistore projects
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestRateLimitValidate(t *testing.T) {
+	testCases := []struct {
+		conf  cmn.RateLimitConf
+		valid bool
+	}{
+		{cmn.RateLimitConf{}, true},
+		{cmn.RateLimitConf{Enabled: true, Bucket: cmn.RateLimits{RPS: 100}, User: cmn.RateLimits{Bandwidth: cmn.MiB}}, true},
+		{cmn.RateLimitConf{Bucket: cmn.RateLimits{RPS: -1}}, false},
+		{cmn.RateLimitConf{User: cmn.RateLimits{Bandwidth: -1}}, false},
+	}
+	for _, test := range testCases {
+		err := test.conf.Validate(nil)
+		tassert.Errorf(t, (err == nil) == test.valid, "%+v: expected valid=%t, got %v", test.conf, test.valid, err)
+	}
+}
+
+func TestRateLimiterAcquire(t *testing.T) {
+	const rate = 100
+	rl := cmn.NewRateLimiter(rate)
+	for i := 0; i < rate; i++ {
+		tassert.Fatalf(t, rl.TryAcquire(1), "expected token %d to be available (burst)", i)
+	}
+	tassert.Errorf(t, !rl.TryAcquire(1), "expected no tokens past the burst")
+
+	time.Sleep(100 * time.Millisecond) // refills about 10 tokens
+	var n int
+	for rl.TryAcquire(1) {
+		n++
+	}
+	tassert.Errorf(t, n >= 5 && n <= 20, "expected about 10 tokens to be refilled, got %d", n)
+
+	// the rate can go below one token per second but the burst is always at least one
+	rl = cmn.NewRateLimiter(0.5)
+	tassert.Errorf(t, rl.TryAcquire(1), "expected one token")
+	tassert.Errorf(t, !rl.TryAcquire(1), "expected no tokens")
+}
+
+func TestRateLimiterReserve(t *testing.T) {
+	const rate = 10 * cmn.MiB
+	rl := cmn.NewRateLimiter(rate)
+	tassert.Errorf(t, rl.Delay(0) == 0 && rl.Full(), "expected new limiter to be full")
+
+	// reserving the burst does not wait
+	d := rl.Reserve(rate)
+	tassert.Errorf(t, d == 0, "expected no wait, got %v", d)
+
+	// reserving more puts the limiter into debt: wait until paid off
+	d = rl.Reserve(rate / 2)
+	tassert.Errorf(t, d > 400*time.Millisecond && d <= 500*time.Millisecond, "expected about 500ms wait, got %v", d)
+	tassert.Errorf(t, rl.Delay(0) > 0 && !rl.Full(), "expected limiter to be in debt")
+	tassert.Errorf(t, rl.Delay(rate) > time.Second, "expected more than 1s until the burst is available")
+
+	// changing the rate keeps the debt
+	rl.SetRate(100 * rate)
+	time.Sleep(100 * time.Millisecond)
+	tassert.Errorf(t, rl.Delay(0) == 0, "expected debt to be paid off")
+}
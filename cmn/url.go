@@ -82,6 +82,21 @@ func ReparseQuery(r *http.Request) {
 	}
 }
 
+// RedirectParams are added by the proxy to the request redirected (or reverse-proxied)
+// to a target - the values sent by clients must not be trusted (see DelRedirectParams).
+var RedirectParams = []string{URLParamProxyID, URLParamUnixTime, URLParamTraceParent, URLParamUserID, URLParamSigHost}
+
+// DelRedirectParams removes RedirectParams from the query and returns true if any were present.
+func DelRedirectParams(query url.Values) (found bool) {
+	for _, param := range RedirectParams {
+		if _, ok := query[param]; ok {
+			query.Del(param)
+			found = true
+		}
+	}
+	return
+}
+
 // JoinWords uses forward slash to join any number of words into a single path.
 // Returned path is prefixed with a slash.
 func JoinWords(w string, words ...string) (path string) {
@@ -13,6 +13,7 @@ func urlpath(words ...string) URLPath { return URLPath{L: words, S: JoinWords(wo
 
 var (
 	URLPathS3 = urlpath(S3) // URLPath{[]string{S3}, S3}
+	URLPathAZ = urlpath(AZ)
 
 	URLPathBuckets   = urlpath(Version, Buckets)
 	URLPathObjects   = urlpath(Version, Objects)
@@ -84,6 +85,7 @@ var (
 	URLPathUsers    = urlpath(Version, Users)
 	URLPathClusters = urlpath(Version, Clusters)
 	URLPathRoles    = urlpath(Version, Roles)
+	URLPathS3Keys   = urlpath(Version, S3Keys)
 )
 
 func (u URLPath) Join(words ...string) string {
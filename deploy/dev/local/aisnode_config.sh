@@ -160,6 +160,24 @@ cat > $AIS_CONF_FILE <<EOL
 		"compression":           "${COMPRESSION:-never}",
 		"call_timeout":          "10m"
 	},
+	"trace": {
+		"enabled":      ${TRACE_ENABLED:-false},
+		"sample_ratio": ${TRACE_SAMPLE_RATIO:-0.01},
+		"exporter":     "${TRACE_EXPORTER:-otlp}",
+		"endpoint":     "${TRACE_ENDPOINT:-http://localhost:4318/v1/traces}",
+		"path":         "${TRACE_PATH:-}"
+	},
+	"rate_limit": {
+		"enabled": ${RATE_LIMIT_ENABLED:-false},
+		"bucket": {
+			"rps":       ${RATE_LIMIT_BUCKET_RPS:-0},
+			"bandwidth": ${RATE_LIMIT_BUCKET_BANDWIDTH:-0}
+		},
+		"user": {
+			"rps":       ${RATE_LIMIT_USER_RPS:-0},
+			"bandwidth": ${RATE_LIMIT_USER_BANDWIDTH:-0}
+		}
+	},
 	"md_write": "${MD_WRITE:-}"
 }
 EOL
@@ -253,6 +253,8 @@ func PrepareObjects(t *testing.T, desc ObjectsDesc) *ObjectsOut {
 
 	_ = fs.CSM.RegisterContentType(fs.WorkfileType, &fs.WorkfileContentResolver{})
 	_ = fs.CSM.RegisterContentType(fs.ObjectType, &fs.ObjectContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.ObjVersionType, &fs.ObjVersionContentResolver{})
+	_ = fs.CSM.RegisterContentType(fs.MptUploadType, &fs.MptUploadContentResolver{})
 	_ = fs.CSM.RegisterContentType(ec.SliceType, &ec.SliceSpec{})
 	_ = fs.CSM.RegisterContentType(ec.MetaType, &ec.MetaSpec{})
 
@@ -140,10 +140,11 @@ const (
 type (
 	// request - structure to request an object to be EC'ed or restored
 	Request struct {
-		LIF      cluster.LIF // object info
-		Action   string      // what to do with the object (see Act* consts)
-		ErrCh    chan error  // for final EC result
-		Callback cluster.OnFinishObj
+		LIF         cluster.LIF // object info
+		Action      string      // what to do with the object (see Act* consts)
+		ErrCh       chan error  // for final EC result
+		Callback    cluster.OnFinishObj
+		TraceParent string // trace context of the GET that requested the restore (see tracing)
 
 		putTime time.Time // time when the object is put into main queue
 		tm      time.Time // to measure different steps
@@ -417,6 +418,9 @@ func LomFromHeader(t cluster.Target, hdr transport.ObjHdr) (*cluster.LOM, error)
 	if hdr.ObjAttrs.Atime != 0 {
 		lom.SetAtimeUnix(hdr.ObjAttrs.Atime)
 	}
+	if hdr.ObjAttrs.Mtime != 0 {
+		lom.SetLastModifiedUnix(hdr.ObjAttrs.Mtime)
+	}
 	if hdr.ObjAttrs.CksumType != cmn.ChecksumNone && hdr.ObjAttrs.CksumValue != "" {
 		lom.SetCksum(cmn.NewCksum(hdr.ObjAttrs.CksumType, hdr.ObjAttrs.CksumValue))
 	}
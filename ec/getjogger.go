@@ -97,7 +97,7 @@ func (c *getJogger) ec(req *Request) {
 				c.parent.DecPending()
 				return
 			}
-			err = c.restore(lom, toDisk)
+			err = c.restore(lom, toDisk, req.TraceParent)
 			c.parent.stats.updateDecodeTime(time.Since(req.tm), err != nil)
 			if cb != nil {
 				cb(err)
@@ -207,7 +207,9 @@ func (c *getJogger) copyMissingReplicas(lom *cluster.LOM, reader cmn.ReadOpenClo
 // * meta - rebuilt object's metadata
 // * nodes - filled by requestMeta the list of targets what responsed to GET
 //      metadata request with valid metafile
-func (c *getJogger) restoreReplicatedFromMemory(lom *cluster.LOM, meta *Metadata, nodes map[string]*Metadata) error {
+// * traceParent - trace context to propagate to the targets (see tracing)
+func (c *getJogger) restoreReplicatedFromMemory(lom *cluster.LOM, meta *Metadata, nodes map[string]*Metadata,
+	traceParent string) error {
 	var (
 		writer *memsys.SGL
 		mm     = c.parent.t.SmallMMSA()
@@ -218,7 +220,7 @@ func (c *getJogger) restoreReplicatedFromMemory(lom *cluster.LOM, meta *Metadata
 		iReqBuf := c.parent.newIntraReq(reqGet, meta, lom.Bck()).NewPack(mm)
 
 		w := mm.NewSGL(cmn.KiB)
-		if _, err := c.parent.readRemote(lom, node, uname, iReqBuf, w); err != nil {
+		if _, err := c.parent.readRemote(lom, node, uname, iReqBuf, w, traceParent); err != nil {
 			glog.Errorf("%s failed to read from %s", c.parent.t.Snode(), node)
 			w.Free()
 			mm.Free(iReqBuf)
@@ -258,7 +260,8 @@ func (c *getJogger) restoreReplicatedFromMemory(lom *cluster.LOM, meta *Metadata
 	return c.copyMissingReplicas(lom, writer, meta, nodes, meta.Parity+1)
 }
 
-func (c *getJogger) restoreReplicatedFromDisk(lom *cluster.LOM, meta *Metadata, nodes map[string]*Metadata) error {
+func (c *getJogger) restoreReplicatedFromDisk(lom *cluster.LOM, meta *Metadata, nodes map[string]*Metadata,
+	traceParent string) error {
 	var (
 		writer *os.File
 		n      int64
@@ -277,7 +280,7 @@ func (c *getJogger) restoreReplicatedFromDisk(lom *cluster.LOM, meta *Metadata,
 			break
 		}
 		iReqBuf := c.parent.newIntraReq(reqGet, meta, lom.Bck()).NewPack(mm)
-		n, err = c.parent.readRemote(lom, node, uname, iReqBuf, w)
+		n, err = c.parent.readRemote(lom, node, uname, iReqBuf, w, traceParent)
 		mm.Free(iReqBuf)
 		cmn.Close(w)
 
@@ -331,7 +334,7 @@ func (c *getJogger) restoreReplicatedFromDisk(lom *cluster.LOM, meta *Metadata,
 // * []slice - a list of received slices in correct order (missing slices = nil)
 // * map[int]string - a map of slice locations: SliceID <-> DaemonID
 func (c *getJogger) requestSlices(lom *cluster.LOM, meta *Metadata, nodes map[string]*Metadata,
-	toDisk bool) ([]*slice, map[int]string, error) {
+	toDisk bool, traceParent string) ([]*slice, map[int]string, error) {
 	var (
 		wgSlices = cmn.NewTimeoutGroup()
 		sliceCnt = meta.Data + meta.Parity
@@ -386,9 +389,10 @@ func (c *getJogger) requestSlices(lom *cluster.LOM, meta *Metadata, nodes map[st
 	mm := c.parent.t.SmallMMSA()
 	request := iReq.NewPack(mm)
 	hdr := transport.ObjHdr{
-		Bck:     lom.Bck().Bck,
-		ObjName: lom.ObjName,
-		Opaque:  request,
+		Bck:         lom.Bck().Bck,
+		ObjName:     lom.ObjName,
+		Opaque:      request,
+		TraceParent: traceParent,
 	}
 
 	// broadcast slice request and wait for all targets respond
@@ -767,13 +771,14 @@ func (c *getJogger) uploadRestoredSlices(lom *cluster.LOM, meta *Metadata, slice
 // * req - original request
 // * meta - rebuild object's metadata
 // * nodes - the list of targets that responded with valid metadata
-func (c *getJogger) restoreEncoded(lom *cluster.LOM, meta *Metadata, nodes map[string]*Metadata, toDisk bool) error {
+func (c *getJogger) restoreEncoded(lom *cluster.LOM, meta *Metadata, nodes map[string]*Metadata, toDisk bool,
+	traceParent string) error {
 	if glog.FastV(4, glog.SmoduleEC) {
 		glog.Infof("Starting EC restore %s", lom)
 	}
 
 	// download all slices from the targets that have sent metadata
-	slices, idToNode, err := c.requestSlices(lom, meta, nodes, toDisk)
+	slices, idToNode, err := c.requestSlices(lom, meta, nodes, toDisk, traceParent)
 
 	freeWriters := func() {
 		for _, slice := range slices {
@@ -830,7 +835,7 @@ func (c *getJogger) restoreEncoded(lom *cluster.LOM, meta *Metadata, nodes map[s
 }
 
 // Entry point: restores main objects and slices if possible
-func (c *getJogger) restore(lom *cluster.LOM, toDisk bool) error {
+func (c *getJogger) restore(lom *cluster.LOM, toDisk bool, traceParent string) error {
 	if lom.Bprops() == nil || !lom.Bprops().EC.Enabled {
 		return ErrorECDisabled
 	}
@@ -848,9 +853,9 @@ func (c *getJogger) restore(lom *cluster.LOM, toDisk bool) error {
 
 	if meta.IsCopy {
 		if toDisk {
-			return c.restoreReplicatedFromDisk(lom, meta, nodes)
+			return c.restoreReplicatedFromDisk(lom, meta, nodes, traceParent)
 		}
-		return c.restoreReplicatedFromMemory(lom, meta, nodes)
+		return c.restoreReplicatedFromMemory(lom, meta, nodes, traceParent)
 	}
 
 	if len(nodes) < meta.Data {
@@ -858,7 +863,7 @@ func (c *getJogger) restore(lom *cluster.LOM, toDisk bool) error {
 			meta.Data, len(nodes))
 	}
 
-	return c.restoreEncoded(lom, meta, nodes, toDisk)
+	return c.restoreEncoded(lom, meta, nodes, toDisk, traceParent)
 }
 
 // broadcast request for object's metadata. The function returns the list of
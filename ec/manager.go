@@ -17,6 +17,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/tracing"
 	"github.com/NVIDIA/aistore/transport"
 	"github.com/NVIDIA/aistore/transport/bundle"
 	"github.com/NVIDIA/aistore/xaction/xreg"
@@ -237,7 +238,10 @@ func (mgr *Manager) recvRequest(w http.ResponseWriter, hdr transport.ObjHdr, obj
 			return
 		}
 	}
+	span := tracing.StartRemote(hdr.TraceParent, "ec.respond")
+	span.SetAttr("object", hdr.ObjName)
 	mgr.RestoreBckRespXact(bck).DispatchReq(iReq, bck, hdr.ObjName)
+	span.End()
 }
 
 // A function to process big chunks of data (replica/slice/meta) sent from other targets
@@ -341,7 +345,9 @@ func (mgr *Manager) CleanupObject(lom *cluster.LOM) {
 	mgr.RestoreBckPutXact(lom.Bck()).Cleanup(req, lom)
 }
 
-func (mgr *Manager) RestoreObject(lom *cluster.LOM) error {
+// RestoreObject restores the object from its replicas or slices stored on other targets;
+// traceParent (if not empty) is propagated to the targets involved (see tracing).
+func (mgr *Manager) RestoreObject(lom *cluster.LOM, traceParent string) error {
 	if !lom.Bprops().EC.Enabled {
 		return ErrorECDisabled
 	}
@@ -358,9 +364,10 @@ func (mgr *Manager) RestoreObject(lom *cluster.LOM) error {
 
 	cmn.Assert(lom.MpathInfo() != nil && lom.MpathInfo().Path != "")
 	req := &Request{
-		Action: ActRestore,
-		LIF:    lom.LIF(),
-		ErrCh:  make(chan error), // unbuffered
+		Action:      ActRestore,
+		LIF:         lom.LIF(),
+		ErrCh:       make(chan error), // unbuffered
+		TraceParent: traceParent,
 	}
 
 	mgr.RestoreBckGetXact(lom.Bck()).Decode(req, lom)
@@ -274,12 +274,14 @@ func (r *xactECBase) sendByDaemonID(daemonIDs []string, hdr transport.ObjHdr,
 //		name, it puts the data to its writer and notifies when download is done
 // * request - request to send
 // * writer - an opened writer that will receive the replica/slice/meta
+// * traceParent - trace context to propagate (optional)
 func (r *xactECBase) readRemote(lom *cluster.LOM, daemonID, uname string, request []byte,
-	writer io.Writer) (int64, error) {
+	writer io.Writer, traceParent string) (int64, error) {
 	hdr := transport.ObjHdr{
-		Bck:     lom.Bucket(),
-		ObjName: lom.ObjName,
-		Opaque:  request,
+		Bck:         lom.Bucket(),
+		ObjName:     lom.ObjName,
+		Opaque:      request,
+		TraceParent: traceParent,
 	}
 	sw := &slice{
 		writer: writer,
@@ -370,6 +372,7 @@ func (r *xactECBase) writeRemote(daemonIDs []string, lom *cluster.LOM, src *data
 		Size:    src.size,
 		Version: lom.Version(),
 		Atime:   lom.AtimeUnix(),
+		Mtime:   lom.LastModifiedUnix(),
 	}
 	if src.metadata != nil && src.metadata.SliceID != 0 {
 		// for a slice read everything from slice's metadata
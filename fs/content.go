@@ -33,6 +33,10 @@ const (
 	contentTypeLen = 2
 	ObjectType     = "ob"
 	WorkfileType   = "wk"
+	ObjVersionType = "vr"
+	MptUploadType  = "mp"
+
+	objVersionPrefix = "~" // see ObjVersionsDir
 )
 
 type (
@@ -151,8 +155,10 @@ func (f *ContentSpecMgr) PermToProcess(fqn string) (ok bool) {
 // FIXME: This should be probably placed somewhere else \/
 
 type (
-	ObjectContentResolver   struct{}
-	WorkfileContentResolver struct{}
+	ObjectContentResolver     struct{}
+	WorkfileContentResolver   struct{}
+	ObjVersionContentResolver struct{}
+	MptUploadContentResolver  struct{}
 )
 
 func (wf *ObjectContentResolver) PermToMove() bool    { return true }
@@ -204,3 +210,56 @@ func (wf *WorkfileContentResolver) ParseUniqueFQN(base string) (orig string, old
 
 	return base[:tieIndex], filePID != pid, true
 }
+
+// Prior versions of an object are stored under a per-object directory:
+// <escaped object name>/~<version> (see ObjVersionsDir). They are neither
+// moved nor evicted on their own - the history follows the object.
+func (vr *ObjVersionContentResolver) PermToMove() bool    { return false }
+func (vr *ObjVersionContentResolver) PermToEvict() bool   { return false }
+func (vr *ObjVersionContentResolver) PermToProcess() bool { return false }
+
+func (vr *ObjVersionContentResolver) GenUniqueFQN(base, version string) string {
+	return ObjVersionsDir(base) + "/" + objVersionPrefix + version
+}
+
+func (vr *ObjVersionContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
+
+// ObjVersionsDir returns the name of the directory that holds prior versions of
+// the object. Each component of the object name that starts with '~' gets
+// prefixed with another '~' - so that a version file ("~<version>") never
+// collides with the history directory of the object named "<object name>/~<version>".
+func ObjVersionsDir(objName string) string {
+	if !strings.Contains(objName, objVersionPrefix) {
+		return objName
+	}
+	parts := strings.Split(objName, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, objVersionPrefix) {
+			parts[i] = objVersionPrefix + part
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// ParseObjVersion returns the version of the given file under ObjVersionsDir.
+func ParseObjVersion(fname string) (version string, ok bool) {
+	if !strings.HasPrefix(fname, objVersionPrefix) {
+		return "", false
+	}
+	return fname[len(objVersionPrefix):], true
+}
+
+// S3 multipart uploads are stored under per-upload directories: <upload ID>/...
+// (see ais/s3compat). They stay on the mountpath where they were started and are
+// removed upon completion, abort, or when stale.
+func (mp *MptUploadContentResolver) PermToMove() bool    { return false }
+func (mp *MptUploadContentResolver) PermToEvict() bool   { return false }
+func (mp *MptUploadContentResolver) PermToProcess() bool { return false }
+
+func (mp *MptUploadContentResolver) GenUniqueFQN(base, _ string) string { return base }
+
+func (mp *MptUploadContentResolver) ParseUniqueFQN(base string) (orig string, old, ok bool) {
+	return base, false, true
+}
@@ -389,3 +389,25 @@ func BenchmarkParseFQN(b *testing.B) {
 		parsedFQN, _ = fs.ParseFQN(fqn)
 	}
 }
+
+func TestObjVersionsDir(t *testing.T) {
+	var (
+		vr    = &fs.ObjVersionContentResolver{}
+		names = []string{"obj", "obj/~2", "obj/~~2", "~obj", "a~b/~/c"}
+		seen  = make(map[string]string, 2*len(names))
+	)
+	for _, name := range names {
+		for _, fqn := range []string{fs.ObjVersionsDir(name), vr.GenUniqueFQN(name, "2")} {
+			prev, ok := seen[fqn]
+			tassert.Errorf(t, !ok, "%q (object %q) collides with object %q", fqn, name, prev)
+			seen[fqn] = name
+		}
+	}
+	tassert.Errorf(t, fs.ObjVersionsDir("a~b/~/c") == "a~b/~~/c", "unexpected %q", fs.ObjVersionsDir("a~b/~/c"))
+
+	fqn := vr.GenUniqueFQN("obj/~2", "3")
+	version, ok := fs.ParseObjVersion(fqn[strings.LastIndex(fqn, "/")+1:])
+	tassert.Errorf(t, ok && version == "3", "expected version 3, got %q (%t)", version, ok)
+	_, ok = fs.ParseObjVersion("2")
+	tassert.Errorf(t, !ok, "expected %q not to be a version", "2")
+}
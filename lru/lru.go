@@ -400,6 +400,14 @@ func (j *lruJ) walk(fqn string, de fs.DirEntry) error {
 	if lom.HasCopies() && lom.IsCopy() {
 		return nil
 	}
+	// not yet uploaded to the remote bucket (see cmn.WriteBack)
+	if lom.IsWriteBackPending() {
+		return nil
+	}
+	// still retained (see cmn.ObjLockConf)
+	if lom.CheckRetention(false) != nil {
+		return nil
+	}
 	if !lom.IsHRW() {
 		j.misplaced = append(j.misplaced, lom)
 		return nil
@@ -526,6 +534,10 @@ func (j *lruJ) _throttle(usedPct int64) (err error) {
 // remove local copies that "belong" to different LRU joggers; hence, space accounting may be temporarily not precise
 func (j *lruJ) evictObj(lom *cluster.LOM) (ok bool) {
 	lom.Lock(true)
+	if err := lom.Load(false); err == nil && (lom.IsWriteBackPending() || lom.CheckRetention(false) != nil) {
+		lom.Unlock(true) // overwritten in write-back mode (or retention extended) since
+		return
+	}
 	if err := lom.Remove(); err == nil {
 		ok = true
 	} else {
@@ -46,6 +46,7 @@ var wiProps = []string{
 	cmn.GetPropsStatus,
 	cmn.GetPropsCopies,
 	cmn.GetTargetURL,
+	cmn.GetPropsVersions,
 }
 
 func NewWalkInfo(ctx context.Context, t cluster.Target, msg *cmn.SelectMsg) *WalkInfo {
@@ -68,6 +69,9 @@ func NewWalkInfo(ctx context.Context, t cluster.Target, msg *cmn.SelectMsg) *Wal
 	for _, prop := range wiProps {
 		propNeeded[prop] = msg.WantProp(prop)
 	}
+	if msg.IsFlagSet(cmn.SelectVersions) {
+		propNeeded[cmn.GetPropsVersions] = true
+	}
 	return &WalkInfo{
 		t:            t, // targetrunner
 		smap:         t.Sowner().Get(),
@@ -88,6 +92,7 @@ func (wi *WalkInfo) needVersion() bool   { return wi.propNeeded[cmn.GetPropsVers
 func (wi *WalkInfo) needStatus() bool    { return wi.propNeeded[cmn.GetPropsStatus] } //nolint:unused // left for consistency
 func (wi *WalkInfo) needCopies() bool    { return wi.propNeeded[cmn.GetPropsCopies] }
 func (wi *WalkInfo) needTargetURL() bool { return wi.propNeeded[cmn.GetTargetURL] }
+func (wi *WalkInfo) needVersions() bool  { return wi.propNeeded[cmn.GetPropsVersions] }
 
 // Checks if the directory should be processed by cache list call
 // Does checks:
@@ -158,6 +163,11 @@ func (wi *WalkInfo) lsObject(lom *cluster.LOM, objStatus uint16) *cmn.BucketEntr
 	if wi.needSize() {
 		fileInfo.Size = lom.Size()
 	}
+	if wi.needVersions() && lom.Bck().IsAIS() {
+		if versions, err := lom.PrevVersions(); err == nil {
+			fileInfo.Versions = strings.Join(versions, ",")
+		}
+	}
 	if wi.postCallback != nil {
 		wi.postCallback(lom)
 	}
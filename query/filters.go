@@ -39,6 +39,8 @@ const (
 	VersionGeF = "version_ge"
 
 	ExtF = "ext"
+
+	TagF = "tag" // args: tag key and value (empty value matches any value)
 )
 
 var functionMeta = map[string]filterMeta{
@@ -55,6 +57,7 @@ var functionMeta = map[string]filterMeta{
 	VersionGeF: {1, intArg},
 
 	ExtF: {1, stringArg},
+	TagF: {2, stringArg},
 }
 
 func NewFilter(fname string, args []string) *FilterMsg {
@@ -136,6 +139,8 @@ func functionFilterMsgToObjectFilter(filterMsg *FilterMsg) (cluster.ObjectFilter
 		switch filterMsg.FName {
 		case ExtF:
 			return ExtFilter(filterMsg.Args[0]), nil
+		case TagF:
+			return TagFilter(filterMsg.Args[0], filterMsg.Args[1]), nil
 		default:
 			cmn.Assert(false)
 			return nil, nil
@@ -299,6 +304,23 @@ func ExtFilter(ext string) cluster.ObjectFilter {
 	}
 }
 
+// TagFilter matches objects that have the tag `key` (see cluster.LOM.Tags);
+// if `value` is not empty, the tag must have this value.
+func TagFilter(key, value string) cluster.ObjectFilter {
+	return func(lom *cluster.LOM) bool {
+		v, ok := lom.GetTag(key)
+		return ok && (value == "" || v == value)
+	}
+}
+
+func TagFilterMsg(key, value string) *FilterMsg {
+	return &FilterMsg{
+		Type:  FUNCTION,
+		FName: TagF,
+		Args:  []string{key, value},
+	}
+}
+
 func And(filters ...cluster.ObjectFilter) cluster.ObjectFilter {
 	return func(lom *cluster.LOM) bool {
 		for _, f := range filters {
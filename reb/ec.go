@@ -334,6 +334,7 @@ func (reb *Manager) sendFromDisk(ct *rebCT, target *cluster.Snode) (err error) {
 		if lom != nil {
 			o.Hdr.ObjAttrs.Atime = lom.AtimeUnix()
 			o.Hdr.ObjAttrs.Version = lom.Version()
+			o.Hdr.ObjAttrs.Mtime = lom.LastModifiedUnix()
 			if cksum := lom.Cksum(); cksum != nil {
 				o.Hdr.ObjAttrs.CksumType, o.Hdr.ObjAttrs.CksumValue = cksum.Get()
 			}
@@ -5,9 +5,11 @@
 package reb
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"net/http"
+	"os"
 	"path/filepath"
 	"runtime"
 	"sync"
@@ -577,6 +579,85 @@ func (rj *rebalanceJogger) objSentCallback(hdr transport.ObjHdr, r io.ReadCloser
 	)
 }
 
+// Sends prior versions of the object (see cluster.SaveVersion); the caller must hold the object's lock.
+func (rj *rebalanceJogger) sendVersions(lom *cluster.LOM, tsi *cluster.Snode) (n uint32, err error) {
+	versions, err := lom.PrevVersions()
+	if err != nil {
+		return
+	}
+	for _, version := range versions {
+		var (
+			vh     *versionHandle
+			vfqn   = lom.VersionFQN(version)
+			finfo  os.FileInfo
+			md     []byte
+			ack    regularAck
+			mm     = rj.m.t.SmallMMSA()
+			opaque []byte
+			o      *transport.Obj
+		)
+		if finfo, err = os.Stat(vfqn); err != nil {
+			if os.IsNotExist(err) {
+				err = nil
+				continue // removed in the meantime
+			}
+			return
+		}
+		if md, err = lom.VersionMD(version); err != nil {
+			return
+		}
+		if vh, err = newVersionHandle(vfqn, md); err != nil {
+			return
+		}
+		ack = regularAck{rebID: rj.m.RebID(), daemonID: rj.m.t.SID(), version: version, mdSize: uint32(len(md))}
+		opaque = ack.NewPack(mm)
+		o = transport.AllocSend()
+		o.Hdr = transport.ObjHdr{
+			Bck:      lom.Bucket(),
+			ObjName:  lom.ObjName,
+			Opaque:   opaque,
+			ObjAttrs: transport.ObjectAttrs{Size: int64(len(md)) + finfo.Size(), Version: version},
+		}
+		o.Callback = rj.versionSentCallback
+		if err = rj.m.dm.Send(o, vh, tsi); err != nil {
+			mm.Free(opaque)
+			return
+		}
+		n++
+	}
+	return
+}
+
+// prior version of the object to send: its metadata followed by its content
+type versionHandle struct {
+	io.Reader
+	fh  *cmn.FileHandle
+	fqn string
+	md  []byte
+}
+
+// interface guard
+var _ cmn.ReadOpenCloser = (*versionHandle)(nil)
+
+func newVersionHandle(fqn string, md []byte) (*versionHandle, error) {
+	fh, err := cmn.NewFileHandle(fqn)
+	if err != nil {
+		return nil, err
+	}
+	return &versionHandle{Reader: io.MultiReader(bytes.NewReader(md), fh), fh: fh, fqn: fqn, md: md}, nil
+}
+
+func (vh *versionHandle) Close() error                      { return vh.fh.Close() }
+func (vh *versionHandle) Open() (cmn.ReadOpenCloser, error) { return newVersionHandle(vh.fqn, vh.md) }
+
+func (rj *rebalanceJogger) versionSentCallback(hdr transport.ObjHdr, _ io.ReadCloser, _ unsafe.Pointer, err error) {
+	rj.m.t.SmallMMSA().Free(hdr.Opaque)
+	if err != nil {
+		glog.Errorf("%s: failed to send o[%s/%s] version %q, err: %v",
+			rj.m.t.Snode(), hdr.Bck, hdr.ObjName, hdr.ObjAttrs.Version, err)
+	}
+}
+
 func (rj *rebalanceJogger) walk(fqn string, de fs.DirEntry) (err error) {
 	if rj.xreb.Aborted() || rj.xreb.Finished() {
 		return cmn.NewAbortedErrorDetails("traversal", rj.xreb.String())
@@ -665,6 +746,14 @@ func (rj *rebalanceJogger) send(lom *cluster.LOM, tsi *cluster.Snode, addAck boo
 		return
 	}
 	cksumType, cksumValue := cksum.Get()
+	// prior versions go ahead of the object - the latter gets removed only when
+	// the destination acknowledges both (see recvObjRegular)
+	var versions uint32
+	if lom.Bck().IsAIS() {
+		if versions, err = rj.sendVersions(lom, tsi); err != nil {
+			return
+		}
+	}
 	if file, err = cmn.NewFileHandle(lom.FQN); err != nil {
 		return
 	}
@@ -674,7 +763,8 @@ func (rj *rebalanceJogger) send(lom *cluster.LOM, tsi *cluster.Snode, addAck boo
 	}
 	// transmit
 	var (
-		ack    = regularAck{rebID: rj.m.RebID(), daemonID: rj.m.t.SID()}
+		ack = regularAck{rebID: rj.m.RebID(), daemonID: rj.m.t.SID(), writeBack: lom.IsWriteBackPending(),
+			versions: versions}
 		mm     = rj.m.t.SmallMMSA()
 		opaque = ack.NewPack(mm)
 		o      = transport.AllocSend()
@@ -689,6 +779,7 @@ func (rj *rebalanceJogger) send(lom *cluster.LOM, tsi *cluster.Snode, addAck boo
 			CksumType:  cksumType,
 			CksumValue: cksumValue,
 			Version:    lom.Version(),
+			Mtime:      lom.LastModifiedUnix(),
 		},
 	}
 	o.Callback, o.CmplPtr = rj.objSentCallback, unsafe.Pointer(lom)
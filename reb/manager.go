@@ -10,6 +10,7 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/url"
+	"strconv"
 	"sync"
 	"time"
 	"unsafe"
@@ -243,6 +244,10 @@ func (reb *Manager) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unp
 	if marked.Interrupted || marked.Xact == nil {
 		return
 	}
+	if ack.version != "" {
+		reb.recvVersion(lom, hdr, ack, objReader)
+		return
+	}
 
 	if stage := reb.stages.stage.Load(); stage >= rebStageFin {
 		reb.laterx.Store(true)
@@ -256,6 +261,13 @@ func (reb *Manager) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unp
 	}
 	lom.SetAtimeUnix(hdr.ObjAttrs.Atime)
 	lom.SetVersion(hdr.ObjAttrs.Version)
+	if hdr.ObjAttrs.Mtime != 0 {
+		lom.SetLastModifiedUnix(hdr.ObjAttrs.Mtime)
+	}
+	if ack.writeBack {
+		// not uploaded yet - the target takes over the write-back (see ais/tgtwb.go)
+		lom.SetCustomKey(cluster.WriteBackObjMD, strconv.FormatInt(time.Now().UnixNano(), 10))
+	}
 
 	params := cluster.PutObjectParams{
 		Tag:      fs.WorkfilePut,
@@ -272,6 +284,17 @@ func (reb *Manager) recvObjRegular(hdr transport.ObjHdr, smap *cluster.Smap, unp
 	if glog.FastV(5, glog.SmoduleReb) {
 		glog.Infof("%s: from %s %s", reb.t.Snode(), tsid, lom)
 	}
+	// not acknowledging (the sender keeps the object) unless all its prior versions were received
+	if ack.versions > 0 {
+		lom.Lock(false)
+		versions, err := lom.PrevVersions()
+		lom.Unlock(false)
+		if err != nil || len(versions) < int(ack.versions) {
+			glog.Errorf("%s: from %s %s: received %d out of %d prior versions (err: %v)",
+				reb.t.Snode(), tsid, lom, len(versions), ack.versions, err)
+			return
+		}
+	}
 	reb.statTracker.AddMany(
 		stats.NamedVal64{Name: stats.RebRxCount, Value: 1},
 		stats.NamedVal64{Name: stats.RebRxSize, Value: hdr.ObjAttrs.Size},
@@ -462,10 +485,33 @@ func (reb *Manager) recvRegularAck(hdr transport.ObjHdr, unpacker *cmn.ByteUnpac
 	if err := lom.Remove(); err != nil {
 		glog.Errorf("%s: error removing %s, err: %v", reb.t.Snode(), lom, err)
 	}
+	// NOTE: prior versions migrate with the object (see sendVersions)
+	if lom.Bck().IsAIS() {
+		if err := lom.DelAllVersions(); err != nil {
+			glog.Errorf("%s: error removing prior versions of %s, err: %v", reb.t.Snode(), lom, err)
+		}
+	}
 	lom.Unlock(true)
 	reb.delLomAck(lom)
 }
 
+// Stores the prior version of the object sent ahead of the object itself (see sendVersions).
+func (reb *Manager) recvVersion(lom *cluster.LOM, hdr transport.ObjHdr, ack *regularAck, objReader io.Reader) {
+	md := make([]byte, ack.mdSize)
+	if _, err := io.ReadFull(objReader, md); err != nil {
+		glog.Errorf("%s: from %s %s version %q: %v", reb.t.Snode(), ack.daemonID, lom, ack.version, err)
+		return
+	}
+	buf, slab := reb.t.MMSA().Alloc()
+	lom.Lock(true)
+	err := lom.SaveVersion(ack.version, md, objReader, hdr.ObjAttrs.Size-int64(ack.mdSize), buf)
+	lom.Unlock(true)
+	slab.Free(buf)
+	if err != nil {
+		glog.Errorf("%s: from %s %s version %q: %v", reb.t.Snode(), ack.daemonID, lom, ack.version, err)
+	}
+}
+
 func (reb *Manager) recvAck(w http.ResponseWriter, hdr transport.ObjHdr, _ io.Reader, err error) {
 	if err != nil {
 		glog.Error(err)
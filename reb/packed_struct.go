@@ -22,8 +22,13 @@ const rebMsgKindSize = 1
 
 type (
 	regularAck struct {
-		rebID    int64
-		daemonID string // sender's DaemonID
+		rebID     int64
+		daemonID  string // sender's DaemonID
+		writeBack bool   // the object is pending write-back (see cluster.WriteBackObjMD)
+		// prior versions (see cluster.SaveVersion) are sent ahead of the object:
+		version  string // the sent one is the prior version (its metadata followed by its content)
+		mdSize   uint32 // size of the version's metadata
+		versions uint32 // number of prior versions sent ahead of the object
 	}
 	ecAck struct {
 		rebID    int64
@@ -55,13 +60,29 @@ func (rack *regularAck) Unpack(unpacker *cmn.ByteUnpack) (err error) {
 	if rack.rebID, err = unpacker.ReadInt64(); err != nil {
 		return
 	}
-	rack.daemonID, err = unpacker.ReadString()
+	if rack.daemonID, err = unpacker.ReadString(); err != nil {
+		return
+	}
+	if rack.writeBack, err = unpacker.ReadBool(); err != nil {
+		return
+	}
+	if rack.version, err = unpacker.ReadString(); err != nil {
+		return
+	}
+	if rack.mdSize, err = unpacker.ReadUint32(); err != nil {
+		return
+	}
+	rack.versions, err = unpacker.ReadUint32()
 	return
 }
 
 func (rack *regularAck) Pack(packer *cmn.BytePack) {
 	packer.WriteInt64(rack.rebID)
 	packer.WriteString(rack.daemonID)
+	packer.WriteBool(rack.writeBack)
+	packer.WriteString(rack.version)
+	packer.WriteUint32(rack.mdSize)
+	packer.WriteUint32(rack.versions)
 }
 
 func (rack *regularAck) NewPack(mm *memsys.MMSA) []byte { // TODO: consider adding as another cmn.Packer interface
@@ -73,9 +94,9 @@ func (rack *regularAck) NewPack(mm *memsys.MMSA) []byte { // TODO: consider addi
 	return packer.Bytes()
 }
 
-// rebID + length of DaemonID + Daemon
+// rebID + length of DaemonID + Daemon + writeBack + length of version + version + mdSize + versions
 func (rack *regularAck) PackedSize() int {
-	return cmn.SizeofI64 + cmn.SizeofLen + len(rack.daemonID)
+	return cmn.SizeofI64 + cmn.SizeofLen + len(rack.daemonID) + 1 + cmn.SizeofLen + len(rack.version) + 2*cmn.SizeofI32
 }
 
 func (eack *ecAck) Unpack(unpacker *cmn.ByteUnpack) (err error) {
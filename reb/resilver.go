@@ -189,12 +189,30 @@ func (rj *joggerCtx) moveObject(lom *cluster.LOM, buf []byte) {
 			glog.Warningf("%s: failed to cleanup old metafile %q: %v", lom, metaOldPath, err)
 		}
 	}
+	if lom.Bck().IsAIS() {
+		rj.moveVersions(lom, buf)
+	}
 
 	rj.xact.BytesAdd(size)
 	rj.xact.ObjectsInc()
 	// NOTE: Rely on LRU to remove "misplaced".
 }
 
+// Moves prior versions of the object (if any) along with the object.
+func (rj *joggerCtx) moveVersions(lom *cluster.LOM, buf []byte) {
+	hlom := cluster.AllocLOM(lom.ObjName)
+	defer cluster.FreeLOM(hlom)
+	if err := hlom.Init(lom.Bucket()); err != nil {
+		glog.Warningf("%s: %v", lom, err)
+		return
+	}
+	lom.Lock(true)
+	if err := lom.MoveVersions(hlom, buf); err != nil {
+		glog.Errorf("%s: failed to move prior versions to %s: %v", lom, hlom.MpathInfo(), err)
+	}
+	lom.Unlock(true)
+}
+
 func (rj *joggerCtx) visitObj(lom *cluster.LOM, buf []byte) (err error) {
 	rj.moveObject(lom, buf)
 	return nil
@@ -7,6 +7,7 @@ package stats
 
 import (
 	"encoding/json"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"os"
@@ -71,6 +72,13 @@ const (
 	ErrRangeCount    = "err.range.n"
 	ErrDownloadCount = "err.dl.n"
 
+	// requests rejected (429) as per rate limits (see cmn.RateLimitConf):
+	// proxies limit requests per second, targets - bandwidth
+	ErrRateLimitBckRPSCount  = "err.ratelimit.bck.rps.n"
+	ErrRateLimitUserRPSCount = "err.ratelimit.user.rps.n"
+	ErrRateLimitBckBWCount   = "err.ratelimit.bck.bw.n"
+	ErrRateLimitUserBWCount  = "err.ratelimit.user.bw.n"
+
 	// KindLatency
 	GetLatency          = "get.ns"
 	ListLatency         = "lst.ns"
@@ -96,6 +104,7 @@ type (
 		CoreStats() *CoreStats
 		GetWhatStats() interface{}
 		RegisterAll()
+		WritePrometheus(w io.Writer, xacts []cluster.XactStats) error
 	}
 	NamedVal64 struct {
 		Name       string
@@ -168,6 +177,7 @@ type (
 		ticker    *time.Ticker
 		ctracker  copyTracker // to avoid making it at runtime
 		daemon    runnerHost
+		node      *cluster.Snode
 		startedUp atomic.Bool
 	}
 	// Stats are tracked via a map of stats names (key) to statsValue (values).
@@ -180,6 +190,9 @@ type (
 		numSamples int64
 		cumulative int64
 		isCommon   bool // optional, common to the proxy and target
+		// latency only: cumulative number of samples and their histogram (see promLatencyBuckets)
+		totalSamples int64
+		hist         []int64
 	}
 	copyValue struct {
 		Value int64 `json:"v,string"`
@@ -244,6 +257,12 @@ func (s *CoreStats) doAdd(name, nameSuffix string, val int64) {
 		v.numSamples++
 		v.cumulative += val
 		v.Value += val
+		v.totalSamples++
+		if i := sort.Search(len(promLatencyBuckets), func(i int) bool {
+			return time.Duration(val) <= promLatencyBuckets[i]
+		}); i < len(v.hist) {
+			v.hist[i]++
+		}
 		v.Unlock()
 	case KindThroughput:
 		v.Lock()
@@ -397,6 +416,9 @@ func (tracker statsTracker) register(key, kind string, isCommon ...bool) {
 	cmn.Assertf(cmn.StringInSlice(kind, kinds), "invalid stats kind %q", kind)
 
 	tracker[key] = &statsValue{kind: kind}
+	if kind == KindLatency {
+		tracker[key].hist = make([]int64, len(promLatencyBuckets))
+	}
 	if len(isCommon) > 0 {
 		tracker[key].isCommon = isCommon[0]
 	}
@@ -425,6 +447,10 @@ func (tracker statsTracker) registerCommonStats() {
 	tracker.register(ErrListCount, KindCounter, true)
 	tracker.register(ErrRangeCount, KindCounter, true)
 	tracker.register(ErrDownloadCount, KindCounter, true)
+	tracker.register(ErrRateLimitBckRPSCount, KindCounter, true)
+	tracker.register(ErrRateLimitUserRPSCount, KindCounter, true)
+	tracker.register(ErrRateLimitBckBWCount, KindCounter, true)
+	tracker.register(ErrRateLimitUserBWCount, KindCounter, true)
 
 	tracker.register(Uptime, KindSpecial, true)
 }
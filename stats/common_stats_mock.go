@@ -5,6 +5,12 @@
  */
 package stats
 
+import (
+	"io"
+
+	"github.com/NVIDIA/aistore/cluster"
+)
+
 type (
 	TrackerMock struct{}
 )
@@ -16,11 +22,12 @@ func NewTrackerMock() Tracker {
 	return &TrackerMock{}
 }
 
-func (*TrackerMock) StartedUp() bool                       { return true }
-func (*TrackerMock) Add(name string, val int64)            {}
-func (*TrackerMock) Get(name string) int64                 { return 0 }
-func (*TrackerMock) AddErrorHTTP(method string, val int64) {}
-func (*TrackerMock) AddMany(namedVal64 ...NamedVal64)      {}
-func (*TrackerMock) RegisterAll()                          {}
-func (*TrackerMock) CoreStats() *CoreStats                 { return nil }
-func (*TrackerMock) GetWhatStats() interface{}             { return nil }
+func (*TrackerMock) StartedUp() bool                                      { return true }
+func (*TrackerMock) Add(name string, val int64)                           {}
+func (*TrackerMock) Get(name string) int64                                { return 0 }
+func (*TrackerMock) AddErrorHTTP(method string, val int64)                {}
+func (*TrackerMock) AddMany(namedVal64 ...NamedVal64)                     {}
+func (*TrackerMock) RegisterAll()                                         {}
+func (*TrackerMock) CoreStats() *CoreStats                                { return nil }
+func (*TrackerMock) GetWhatStats() interface{}                            { return nil }
+func (*TrackerMock) WritePrometheus(io.Writer, []cluster.XactStats) error { return nil }
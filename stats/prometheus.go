@@ -0,0 +1,247 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/memsys"
+)
+
+// Prometheus metrics are served in the text-based exposition format, see
+// https://prometheus.io/docs/instrumenting/exposition_formats
+//
+// CoreStats trackers are exposed as follows:
+//  -> "*.n" counter     => ais_*_total counter
+//  -> "*.size" counter  => ais_*_bytes_total counter
+//  -> "*.bps"           => ais_*_bytes_total counter (cumulative)
+//  -> "*.ns" latency    => ais_*_seconds histogram
+//  -> "*.ns" special    => ais_*_seconds gauge
+// All metrics are labeled with the node ID and its role (proxy or target).
+
+const (
+	PromContentType = "text/plain; version=0.0.4; charset=utf-8"
+	promPrefix      = "ais_"
+
+	promCounter   = "counter"
+	promGauge     = "gauge"
+	promHistogram = "histogram"
+)
+
+// upper bounds of the latency histograms' buckets
+var promLatencyBuckets = []time.Duration{
+	time.Millisecond, 5 * time.Millisecond, 10 * time.Millisecond, 25 * time.Millisecond,
+	50 * time.Millisecond, 100 * time.Millisecond, 250 * time.Millisecond, 500 * time.Millisecond,
+	time.Second, 2500 * time.Millisecond, 5 * time.Second, 10 * time.Second,
+}
+
+type (
+	promSample struct {
+		suffix string // histogram: "_bucket", "_sum" or "_count"
+		labels string
+		value  string
+	}
+	promMetric struct {
+		typ     string
+		help    string
+		samples []promSample
+	}
+	// collects metrics (by name) to write them out sorted
+	promWriter struct {
+		metrics map[string]*promMetric
+		labels  string // common labels: node ID and role
+	}
+)
+
+func newPromWriter(node *cluster.Snode) *promWriter {
+	return &promWriter{
+		metrics: make(map[string]*promMetric, 64),
+		labels:  promLabels("node", node.ID(), "role", node.Type()),
+	}
+}
+
+// labels are given as name-value pairs
+func promLabels(labels ...string) string {
+	var sb strings.Builder
+	for i := 0; i < len(labels); i += 2 {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(labels[i])
+		sb.WriteString(`="`)
+		sb.WriteString(promEscape(labels[i+1]))
+		sb.WriteByte('"')
+	}
+	return sb.String()
+}
+
+func promEscape(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`).Replace(s)
+}
+
+func promFloat(f float64) string { return strconv.FormatFloat(f, 'g', -1, 64) }
+
+func (pw *promWriter) add(name, typ, help, value string, labels ...string) {
+	m, ok := pw.metrics[name]
+	if !ok {
+		m = &promMetric{typ: typ, help: help}
+		pw.metrics[name] = m
+	}
+	lbls := pw.labels
+	if len(labels) > 0 {
+		lbls += "," + promLabels(labels...)
+	}
+	m.samples = append(m.samples, promSample{labels: lbls, value: value})
+}
+
+func (pw *promWriter) counter(name, help string, value int64, labels ...string) {
+	pw.add(name, promCounter, help, strconv.FormatInt(value, 10), labels...)
+}
+
+func (pw *promWriter) gauge(name, help string, value float64, labels ...string) {
+	pw.add(name, promGauge, help, promFloat(value), labels...)
+}
+
+func (pw *promWriter) histogram(name, help string, v *statsValue) {
+	var (
+		m   = &promMetric{typ: promHistogram, help: help}
+		cnt int64
+	)
+	for i, bound := range promLatencyBuckets {
+		cnt += v.hist[i]
+		m.samples = append(m.samples, promSample{
+			suffix: "_bucket",
+			labels: pw.labels + `,le="` + promFloat(bound.Seconds()) + `"`,
+			value:  strconv.FormatInt(cnt, 10),
+		})
+	}
+	count := strconv.FormatInt(v.totalSamples, 10)
+	m.samples = append(m.samples,
+		promSample{suffix: "_bucket", labels: pw.labels + `,le="+Inf"`, value: count},
+		promSample{suffix: "_sum", labels: pw.labels, value: promFloat(time.Duration(v.cumulative).Seconds())},
+		promSample{suffix: "_count", labels: pw.labels, value: count},
+	)
+	pw.metrics[name] = m
+}
+
+func (pw *promWriter) write(w io.Writer) (err error) {
+	names := make([]string, 0, len(pw.metrics))
+	for name := range pw.metrics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	for _, name := range names {
+		m := pw.metrics[name]
+		fmt.Fprintf(&sb, "# HELP %s %s\n# TYPE %s %s\n", name, m.help, name, m.typ)
+		for _, s := range m.samples {
+			fmt.Fprintf(&sb, "%s%s{%s} %s\n", name, s.suffix, s.labels, s.value)
+		}
+	}
+	_, err = io.WriteString(w, sb.String())
+	return
+}
+
+// Converts the name of the tracker to the name of the Prometheus metric,
+// e.g. "get.cold.size" => "ais_get_cold_bytes_total".
+func promName(name, kind string) string {
+	var (
+		words  = strings.Split(name, ".")
+		parts  = words[:0]
+		suffix string
+	)
+	for _, word := range words {
+		switch word {
+		case "n":
+			suffix = "_total"
+		case "size", "bps":
+			suffix = "_bytes_total"
+		case "ns":
+			suffix = "_seconds"
+		default:
+			parts = append(parts, strings.ReplaceAll(word, "-", "_"))
+		}
+	}
+	if suffix == "" && kind == KindCounter {
+		suffix = "_total"
+	}
+	return promPrefix + strings.Join(parts, "_") + suffix
+}
+
+func (s *CoreStats) promWrite(pw *promWriter) {
+	for name, v := range s.Tracker {
+		pname := promName(name, v.kind)
+		v.RLock()
+		switch v.kind {
+		case KindCounter:
+			pw.counter(pname, "AIS counter "+name, v.Value)
+		case KindThroughput:
+			pw.counter(pname, "AIS cumulative size "+name, v.cumulative)
+		case KindLatency:
+			pw.histogram(pname, "AIS latency "+name, v)
+		default:
+			value := float64(v.Value)
+			if strings.HasSuffix(pname, "_seconds") {
+				value = time.Duration(v.Value).Seconds()
+			}
+			pw.gauge(pname, "AIS "+name, value)
+		}
+		v.RUnlock()
+	}
+}
+
+func promWriteXacts(pw *promWriter, xacts []cluster.XactStats) {
+	for _, xact := range xacts {
+		var (
+			bck    = xact.Bck()
+			labels = []string{"kind", xact.Kind(), "xid", xact.ID(), "bucket", ""}
+		)
+		if bck.Name != "" {
+			labels[5] = bck.String()
+		}
+		pw.gauge(promPrefix+"xaction_running", "running xaction", 1, labels...)
+		pw.gauge(promPrefix+"xaction_objects", "number of objects processed by the running xaction", float64(xact.ObjCount()), labels...)
+		pw.gauge(promPrefix+"xaction_bytes", "size of the data processed by the running xaction", float64(xact.BytesCount()), labels...)
+	}
+}
+
+func promWriteMemsys(pw *promWriter) {
+	pressure := memsys.DefaultPageMM().MemPressure()
+	pw.gauge(promPrefix+"memsys_pressure", "memory pressure: 0 - low, 1 - moderate, 2 - high, 3 - extreme, 4 - OOM",
+		float64(pressure))
+}
+
+func (r *Prunner) WritePrometheus(w io.Writer, xacts []cluster.XactStats) error {
+	pw := newPromWriter(r.node)
+	r.Core.promWrite(pw)
+	promWriteXacts(pw, xacts)
+	promWriteMemsys(pw)
+	return pw.write(w)
+}
+
+func (r *Trunner) WritePrometheus(w io.Writer, xacts []cluster.XactStats) error {
+	pw := newPromWriter(r.node)
+	r.Core.promWrite(pw)
+	promWriteXacts(pw, xacts)
+	promWriteMemsys(pw)
+
+	utils := fs.GetAllMpathUtils()
+	for mpath, capacity := range r.MPCap {
+		pw.gauge(promPrefix+"mountpath_used_bytes", "used capacity of the mountpath", float64(capacity.Used), "mountpath", mpath)
+		pw.gauge(promPrefix+"mountpath_avail_bytes", "available capacity of the mountpath", float64(capacity.Avail), "mountpath", mpath)
+		pw.gauge(promPrefix+"mountpath_utilization_percent", "disk utilization of the mountpath",
+			float64(utils.Util(mpath)), "mountpath", mpath)
+	}
+	return pw.write(w)
+}
@@ -0,0 +1,82 @@
+// Package stats provides methods and functionality to register, track, log,
+// and StatsD-notify statistics that, for the most part, include "counter" and "latency" kinds.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+func TestPromName(t *testing.T) {
+	tests := []struct {
+		name, kind, expected string
+	}{
+		{GetCount, KindCounter, "ais_get_total"},
+		{GetColdSize, KindCounter, "ais_get_cold_bytes_total"},
+		{GetThroughput, KindThroughput, "ais_get_bytes_total"},
+		{GetLatency, KindLatency, "ais_get_seconds"},
+		{Uptime, KindSpecial, "ais_up_time_seconds"},
+		{"put.redirect-err", KindCounter, "ais_put_redirect_err_total"},
+	}
+	for _, test := range tests {
+		pname := promName(test.name, test.kind)
+		tassert.Errorf(t, pname == test.expected, "%q (%s): expected %q, got %q", test.name, test.kind, test.expected, pname)
+	}
+}
+
+func TestPromLabels(t *testing.T) {
+	labels := promLabels("mountpath", "/a \"b\"\\c\n", "kind", "lru")
+	expected := `mountpath="/a \"b\"\\c\n",kind="lru"`
+	tassert.Errorf(t, labels == expected, "expected %s, got %s", expected, labels)
+}
+
+func TestPromWrite(t *testing.T) {
+	var (
+		pw = newPromWriter(&cluster.Snode{DaemonID: "t1", DaemonType: cmn.Target})
+		v  = &statsValue{kind: KindLatency, hist: make([]int64, len(promLatencyBuckets))}
+		sb strings.Builder
+	)
+	// samples: 2 x 250ms, 1s, and 20s (greater than the largest bound)
+	v.hist[6], v.hist[8] = 2, 1
+	v.totalSamples = 4
+	v.cumulative = int64(2*250*time.Millisecond + time.Second + 20*time.Second)
+	pw.histogram("ais_get_seconds", "AIS latency get.ns", v)
+	pw.counter("ais_get_total", "AIS counter get.n", 42)
+	pw.gauge("ais_mountpath_used_bytes", "used capacity of the mountpath", 1024, "mountpath", "/mp\"1\"")
+	tassert.CheckFatal(t, pw.write(&sb))
+
+	out := sb.String()
+	lines := []string{
+		"# HELP ais_get_seconds AIS latency get.ns\n# TYPE ais_get_seconds histogram\n",
+		`ais_get_seconds_bucket{node="t1",role="target",le="0.001"} 0` + "\n",
+		`ais_get_seconds_bucket{node="t1",role="target",le="0.25"} 2` + "\n",
+		`ais_get_seconds_bucket{node="t1",role="target",le="0.5"} 2` + "\n",
+		`ais_get_seconds_bucket{node="t1",role="target",le="1"} 3` + "\n",
+		`ais_get_seconds_bucket{node="t1",role="target",le="10"} 3` + "\n",
+		`ais_get_seconds_bucket{node="t1",role="target",le="+Inf"} 4` + "\n",
+		`ais_get_seconds_sum{node="t1",role="target"} 21.5` + "\n",
+		`ais_get_seconds_count{node="t1",role="target"} 4` + "\n",
+		"# TYPE ais_get_total counter\n" + `ais_get_total{node="t1",role="target"} 42` + "\n",
+		"# TYPE ais_mountpath_used_bytes gauge\n" +
+			`ais_mountpath_used_bytes{node="t1",role="target",mountpath="/mp\"1\""} 1024` + "\n",
+	}
+	for _, line := range lines {
+		tassert.Errorf(t, strings.Contains(out, line), "expected %q in:\n%s", line, out)
+	}
+
+	// metrics are sorted by name
+	var (
+		get   = strings.Index(out, "# HELP ais_get_seconds ")
+		total = strings.Index(out, "# HELP ais_get_total ")
+		mpath = strings.Index(out, "# HELP ais_mountpath_used_bytes ")
+	)
+	tassert.Errorf(t, get < total && total < mpath, "expected metrics sorted by name:\n%s", out)
+}
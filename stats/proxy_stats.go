@@ -50,6 +50,7 @@ func (r *Prunner) Init(p cluster.Node) *atomic.Bool {
 
 	r.statsRunner.name = "proxystats"
 	r.statsRunner.daemon = p
+	r.statsRunner.node = p.Snode()
 
 	r.statsRunner.stopCh = make(chan struct{}, 4)
 	r.statsRunner.workCh = make(chan NamedVal64, 256)
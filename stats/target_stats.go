@@ -36,20 +36,25 @@ const (
 	RebTxSize  = "reb.tx.size"
 	RebRxCount = "reb.rx.n"
 	RebRxSize  = "reb.rx.size"
+	// write-back (asynchronous PUT to remote buckets)
+	WriteBackCount = "wb.n"
+	WriteBackSize  = "wb.size"
 	// errors
-	ErrCksumCount    = "err.cksum.n"
-	ErrCksumSize     = "err.cksum.size"
-	ErrMetadataCount = "err.md.n"
-	ErrIOCount       = "err.io.n"
+	ErrCksumCount     = "err.cksum.n"
+	ErrCksumSize      = "err.cksum.size"
+	ErrMetadataCount  = "err.md.n"
+	ErrIOCount        = "err.io.n"
+	ErrWriteBackCount = "err.wb.n"
 	// special
 	RestartCount = "restart.n"
 
 	// KindLatency
-	PutLatency      = "put.ns"
-	AppendLatency   = "append.ns"
-	GetRedirLatency = "get.redir.ns"
-	PutRedirLatency = "put.redir.ns"
-	DownloadLatency = "dl.ns"
+	PutLatency       = "put.ns"
+	AppendLatency    = "append.ns"
+	GetRedirLatency  = "get.redir.ns"
+	PutRedirLatency  = "put.redir.ns"
+	DownloadLatency  = "dl.ns"
+	WriteBackLatency = "wb.ns"
 
 	// DSort
 	DSortCreationReqCount    = "dsort.creation.req.n"
@@ -106,6 +111,7 @@ func (r *Trunner) Init(t cluster.Target) *atomic.Bool {
 
 	r.statsRunner.name = "targetstats"
 	r.statsRunner.daemon = t
+	r.statsRunner.node = t.Snode()
 
 	r.statsRunner.stopCh = make(chan struct{}, 4)
 	r.statsRunner.workCh = make(chan NamedVal64, 256)
@@ -146,6 +152,7 @@ func (r *Trunner) RegisterAll() {
 	r.Register(ErrCksumSize, KindCounter)
 	r.Register(ErrMetadataCount, KindCounter)
 	r.Register(ErrIOCount, KindCounter)
+	r.Register(ErrWriteBackCount, KindCounter)
 
 	// rebalance
 	r.Register(RebTxCount, KindCounter)
@@ -153,6 +160,11 @@ func (r *Trunner) RegisterAll() {
 	r.Register(RebRxCount, KindCounter)
 	r.Register(RebRxSize, KindCounter)
 
+	// write-back
+	r.Register(WriteBackCount, KindCounter)
+	r.Register(WriteBackSize, KindCounter)
+	r.Register(WriteBackLatency, KindLatency)
+
 	// special
 	r.Register(RestartCount, KindCounter)
 
@@ -0,0 +1,254 @@
+// Package tracing provides distributed tracing of user requests as they travel
+// through the cluster: proxy, redirect, target, intra-cluster streams, and backends.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/atomic"
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/hk"
+)
+
+// Finished spans are batched in memory and exported periodically (by the housekeeper)
+// via the exporter that is configured at the time (see TraceConf):
+//  -> TraceExporterOTLP: OTLP/HTTP request (JSON encoding) to the collector's endpoint
+//  -> TraceExporterFile: one JSON object per span appended to the file
+// When the exporter falls behind, new spans are dropped (and counted).
+
+const (
+	serviceName   = "aistore"
+	flushInterval = 5 * time.Second
+	maxPending    = 16 * 1024
+	otlpTimeout   = 10 * time.Second
+)
+
+type (
+	exporter struct {
+		mu       sync.Mutex
+		node     string
+		spans    []*Span
+		dropped  atomic.Int64
+		flushing atomic.Bool
+		client   *http.Client
+	}
+
+	// TraceExporterFile format
+	fileSpan struct {
+		TraceID  string        `json:"trace_id"`
+		SpanID   string        `json:"span_id"`
+		ParentID string        `json:"parent_id,omitempty"`
+		Name     string        `json:"name"`
+		Node     string        `json:"node"`
+		Start    time.Time     `json:"start"`
+		Duration time.Duration `json:"duration"`
+		Attrs    cmn.SimpleKVs `json:"attrs,omitempty"`
+		Err      string        `json:"error,omitempty"`
+	}
+
+	// TraceExporterOTLP format (subset) - Ref: https://github.com/open-telemetry/opentelemetry-proto
+	otlpKV struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+		} `json:"value"`
+	}
+	otlpStatus struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	}
+	otlpSpan struct {
+		TraceID           string     `json:"traceId"`
+		SpanID            string     `json:"spanId"`
+		ParentSpanID      string     `json:"parentSpanId,omitempty"`
+		Name              string     `json:"name"`
+		Kind              int        `json:"kind"`
+		StartTimeUnixNano string     `json:"startTimeUnixNano"`
+		EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+		Attributes        []otlpKV   `json:"attributes,omitempty"`
+		Status            otlpStatus `json:"status"`
+	}
+	otlpScopeSpans struct {
+		Scope struct {
+			Name string `json:"name"`
+		} `json:"scope"`
+		Spans []otlpSpan `json:"spans"`
+	}
+	otlpResourceSpans struct {
+		Resource struct {
+			Attributes []otlpKV `json:"attributes"`
+		} `json:"resource"`
+		ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+	}
+	otlpRequest struct {
+		ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+	}
+)
+
+// OTLP status codes
+const (
+	otlpStatusOK    = 1
+	otlpStatusError = 2
+)
+
+var exp = &exporter{}
+
+// Init must be called once the node has its ID - prior to serving any requests.
+func Init(nodeID string) {
+	exp.node = nodeID
+	exp.client = cmn.NewClient(cmn.TransportArgs{Timeout: otlpTimeout})
+	hk.Reg("tracing", exp.housekeep, flushInterval)
+}
+
+func (e *exporter) add(span *Span) {
+	e.mu.Lock()
+	if len(e.spans) < maxPending {
+		e.spans = append(e.spans, span)
+	} else {
+		e.dropped.Inc()
+	}
+	e.mu.Unlock()
+}
+
+func (e *exporter) housekeep() time.Duration {
+	// export asynchronously - not to hold the housekeeper
+	if e.flushing.CAS(false, true) {
+		go func() {
+			e.flush()
+			e.flushing.Store(false)
+		}()
+	}
+	return flushInterval
+}
+
+func (e *exporter) flush() {
+	e.mu.Lock()
+	spans := e.spans
+	e.spans = nil
+	e.mu.Unlock()
+	if dropped := e.dropped.Swap(0); dropped > 0 {
+		glog.Warningf("tracing: dropped %d span(s)", dropped)
+	}
+	if len(spans) == 0 {
+		return
+	}
+
+	var (
+		err  error
+		conf = cmn.GCO.Get().Trace
+	)
+	switch conf.Exporter {
+	case cmn.TraceExporterOTLP:
+		err = e.exportOTLP(conf.Endpoint, spans)
+	case cmn.TraceExporterFile:
+		err = e.exportFile(conf.Path, spans)
+	default:
+		err = fmt.Errorf("invalid exporter %q", conf.Exporter)
+	}
+	if err != nil {
+		glog.Errorf("tracing: failed to export %d span(s): %v", len(spans), err)
+	}
+}
+
+func (e *exporter) exportFile(path string, spans []*Span) error {
+	var buf bytes.Buffer
+	for _, s := range spans {
+		buf.Write(cmn.MustMarshal(e.fileSpan(s)))
+		buf.WriteByte('\n')
+	}
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, cmn.PermRWR)
+	if err != nil {
+		return err
+	}
+	_, err = buf.WriteTo(file)
+	if errClose := file.Close(); err == nil {
+		err = errClose
+	}
+	return err
+}
+
+func (e *exporter) fileSpan(s *Span) *fileSpan {
+	fspan := &fileSpan{
+		TraceID:  s.traceID.String(),
+		SpanID:   s.spanID.String(),
+		Name:     s.name,
+		Node:     e.node,
+		Start:    s.start,
+		Duration: s.end.Sub(s.start),
+		Attrs:    s.attrs,
+		Err:      s.err,
+	}
+	if !s.parentID.IsZero() {
+		fspan.ParentID = s.parentID.String()
+	}
+	return fspan
+}
+
+func (e *exporter) exportOTLP(endpoint string, spans []*Span) error {
+	var (
+		rs = otlpResourceSpans{ScopeSpans: make([]otlpScopeSpans, 1)}
+		ss = &rs.ScopeSpans[0]
+	)
+	rs.Resource.Attributes = []otlpKV{newOtlpKV("service.name", serviceName), newOtlpKV("service.instance.id", e.node)}
+	ss.Scope.Name = serviceName
+	ss.Spans = make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		ss.Spans = append(ss.Spans, otlpFromSpan(s))
+	}
+	req := otlpRequest{ResourceSpans: []otlpResourceSpans{rs}}
+
+	resp, err := e.client.Post(endpoint, cmn.ContentJSON, bytes.NewReader(cmn.MustMarshal(&req)))
+	if err != nil {
+		return err
+	}
+	body, _ := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if resp.StatusCode >= http.StatusBadRequest {
+		return fmt.Errorf("%s: %s (%s)", endpoint, resp.Status, body)
+	}
+	return nil
+}
+
+func newOtlpKV(key, value string) (kv otlpKV) {
+	kv.Key, kv.Value.StringValue = key, value
+	return
+}
+
+func otlpFromSpan(s *Span) otlpSpan {
+	ospan := otlpSpan{
+		TraceID:           s.traceID.String(),
+		SpanID:            s.spanID.String(),
+		Name:              s.name,
+		Kind:              s.kind,
+		StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+		EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		Status:            otlpStatus{Code: otlpStatusOK},
+	}
+	if !s.parentID.IsZero() {
+		ospan.ParentSpanID = s.parentID.String()
+	}
+	if s.err != "" {
+		ospan.Status = otlpStatus{Code: otlpStatusError, Message: s.err}
+	}
+	keys := make([]string, 0, len(s.attrs))
+	for k := range s.attrs {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		ospan.Attributes = append(ospan.Attributes, newOtlpKV(k, s.attrs[k]))
+	}
+	return ospan
+}
@@ -0,0 +1,258 @@
+// Package tracing provides distributed tracing of user requests as they travel
+// through the cluster: proxy, redirect, target, intra-cluster streams, and backends.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"math/rand"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/NVIDIA/aistore/cmn"
+)
+
+// Trace context is propagated in the W3C format (https://www.w3.org/TR/trace-context):
+//  -> HTTP requests:     cmn.HeaderTraceParent header
+//  -> proxy redirects:   cmn.URLParamTraceParent query parameter
+//  -> transport streams: transport.ObjHdr.TraceParent
+//
+// Only the requests that enter the cluster are sampled - as per the (optional)
+// cmn.HeaderTrace header or, otherwise, TraceConf.SampleRatio. Redirected and
+// intra-cluster requests are traced if and only if the propagated context says so.
+// All methods of the Span are no-op when the span is nil (i.e., not sampled).
+
+const (
+	traceParentVersion = "00"
+	flagSampled        = "01"
+	traceParentLen     = 55 // version(2) - trace ID(32) - span ID(16) - flags(2), separated by dashes
+)
+
+type (
+	TraceID [16]byte
+	SpanID  [8]byte
+
+	Span struct {
+		mu       sync.Mutex
+		traceID  TraceID
+		spanID   SpanID
+		parentID SpanID // zero for the root span
+		name     string
+		kind     int // OTLP span kind
+		start    time.Time
+		end      time.Time
+		attrs    cmn.SimpleKVs
+		err      string
+	}
+
+	ctxKey struct{}
+)
+
+// OTLP span kinds
+const (
+	kindInternal = 1
+	kindServer   = 2
+)
+
+func (id TraceID) String() string { return hex.EncodeToString(id[:]) }
+func (id SpanID) String() string  { return hex.EncodeToString(id[:]) }
+func (id SpanID) IsZero() bool    { return id == SpanID{} }
+
+func newTraceID() (id TraceID) {
+	binary.BigEndian.PutUint64(id[:8], rand.Uint64())
+	binary.BigEndian.PutUint64(id[8:], rand.Uint64())
+	return
+}
+
+func newSpanID() (id SpanID) {
+	for id.IsZero() {
+		binary.BigEndian.PutUint64(id[:], rand.Uint64())
+	}
+	return
+}
+
+// ParseTraceParent parses the W3C traceparent value; `ok` is false if the value is invalid.
+func ParseTraceParent(s string) (traceID TraceID, parentID SpanID, sampled, ok bool) {
+	s = strings.TrimSpace(s)
+	if len(s) < traceParentLen || s[2] != '-' || s[35] != '-' || s[52] != '-' {
+		return
+	}
+	switch {
+	case s[:2] == "ff": // forbidden version
+		return
+	case s[:2] == traceParentVersion && len(s) != traceParentLen:
+		return
+	case len(s) > traceParentLen && s[traceParentLen] != '-':
+		return
+	}
+	if _, err := hex.Decode(traceID[:], []byte(s[3:35])); err != nil || traceID == (TraceID{}) {
+		return
+	}
+	if _, err := hex.Decode(parentID[:], []byte(s[36:52])); err != nil || parentID.IsZero() {
+		return
+	}
+	flags, err := hex.DecodeString(s[53:55])
+	if err != nil {
+		return
+	}
+	return traceID, parentID, flags[0]&1 == 1, true
+}
+
+func enabled() bool { return cmn.GCO.Get().Trace.Enabled }
+
+// StartRequest starts the server span of the incoming HTTP request.
+// Returns nil if the request is not to be traced.
+func StartRequest(r *http.Request, name string) *Span {
+	if !enabled() {
+		return nil
+	}
+	var (
+		query = r.URL.Query()
+		tp    = r.Header.Get(cmn.HeaderTraceParent)
+	)
+	if v := query.Get(cmn.URLParamTraceParent); v != "" {
+		tp = v // set by the redirecting proxy
+	}
+	if tp != "" {
+		if traceID, parentID, sampled, ok := ParseTraceParent(tp); ok {
+			if !sampled {
+				return nil
+			}
+			return newSpan(traceID, parentID, name, kindServer)
+		}
+	}
+	// not a user request that enters the cluster: the decision was made upstream
+	if query.Get(cmn.URLParamProxyID) != "" || r.Header.Get(cmn.HeaderCallerID) != "" {
+		return nil
+	}
+	if !sample(r.Header.Get(cmn.HeaderTrace)) {
+		return nil
+	}
+	return newSpan(newTraceID(), SpanID{}, name, kindServer)
+}
+
+func sample(hdr string) bool {
+	if hdr != "" {
+		if v, err := cmn.ParseBool(hdr); err == nil {
+			return v
+		}
+	}
+	ratio := cmn.GCO.Get().Trace.SampleRatio
+	return ratio > 0 && rand.Float64() < ratio
+}
+
+// StartRemote starts the span of the operation requested by another node
+// that has propagated its trace context (e.g., via transport.ObjHdr).
+func StartRemote(traceParent, name string) *Span {
+	if traceParent == "" || !enabled() {
+		return nil
+	}
+	traceID, parentID, sampled, ok := ParseTraceParent(traceParent)
+	if !ok || !sampled {
+		return nil
+	}
+	return newSpan(traceID, parentID, name, kindServer)
+}
+
+// StartSpan starts the child of the span carried by the context, if any.
+func StartSpan(ctx context.Context, name string) (context.Context, *Span) {
+	parent := FromContext(ctx)
+	if parent == nil {
+		return ctx, nil
+	}
+	span := newSpan(parent.traceID, parent.spanID, name, kindInternal)
+	return NewContext(ctx, span), span
+}
+
+func newSpan(traceID TraceID, parentID SpanID, name string, kind int) *Span {
+	return &Span{
+		traceID:  traceID,
+		spanID:   newSpanID(),
+		parentID: parentID,
+		name:     name,
+		kind:     kind,
+		start:    time.Now(),
+	}
+}
+
+// NewContext returns the context that carries the span (nil span - the context itself).
+func NewContext(ctx context.Context, span *Span) context.Context {
+	if span == nil {
+		return ctx
+	}
+	return context.WithValue(ctx, ctxKey{}, span)
+}
+
+func FromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	span, _ := ctx.Value(ctxKey{}).(*Span)
+	return span
+}
+
+// TraceParent returns the trace context to propagate, "" if there's none.
+func TraceParent(ctx context.Context) string { return FromContext(ctx).TraceParent() }
+
+// Inject adds the trace context (if any) to the outgoing request's header.
+func Inject(ctx context.Context, header http.Header) { FromContext(ctx).Inject(header) }
+
+//////////
+// Span //
+//////////
+
+func (s *Span) TraceParent() string {
+	if s == nil {
+		return ""
+	}
+	return traceParentVersion + "-" + s.traceID.String() + "-" + s.spanID.String() + "-" + flagSampled
+}
+
+func (s *Span) Inject(header http.Header) {
+	if s == nil {
+		return
+	}
+	header.Set(cmn.HeaderTraceParent, s.TraceParent())
+}
+
+func (s *Span) SetAttr(key, value string) {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if s.attrs == nil {
+		s.attrs = make(cmn.SimpleKVs, 4)
+	}
+	s.attrs[key] = value
+	s.mu.Unlock()
+}
+
+func (s *Span) SetErr(err error) {
+	if s == nil || err == nil {
+		return
+	}
+	s.mu.Lock()
+	s.err = err.Error()
+	s.mu.Unlock()
+}
+
+// End finishes the span and queues it for export.
+func (s *Span) End() {
+	if s == nil {
+		return
+	}
+	s.mu.Lock()
+	if !s.end.IsZero() {
+		s.mu.Unlock()
+		return
+	}
+	s.end = time.Now()
+	s.mu.Unlock()
+	exp.add(s)
+}
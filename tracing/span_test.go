@@ -0,0 +1,117 @@
+// Package tracing provides distributed tracing of user requests as they travel
+// through the cluster: proxy, redirect, target, intra-cluster streams, and backends.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package tracing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/devtools/tutils/tassert"
+)
+
+const testTraceParent = "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01"
+
+func setTraceConf(enabled bool, ratio float64) {
+	config := cmn.GCO.BeginUpdate()
+	config.Trace.Enabled = enabled
+	config.Trace.SampleRatio = ratio
+	cmn.GCO.CommitUpdate(config)
+}
+
+func TestParseTraceParent(t *testing.T) {
+	tests := []struct {
+		tp      string
+		sampled bool
+		ok      bool
+	}{
+		{tp: testTraceParent, sampled: true, ok: true},
+		{tp: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-00", sampled: false, ok: true},
+		{tp: "01-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-future", sampled: true, ok: true},
+		{tp: "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01-extra", ok: false},
+		{tp: "ff-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ok: false},
+		{tp: "00-00000000000000000000000000000000-00f067aa0ba902b7-01", ok: false},
+		{tp: "00-4bf92f3577b34da6a3ce929d0e0e4736-0000000000000000-01", ok: false},
+		{tp: "00-4bf92f3577b34da6a3ce929d0e0e47zz-00f067aa0ba902b7-01", ok: false},
+		{tp: "garbage", ok: false},
+		{tp: "", ok: false},
+	}
+	for _, test := range tests {
+		traceID, parentID, sampled, ok := ParseTraceParent(test.tp)
+		tassert.Errorf(t, ok == test.ok, "%q: expected ok=%t", test.tp, test.ok)
+		if !ok || !test.ok {
+			continue
+		}
+		tassert.Errorf(t, sampled == test.sampled, "%q: expected sampled=%t", test.tp, test.sampled)
+		tassert.Errorf(t, traceID.String() == test.tp[3:35], "%q: invalid trace ID %s", test.tp, traceID)
+		tassert.Errorf(t, parentID.String() == test.tp[36:52], "%q: invalid parent ID %s", test.tp, parentID)
+	}
+}
+
+func TestStartRequest(t *testing.T) {
+	newRequest := func(target string, header ...string) *http.Request {
+		r := httptest.NewRequest(http.MethodGet, target, nil)
+		for i := 0; i < len(header); i += 2 {
+			r.Header.Set(header[i], header[i+1])
+		}
+		return r
+	}
+
+	setTraceConf(false, 1)
+	tassert.Errorf(t, StartRequest(newRequest("/v1/objects/b/o"), "get") == nil, "expected no span (tracing disabled)")
+
+	setTraceConf(true, 0)
+	defer setTraceConf(false, 0)
+
+	tassert.Errorf(t, StartRequest(newRequest("/v1/objects/b/o"), "get") == nil, "expected no span (not sampled)")
+	span := StartRequest(newRequest("/v1/objects/b/o", cmn.HeaderTrace, "true"), "get")
+	tassert.Fatalf(t, span != nil, "expected span (requested via header)")
+	tassert.Errorf(t, span.parentID.IsZero(), "expected root span")
+
+	// propagated context: header and (redirect) query
+	span = StartRequest(newRequest("/v1/objects/b/o", cmn.HeaderTraceParent, testTraceParent), "get")
+	tassert.Fatalf(t, span != nil, "expected span (propagated via header)")
+	tassert.Errorf(t, span.traceID.String() == testTraceParent[3:35], "invalid trace ID %s", span.traceID)
+	tassert.Errorf(t, span.parentID.String() == testTraceParent[36:52], "invalid parent ID %s", span.parentID)
+
+	span = StartRequest(newRequest("/v1/objects/b/o?"+cmn.URLParamProxyID+"=p&"+cmn.URLParamTraceParent+"="+testTraceParent), "get")
+	tassert.Fatalf(t, span != nil, "expected span (propagated via redirect)")
+	tassert.Errorf(t, span.parentID.String() == testTraceParent[36:52], "invalid parent ID %s", span.parentID)
+
+	// redirected and intra-cluster requests are never sampled on their own
+	setTraceConf(true, 1)
+	tassert.Errorf(t, StartRequest(newRequest("/v1/objects/b/o?"+cmn.URLParamProxyID+"=p"), "get") == nil,
+		"expected no span (redirected)")
+	tassert.Errorf(t, StartRequest(newRequest("/v1/objects/b/o", cmn.HeaderCallerID, "t"), "get") == nil,
+		"expected no span (intra-cluster)")
+	tassert.Errorf(t, StartRequest(newRequest("/v1/objects/b/o", cmn.HeaderTrace, "false"), "get") == nil,
+		"expected no span (suppressed via header)")
+	tassert.Errorf(t, StartRequest(newRequest("/v1/objects/b/o"), "get") != nil, "expected span (sampled)")
+}
+
+func TestStartSpan(t *testing.T) {
+	setTraceConf(true, 0)
+	defer setTraceConf(false, 0)
+
+	ctx, span := StartSpan(context.Background(), "child")
+	tassert.Errorf(t, span == nil && TraceParent(ctx) == "", "expected no span without parent")
+
+	parent := StartRemote(testTraceParent, "parent")
+	tassert.Fatalf(t, parent != nil, "expected span")
+	ctx, span = StartSpan(NewContext(context.Background(), parent), "child")
+	tassert.Fatalf(t, span != nil, "expected child span")
+	tassert.Errorf(t, span.traceID == parent.traceID, "expected the same trace")
+	tassert.Errorf(t, span.parentID == parent.spanID, "expected %s to be the parent, got %s", parent.spanID, span.parentID)
+	tassert.Errorf(t, TraceParent(ctx) == span.TraceParent(), "expected context to carry the child span")
+
+	header := make(http.Header)
+	Inject(ctx, header)
+	traceID, parentID, sampled, ok := ParseTraceParent(header.Get(cmn.HeaderTraceParent))
+	tassert.Errorf(t, ok && sampled, "invalid injected trace context %q", header.Get(cmn.HeaderTraceParent))
+	tassert.Errorf(t, traceID == span.traceID && parentID == span.spanID, "invalid injected trace context")
+}
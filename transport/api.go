@@ -57,13 +57,15 @@ type (
 		CksumType  string // checksum type
 		CksumValue string // checksum of the object produced by given checksum type
 		Version    string // version of the object
+		Mtime      int64  // last-modified time (see cluster.LastModifiedObjMD) - nanoseconds since UNIX epoch
 	}
 	// object header
 	ObjHdr struct {
-		Bck      cmn.Bck
-		ObjName  string
-		ObjAttrs ObjectAttrs // attributes/metadata of the sent object
-		Opaque   []byte      // custom control (optional)
+		Bck         cmn.Bck
+		ObjName     string
+		ObjAttrs    ObjectAttrs // attributes/metadata of the sent object
+		Opaque      []byte      // custom control (optional)
+		TraceParent string      // trace context of the sender (optional, see tracing package)
 	}
 	// object to transmit
 	Obj struct {
@@ -47,6 +47,7 @@ func insObjHeader(hbuf []byte, hdr *ObjHdr, usePDU bool) (off int) {
 	off = insString(off, hbuf, hdr.Bck.Ns.UUID)
 	off = insByte(off, hbuf, hdr.Opaque)
 	off = insAttrs(off, hbuf, hdr.ObjAttrs)
+	off = insString(off, hbuf, hdr.TraceParent)
 	word1 := uint64(off-sizeProtoHdr) | flags
 	insUint64(0, hbuf, word1)
 	checksum := xoshiro256.Hash(word1)
@@ -105,6 +106,7 @@ func insAttrs(off int, to []byte, attr ObjectAttrs) int {
 	off = insString(off, to, attr.CksumType)
 	off = insString(off, to, attr.CksumValue)
 	off = insString(off, to, attr.Version)
+	off = insInt64(off, to, attr.Mtime)
 	return off
 }
 
@@ -134,6 +136,7 @@ func ExtObjHeader(body []byte, hlen int) (hdr ObjHdr) {
 	off, hdr.Bck.Ns.UUID = extString(off, body)
 	off, hdr.Opaque = extByte(off, body)
 	off, hdr.ObjAttrs = extAttrs(off, body)
+	off, hdr.TraceParent = extString(off, body)
 	debug.Assertf(off == hlen, "off %d, hlen %d", off, hlen)
 	return
 }
@@ -174,5 +177,6 @@ func extAttrs(off int, from []byte) (n int, attr ObjectAttrs) {
 	off, attr.CksumType = extString(off, from)
 	off, attr.CksumValue = extString(off, from)
 	off, attr.Version = extString(off, from)
+	off, attr.Mtime = extInt64(off, from)
 	return off, attr
 }
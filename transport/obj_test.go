@@ -128,8 +128,8 @@ func Example_headers() {
 	stream.Fin()
 
 	// Output:
-	// {Bck:aws://@uuid#namespace/abc ObjName:X ObjAttrs:{Atime:663346294 Size:231 CksumType:xxhash CksumValue:hash Version:2} Opaque:[]} (119)
-	// {Bck:ais://abracadabra ObjName:p/q/s ObjAttrs:{Atime:663346294 Size:213 CksumType:xxhash CksumValue:hash Version:2} Opaque:[49 50 51]} (121)
+	// {Bck:aws://@uuid#namespace/abc ObjName:X ObjAttrs:{Atime:663346294 Size:231 CksumType:xxhash CksumValue:hash Version:2} Opaque:[] TraceParent:} (127)
+	// {Bck:ais://abracadabra ObjName:p/q/s ObjAttrs:{Atime:663346294 Size:213 CksumType:xxhash CksumValue:hash Version:2} Opaque:[49 50 51] TraceParent:} (129)
 }
 
 func sendText(stream *transport.Stream, txt1, txt2 string) {
@@ -373,6 +373,7 @@ func Test_ObjAttrs(t *testing.T) {
 			CksumType:  cmn.ChecksumXXHash,
 			CksumValue: "120421",
 			Version:    "102.44",
+			Mtime:      math.MaxInt64,
 		},
 		{
 			Size:       0,
@@ -382,6 +383,7 @@ func Test_ObjAttrs(t *testing.T) {
 			Version:    "",
 		},
 	}
+	testTraceParents := []string{"", "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", ""}
 
 	ts := httptest.NewServer(objmux)
 	defer ts.Close()
@@ -394,6 +396,8 @@ func Test_ObjAttrs(t *testing.T) {
 		cmn.AssertMsg(hdr.Bck.IsAIS(), "expecting ais bucket")
 		cmn.Assertf(reflect.DeepEqual(testAttrs[idx], hdr.ObjAttrs),
 			"attrs are not equal: %v; %v;", testAttrs[idx], hdr.ObjAttrs)
+		cmn.Assertf(hdr.TraceParent == testTraceParents[idx],
+			"trace parents are not equal: %q; %q;", testTraceParents[idx], hdr.TraceParent)
 
 		written, err := io.Copy(ioutil.Discard, objReader)
 		cmn.Assert(err == nil)
@@ -417,8 +421,9 @@ func Test_ObjAttrs(t *testing.T) {
 				Bck: cmn.Bck{
 					Provider: cmn.ProviderAIS,
 				},
-				ObjAttrs: attrs,
-				Opaque:   []byte{byte(idx)},
+				ObjAttrs:    attrs,
+				Opaque:      []byte{byte(idx)},
+				TraceParent: testTraceParents[idx],
 			}
 		)
 		slab, err := MMSA.GetSlab(memsys.PageSize)
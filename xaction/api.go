@@ -86,11 +86,13 @@ var XactsDtor = map[string]XactDescriptor{
 	cmn.ActEvictObjects:   {Type: XactTypeBck, Access: cmn.AccessObjDELETE, Startable: false, Mountpath: true},
 	cmn.ActDelete:         {Type: XactTypeBck, Access: cmn.AccessObjDELETE, Startable: false, Mountpath: true},
 	cmn.ActLoadLomCache:   {Type: XactTypeBck, Startable: true, Mountpath: true},
+	cmn.ActLifecycle:      {Type: XactTypeBck, Access: cmn.AccessObjDELETE, Startable: true, Mountpath: true},
 	cmn.ActPrefetch:       {Type: XactTypeBck, Access: cmn.AccessRW, Startable: true},
 	cmn.ActPromote:        {Type: XactTypeBck, Access: cmn.AccessPROMOTE, Startable: false, RefreshCap: true},
 	cmn.ActQueryObjects:   {Type: XactTypeBck, Access: cmn.AccessObjLIST, Startable: false, Metasync: false, Owned: true},
 	cmn.ActListObjects:    {Type: XactTypeBck, Access: cmn.AccessObjLIST, Startable: false, Metasync: false, Owned: true},
 	cmn.ActSummaryBck:     {Type: XactTypeTask, Access: cmn.AccessObjLIST | cmn.AccessBckHEAD, Startable: false, Metasync: false, Owned: true, Mountpath: true},
+	cmn.ActDiffBck:        {Type: XactTypeTask, Access: cmn.AccessObjLIST | cmn.AccessBckHEAD, Startable: false, Metasync: false, Owned: true, Mountpath: true},
 	cmn.ActInvalListCache: {Type: XactTypeBck, Access: cmn.AccessObjLIST, Startable: false},
 }
 
@@ -199,6 +199,15 @@ func (r *registry) renewBckLoadLomCache(t cluster.Target, uuid string, bck *clus
 	return r.renewBucketXact(cmn.ActLoadLomCache, bck, XactArgs{T: t, UUID: uuid})
 }
 
+func RenewLifecycle(t cluster.Target, uuid string, bck *cluster.Bck) (err error) {
+	_, err = defaultReg.renewLifecycle(t, uuid, bck)
+	return
+}
+
+func (r *registry) renewLifecycle(t cluster.Target, uuid string, bck *cluster.Bck) (cluster.Xact, error) {
+	return r.renewBucketXact(cmn.ActLifecycle, bck, XactArgs{T: t, UUID: uuid})
+}
+
 func RenewPutMirror(t cluster.Target, lom *cluster.LOM) cluster.Xact {
 	return defaultReg.renewPutMirror(t, lom)
 }
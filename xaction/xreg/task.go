@@ -7,6 +7,7 @@ package xreg
 import (
 	"context"
 	"errors"
+	"strings"
 	"sync"
 	gatomic "sync/atomic"
 	"unsafe"
@@ -15,6 +16,7 @@ import (
 	"github.com/NVIDIA/aistore/cluster"
 	"github.com/NVIDIA/aistore/cmn"
 	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
 	"github.com/NVIDIA/aistore/ios"
 	"github.com/NVIDIA/aistore/objwalk"
 	"github.com/NVIDIA/aistore/xaction"
@@ -148,7 +150,12 @@ func (t *bckSummaryTask) Run() {
 				msg.Cached = true
 			}
 
-			if msg.Fast && (bck.IsAIS() || msg.Cached) {
+			if msg.DiskUsage {
+				if err := t.doBckDiskUsage(bck, &summary); err != nil {
+					errCh <- err
+					return
+				}
+			} else if msg.Fast && (bck.IsAIS() || msg.Cached) {
 				objCount, size, err := t.doBckSummaryFast(bck)
 				if err != nil {
 					errCh <- err
@@ -260,6 +267,68 @@ func (t *bckSummaryTask) doBckSummaryFast(bck *cluster.Bck) (objCount, size uint
 	return objCount, size, group.Wait()
 }
 
+// doBckDiskUsage walks all mountpaths and aggregates the sizes of the objects
+// (including mirrored copies) by prefix and mountpath.
+func (t *bckSummaryTask) doBckDiskUsage(bck *cluster.Bck, summary *cmn.BucketSummary) error {
+	type key struct{ prefix, mpath string }
+	var (
+		mtx   sync.Mutex
+		usage = make(map[key]*cmn.PrefixUsage)
+	)
+	visitObj := func(lom *cluster.LOM, _ []byte) error {
+		if !strings.HasPrefix(lom.ObjName, t.msg.Prefix) {
+			return nil
+		}
+		var (
+			k    = key{cmn.UsagePrefix(lom.ObjName, t.msg.Prefix, t.msg.Depth), lom.MpathInfo().Path}
+			size = uint64(lom.Size())
+			cnt  uint64
+		)
+		if !lom.IsCopy() {
+			cnt = 1
+		}
+		mtx.Lock()
+		u, ok := usage[k]
+		if !ok {
+			u = &cmn.PrefixUsage{Prefix: k.prefix, Target: t.t.SID(), Mountpath: k.mpath}
+			usage[k] = u
+		}
+		u.ObjCount += cnt
+		u.Size += size
+		summary.ObjCount += cnt
+		summary.Size += size
+		mtx.Unlock()
+
+		t.ObjectsAdd(int64(cnt))
+		t.BytesAdd(int64(size))
+		return nil
+	}
+	jg := mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+		T:           t.t,
+		Bck:         bck.Bck,
+		CTs:         []string{fs.ObjectType},
+		VisitObj:    visitObj,
+		DoLoad:      mpather.Load,
+		IncludeCopy: true,
+		Throttle:    true,
+	})
+	jg.Run()
+	select {
+	case <-t.ChanAbort():
+		jg.Stop()
+		return cmn.NewAbortedError(t.String())
+	case <-jg.ListenFinished():
+		if err := jg.Stop(); err != nil {
+			return err
+		}
+	}
+	summary.Usage = make([]cmn.PrefixUsage, 0, len(usage))
+	for _, u := range usage {
+		summary.Usage = append(summary.Usage, *u)
+	}
+	return nil
+}
+
 func (t *bckSummaryTask) UpdateResult(result interface{}, err error) {
 	res := &taskState{Err: err}
 	if err == nil {
@@ -276,3 +345,162 @@ func (t *bckSummaryTask) Result() (interface{}, error) {
 	}
 	return ts.Result, ts.Err
 }
+
+//
+// bckDiffTask
+//
+
+type (
+	bckDiffTask struct {
+		xaction.XactBase
+		ctx context.Context
+		t   cluster.Target
+		msg *cmn.BucketDiffMsg
+		res atomic.Pointer
+	}
+
+	bckDiffTaskEntry struct {
+		xact *bckDiffTask
+
+		ctx  context.Context
+		t    cluster.Target
+		uuid string
+		msg  *cmn.BucketDiffMsg
+	}
+)
+
+func RenewBckDiff(ctx context.Context, t cluster.Target, bck *cluster.Bck, msg *cmn.BucketDiffMsg) error {
+	return defaultReg.renewBckDiff(ctx, t, bck, msg)
+}
+
+func (r *registry) renewBckDiff(ctx context.Context, t cluster.Target, bck *cluster.Bck, msg *cmn.BucketDiffMsg) error {
+	if err := r.removeFinishedByID(msg.UUID); err != nil {
+		return err
+	}
+	e := &bckDiffTaskEntry{ctx: ctx, t: t, uuid: msg.UUID, msg: msg}
+	if err := e.Start(bck.Bck); err != nil {
+		return err
+	}
+	r.storeEntry(e)
+	return nil
+}
+
+func (e *bckDiffTaskEntry) Start(bck cmn.Bck) error {
+	xact := &bckDiffTask{
+		XactBase: *xaction.NewXactBaseBck(e.uuid, cmn.ActDiffBck, bck),
+		t:        e.t,
+		msg:      e.msg,
+		ctx:      e.ctx,
+	}
+	e.xact = xact
+	go xact.Run()
+	return nil
+}
+func (e *bckDiffTaskEntry) Kind() string      { return cmn.ActDiffBck }
+func (e *bckDiffTaskEntry) Get() cluster.Xact { return e.xact }
+
+// Run lists the objects of both buckets: in-cluster objects are listed by each
+// target (locally), while remote buckets are listed by a single target
+// designated for the task. Comparison itself is done by the proxy.
+func (t *bckDiffTask) Run() {
+	var (
+		res = &cmn.BucketDiffLists{}
+		src = cluster.NewBckEmbed(t.Bck())
+		dst = cluster.NewBckEmbed(t.msg.Dst)
+	)
+	if t.msg.Backend {
+		dst = src
+	}
+	for _, bck := range []*cluster.Bck{src, dst} {
+		if err := bck.Init(t.t.Bowner()); err != nil {
+			t.UpdateResult(nil, err)
+			return
+		}
+	}
+	si, err := cluster.HrwTargetTask(t.msg.UUID, t.t.Sowner().Get())
+	if err != nil {
+		t.UpdateResult(nil, err)
+		return
+	}
+	var (
+		designated = si.ID() == t.t.SID()
+		// in the `Backend` mode the source is the in-cluster content of the remote bucket
+		srcLoc = src.IsAIS() || t.msg.Backend
+		dstLoc = dst.IsAIS() && !t.msg.Backend
+	)
+	if srcLoc || designated {
+		if res.Src, err = t.list(src, srcLoc); err != nil {
+			t.UpdateResult(nil, err)
+			return
+		}
+	}
+	if dstLoc || designated {
+		if res.Dst, err = t.list(dst, dstLoc); err != nil {
+			t.UpdateResult(nil, err)
+			return
+		}
+	}
+	t.UpdateResult(res, nil)
+}
+
+// list returns all objects (optionally, only those stored in the cluster) of a given bucket;
+// fails if aborted or if the bucket has more than cmn.BucketDiffMaxObjects objects
+func (t *bckDiffTask) list(bck *cluster.Bck, cached bool) (entries []*cmn.BucketEntry, err error) {
+	smsg := &cmn.SelectMsg{Prefix: t.msg.Prefix}
+	smsg.AddProps(cmn.GetPropsSize, cmn.GetPropsChecksum)
+	if cached {
+		smsg.Flags = cmn.SelectCached
+	} else {
+		smsg.PageSize = t.t.Backend(bck).MaxPageSize()
+	}
+	for {
+		if t.Aborted() {
+			return nil, cmn.NewAbortedError(t.String())
+		}
+		var (
+			list *cmn.BucketList
+			walk = objwalk.NewWalk(t.ctx, t.t, bck, smsg)
+		)
+		if cached {
+			list, err = walk.DefaultLocalObjPage(smsg)
+		} else {
+			list, err = walk.RemoteObjPage()
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range list.Entries {
+			if e.IsDir() || !e.IsStatusOK() {
+				continue
+			}
+			entries = append(entries, &cmn.BucketEntry{Name: e.Name, Size: e.Size, Checksum: e.Checksum})
+			t.ObjectsInc()
+			t.BytesAdd(e.Size)
+		}
+		if len(entries) > cmn.BucketDiffMaxObjects {
+			return nil, cmn.ErrBucketDiffTooLarge
+		}
+		if list.ContinuationToken == "" {
+			break
+		}
+		smsg.ContinuationToken = list.ContinuationToken
+	}
+	return
+}
+
+func (t *bckDiffTask) UpdateResult(result interface{}, err error) {
+	res := &taskState{Err: err}
+	if err == nil {
+		res.Result = result
+	}
+	t.res.Store(unsafe.Pointer(res))
+	t.Finish(err)
+}
+
+func (t *bckDiffTask) Result() (interface{}, error) {
+	ts := (*taskState)(t.res.Load())
+	if ts == nil {
+		return nil, errors.New("no result to load")
+	}
+	return ts.Result, ts.Err
+}
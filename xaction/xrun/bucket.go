@@ -24,6 +24,7 @@ func init() {
 	xreg.RegisterBucketXact(&evictDeleteProvider{kind: cmn.ActEvictObjects})
 	xreg.RegisterBucketXact(&evictDeleteProvider{kind: cmn.ActDelete})
 	xreg.RegisterBucketXact(&PrefetchProvider{})
+	xreg.RegisterBucketXact(&lifecycleProvider{})
 }
 
 type (
@@ -0,0 +1,125 @@
+// Package runners provides implementation for the AIStore extended actions.
+/*
+ * Copyright (c) 2018-2020, NVIDIA CORPORATION. All rights reserved.
+ */
+package xrun
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/NVIDIA/aistore/3rdparty/glog"
+	"github.com/NVIDIA/aistore/cluster"
+	"github.com/NVIDIA/aistore/cmn"
+	"github.com/NVIDIA/aistore/fs"
+	"github.com/NVIDIA/aistore/fs/mpather"
+	"github.com/NVIDIA/aistore/xaction"
+	"github.com/NVIDIA/aistore/xaction/xreg"
+)
+
+// lifecycle walks the bucket and applies its lifecycle rules (see cmn.LifecycleConf)
+// to each object. The rules are taken from the current bucket props, so that
+// changes made while the xaction is running take effect immediately.
+
+type (
+	lifecycleProvider struct {
+		t    cluster.Target
+		xact *lifecycle
+		uuid string
+	}
+	lifecycle struct {
+		xaction.XactBase
+		t       cluster.Target
+		joggers *mpather.JoggerGroup
+	}
+)
+
+// interface guard
+var _ cluster.Xact = (*lifecycle)(nil)
+
+func (*lifecycleProvider) New(args xreg.XactArgs) xreg.BucketEntry {
+	return &lifecycleProvider{t: args.T, uuid: args.UUID}
+}
+
+func (p *lifecycleProvider) Start(bck cmn.Bck) error {
+	p.xact = newLifecycle(p.t, p.uuid, bck)
+	go p.xact.Run()
+	return nil
+}
+func (*lifecycleProvider) Kind() string        { return cmn.ActLifecycle }
+func (p *lifecycleProvider) Get() cluster.Xact { return p.xact }
+
+// keep running (if already running) - see xreg.BaseBckEntry
+func (p *lifecycleProvider) PreRenewHook(_ xreg.BucketEntry) (bool, error) { return true, nil }
+func (p *lifecycleProvider) PostRenewHook(_ xreg.BucketEntry)              {}
+
+func newLifecycle(t cluster.Target, uuid string, bck cmn.Bck) *lifecycle {
+	r := &lifecycle{XactBase: *xaction.NewXactBaseBck(uuid, cmn.ActLifecycle, bck), t: t}
+	r.joggers = mpather.NewJoggerGroup(&mpather.JoggerGroupOpts{
+		T:        t,
+		Bck:      bck,
+		CTs:      []string{fs.ObjectType},
+		VisitObj: r.visitObj,
+		DoLoad:   mpather.Load,
+		Throttle: true,
+	})
+	return r
+}
+
+func (r *lifecycle) Run() {
+	glog.Infoln(r.String())
+	r.joggers.Run()
+	var err error
+	select {
+	case <-r.ChanAbort():
+		r.joggers.Stop()
+		err = cmn.NewAbortedError(r.String())
+	case <-r.joggers.ListenFinished():
+		err = r.joggers.Stop()
+	}
+	r.Finish(err)
+}
+
+func (r *lifecycle) visitObj(lom *cluster.LOM, _ []byte) error {
+	var (
+		conf   = &lom.Bprops().Lifecycle
+		remote = lom.Bck().IsRemote()
+		mtime  time.Time
+	)
+	if !conf.Enabled() || lom.IsWriteBackPending() || lom.CheckRetention(false) != nil {
+		return nil
+	}
+	if conf.HasAction(cmn.LifecycleDelete) {
+		if tu := lom.LastModifiedUnix(); tu != 0 {
+			mtime = time.Unix(0, tu)
+		} else {
+			// written prior to the last-modified time being recorded
+			finfo, err := os.Stat(lom.FQN)
+			if err != nil {
+				return nil // removed in the meantime
+			}
+			mtime = finfo.ModTime()
+		}
+	}
+	action := conf.Eval(lom.ObjName, lom.Atime(), mtime, time.Now(), remote)
+	if action == "" {
+		return nil
+	}
+	size := lom.Size()
+	errCode, err := r.t.DeleteObject(context.Background(), lom, action == cmn.LifecycleEvict)
+	if err != nil {
+		// not failing the entire run because of a single object
+		if errCode != http.StatusNotFound && errCode != http.StatusConflict && errCode != http.StatusForbidden {
+			glog.Errorf("%s: failed to %s %s: %v", r, action, lom, err)
+		}
+		return nil
+	}
+	if glog.FastV(4, glog.SmoduleAIS) {
+		glog.Infof("%s: %s %s", r, action, lom)
+	}
+	r.ObjectsInc()
+	r.BytesAdd(size)
+	return nil
+}
@@ -61,6 +61,10 @@ func (r *evictDelete) doObjEvictDelete(args *xreg.DeletePrefetchArgs, objName st
 		if cmn.IsObjNotExist(err) || cmn.IsStatusNotFound(err) {
 			return nil
 		}
+		if cmn.IsErrObjLocked(err) {
+			glog.Warning(err) // skipping retained objects (see cmn.ObjLockConf)
+			return nil
+		}
 		return err
 	}
 	r.ObjectsInc()